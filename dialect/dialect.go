@@ -195,6 +195,22 @@ func (d *DebugTx) QueryContext(ctx context.Context, query string, args ...any) (
 	return drv.QueryContext(ctx, query, args...)
 }
 
+// Savepoint logs its params and calls the underlying transaction Savepoint method if it is supported.
+func (d *DebugTx) Savepoint(ctx context.Context, name string) (Tx, error) {
+	drv, ok := d.Tx.(interface {
+		Savepoint(context.Context, string) (Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.Savepoint is not supported")
+	}
+	d.log(ctx, fmt.Sprintf("Tx(%s).Savepoint: name=%v", d.id, name))
+	tx, err := drv.Savepoint(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &DebugTx{tx, d.id + "/" + name, d.log, ctx}, nil
+}
+
 // Commit logs this step and calls the underlying transaction Commit method.
 func (d *DebugTx) Commit() error {
 	d.log(d.ctx, fmt.Sprintf("Tx(%s): committed", d.id))
@@ -27,6 +27,9 @@ func Or(args ...any) *dsl.Traversal { return New().Or(args...) }
 // And is the api for calling __.And().
 func And(args ...any) *dsl.Traversal { return New().And(args...) }
 
+// Both is the api for calling __.Both().
+func Both(args ...any) *dsl.Traversal { return New().Both(args...) }
+
 // In is the api for calling __.In().
 func In(args ...any) *dsl.Traversal { return New().In(args...) }
 
@@ -51,6 +54,12 @@ func OutV(args ...any) *dsl.Traversal { return New().OutV(args...) }
 // Values is the api for calling __.Values().
 func Values(args ...string) *dsl.Traversal { return New().Values(args...) }
 
+// HasID is the api for calling __.HasID().
+func HasID(args ...any) *dsl.Traversal { return New().HasID(args...) }
+
+// Loops is the api for calling __.Loops().
+func Loops(args ...any) *dsl.Traversal { return New().Loops(args...) }
+
 // Union is the api for calling __.Union().
 func Union(args ...any) *dsl.Traversal { return New().Union(args...) }
 
@@ -226,6 +226,21 @@ func TestTraverse(t *testing.T) {
 			wantQuery: "g.V().has($0).sideEffect(__.properties($1).drop()).valueMap()",
 			wantBinds: dsl.Bindings{"$0": "age", "$1": "name"},
 		},
+		{
+			input:     g.V(1).Repeat(__.Out("knows").SimplePath()).Until(__.HasID(5)).Limit(1).Path().Unfold().ID(),
+			wantQuery: "g.V($0).repeat(__.out($1).simplePath()).until(__.hasId($2)).limit($3).path().unfold().id()",
+			wantBinds: dsl.Bindings{"$0": 1, "$1": "knows", "$2": 5, "$3": 1},
+		},
+		{
+			input:     g.V(1).Repeat(__.In("knows")).Until(__.Or(__.HasID(5), __.Loops().Is(3))).HasID(5),
+			wantQuery: "g.V($0).repeat(__.in($1)).until(__.or(__.hasId($2), __.loops().is($3))).hasId($4)",
+			wantBinds: dsl.Bindings{"$0": 1, "$1": "knows", "$2": 5, "$3": 3, "$4": 5},
+		},
+		{
+			input:     g.V(1).Repeat(__.Out("knows")).Times(2).Values("name"),
+			wantQuery: "g.V($0).repeat(__.out($1)).times($2).values($3)",
+			wantBinds: dsl.Bindings{"$0": 1, "$1": "knows", "$2": 2, "$3": "name"},
+		},
 	}
 	for i, tt := range tests {
 		tt := tt
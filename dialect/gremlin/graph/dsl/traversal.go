@@ -345,6 +345,37 @@ func (t *Traversal) SideEffect(args ...any) *Traversal {
 	return t.Add(Dot, NewFunc("sideEffect", args...))
 }
 
+// Repeat loops over the given traversal until a break predicate is satisfied,
+// usually configured using the Until or the Times steps.
+func (t *Traversal) Repeat(args ...any) *Traversal {
+	return t.Add(Dot, NewFunc("repeat", args...))
+}
+
+// Until configures the break predicate of the Repeat step.
+func (t *Traversal) Until(args ...any) *Traversal {
+	return t.Add(Dot, NewFunc("until", args...))
+}
+
+// Times configures the number of loops of the Repeat step.
+func (t *Traversal) Times(args ...any) *Traversal {
+	return t.Add(Dot, NewFunc("times", args...))
+}
+
+// Loops extracts the number of times the traverser has gone through the current loop.
+func (t *Traversal) Loops(args ...any) *Traversal {
+	return t.Add(Dot, NewFunc("loops", args...))
+}
+
+// Path maps the traverser to the history of objects it has visited.
+func (t *Traversal) Path() *Traversal {
+	return t.Add(Dot, NewFunc("path"))
+}
+
+// SimplePath filters the traversers whose path contains repeated objects (i.e. cycles).
+func (t *Traversal) SimplePath() *Traversal {
+	return t.Add(Dot, NewFunc("simplePath"))
+}
+
 // Each is a Groovy each-loop function.
 func Each(v any, cb func(it *Traversal) *Traversal) *Traversal {
 	t := &Traversal{}
@@ -2035,10 +2035,17 @@ func (*queryView) view() {}
 // SelectTable is a table selector.
 type SelectTable struct {
 	Builder
-	as     string
-	name   string
-	schema string
-	quote  bool
+	as      string
+	name    string
+	schema  string
+	quote   bool
+	indexes []indexHint
+}
+
+// indexHint is an index hint of a table reference.
+type indexHint struct {
+	kind    string
+	indexes []string
 }
 
 // Table returns a new table selector.
@@ -2092,19 +2099,63 @@ func (s *SelectTable) Unquote() *SelectTable {
 	return s
 }
 
-// ref returns the table reference.
-func (s *SelectTable) ref() string {
+// UseIndex adds a `USE INDEX` hint to the table reference, that tells MySQL to use only
+// one of the given indexes for finding rows in the table. In SQLite, it is translated to
+// `INDEXED BY`, which accepts exactly one index. Index hints are not supported in PostgreSQL.
+//
+//	t1 := Table("users").UseIndex("users_name_idx")
+//	Select().From(t1)
+func (s *SelectTable) UseIndex(indexes ...string) *SelectTable {
+	s.indexes = append(s.indexes, indexHint{kind: "USE", indexes: indexes})
+	return s
+}
+
+// ForceIndex adds a `FORCE INDEX` hint to the table reference. It is similar to UseIndex,
+// except that a table scan is assumed to be very expensive. In SQLite, it is translated to
+// `INDEXED BY`, which accepts exactly one index.
+func (s *SelectTable) ForceIndex(indexes ...string) *SelectTable {
+	s.indexes = append(s.indexes, indexHint{kind: "FORCE", indexes: indexes})
+	return s
+}
+
+// IgnoreIndex adds an `IGNORE INDEX` hint to the table reference, that tells MySQL not to use
+// the given indexes. In SQLite, it is translated to `NOT INDEXED`, which ignores all indexes.
+func (s *SelectTable) IgnoreIndex(indexes ...string) *SelectTable {
+	s.indexes = append(s.indexes, indexHint{kind: "IGNORE", indexes: indexes})
+	return s
+}
+
+// ref writes the table reference to the given builder.
+func (s *SelectTable) ref(b *Builder) {
 	if !s.quote {
-		return s.name
+		b.WriteString(s.name)
+	} else {
+		b.writeSchema(s.schema)
+		b.Ident(s.name)
+		if s.as != "" {
+			b.WriteString(" AS ")
+			b.Ident(s.as)
+		}
 	}
-	b := &Builder{dialect: s.dialect}
-	b.writeSchema(s.schema)
-	b.Ident(s.name)
-	if s.as != "" {
-		b.WriteString(" AS ")
-		b.Ident(s.as)
+	for _, h := range s.indexes {
+		switch {
+		case b.postgres():
+			b.AddError(fmt.Errorf("sql: index hints are not supported in %s", b.dialect))
+		case b.sqlite() && h.kind == "IGNORE":
+			b.WriteString(" NOT INDEXED")
+		case b.sqlite():
+			if len(h.indexes) != 1 {
+				b.AddError(fmt.Errorf("sql: INDEXED BY expects exactly one index, got %d", len(h.indexes)))
+				continue
+			}
+			b.WriteString(" INDEXED BY ").Ident(h.indexes[0])
+		default:
+			b.WriteString(" " + h.kind + " INDEX ")
+			b.Wrap(func(b *Builder) {
+				b.IdentComma(h.indexes...)
+			})
+		}
 	}
-	return b.String()
 }
 
 // implement the table view.
@@ -2148,6 +2199,7 @@ type Selector struct {
 	setOps    []setOp
 	prefix    Queries
 	lock      *LockOptions
+	hints     []string
 }
 
 // WithContext sets the context into the *Selector.
@@ -2697,6 +2749,72 @@ func (s *Selector) ForUpdate(opts ...LockOption) *Selector {
 	return s.For(LockUpdate, opts...)
 }
 
+// Hint adds optimizer hints to the statement. The hints are written in a `/*+ ... */` comment,
+// right after the SELECT keyword in MySQL and SQLite, and at the beginning of the statement in
+// PostgreSQL, where they are read by the pg_hint_plan extension.
+//
+//	Select().
+//		From(Table("users")).
+//		Hint("MAX_EXECUTION_TIME(1000)")
+func (s *Selector) Hint(hints ...string) *Selector {
+	s.hints = append(s.hints, hints...)
+	return s
+}
+
+// QueryHint is an optimizer or an index hint that can be applied on a Selector.
+// It is used by generated query builders to accept hints in a dialect-agnostic way.
+type QueryHint func(*Selector)
+
+// OptimizerHint returns a QueryHint that adds the given optimizer hints to the selector.
+func OptimizerHint(hints ...string) QueryHint {
+	return func(s *Selector) {
+		s.Hint(hints...)
+	}
+}
+
+// UseIndex returns a QueryHint that adds a `USE INDEX` hint to the
+// table of the selector. See SelectTable.UseIndex for more info.
+func UseIndex(indexes ...string) QueryHint {
+	return indexQueryHint((*SelectTable).UseIndex, indexes)
+}
+
+// ForceIndex returns a QueryHint that adds a `FORCE INDEX` hint to the
+// table of the selector. See SelectTable.ForceIndex for more info.
+func ForceIndex(indexes ...string) QueryHint {
+	return indexQueryHint((*SelectTable).ForceIndex, indexes)
+}
+
+// IgnoreIndex returns a QueryHint that adds an `IGNORE INDEX` hint to the
+// table of the selector. See SelectTable.IgnoreIndex for more info.
+func IgnoreIndex(indexes ...string) QueryHint {
+	return indexQueryHint((*SelectTable).IgnoreIndex, indexes)
+}
+
+// indexQueryHint returns a QueryHint that applies the given index hint on the
+// table of the selector, and fails if the selector does not select from a table.
+// The hint is validated before it is applied, because the errors of table references
+// are reported only when the query is built, after the selector errors were checked.
+func indexQueryHint(hint func(*SelectTable, ...string) *SelectTable, indexes []string) QueryHint {
+	return func(s *Selector) {
+		if len(s.from) == 0 {
+			s.AddError(errors.New("sql: index hints require a table in the FROM clause"))
+			return
+		}
+		t, ok := s.from[0].(*SelectTable)
+		if !ok {
+			s.AddError(fmt.Errorf("sql: index hints are not supported on %T", s.from[0]))
+			return
+		}
+		b := Builder{dialect: s.dialect}
+		hint(Table(t.name), indexes...).ref(&b)
+		if err := b.Err(); err != nil {
+			s.AddError(err)
+			return
+		}
+		hint(t, indexes...)
+	}
+}
+
 // Clone returns a duplicate of the selector, including all associated steps. It can be
 // used to prepare common SELECT statements and use them differently after the clone is made.
 func (s *Selector) Clone() *Selector {
@@ -2722,7 +2840,9 @@ func (s *Selector) Clone() *Selector {
 		joins:     append([]join{}, joins...),
 		group:     append([]string{}, s.group...),
 		order:     append([]any{}, s.order...),
+		prefix:    append(Queries{}, s.prefix...),
 		selection: append([]any{}, s.selection...),
+		hints:     append([]string{}, s.hints...),
 	}
 }
 
@@ -2783,6 +2903,23 @@ func (s *Selector) ClearOrder() *Selector {
 	return s
 }
 
+// OrderExprs returns the ORDER BY clause of the Selector as a list of expressions.
+// Unlike OrderColumns, columns added with OrderBy are returned as expressions too.
+func (s *Selector) OrderExprs() []Querier {
+	exprs := make([]Querier, 0, len(s.order))
+	for i := range s.order {
+		switch r := s.order[i].(type) {
+		case string:
+			exprs = append(exprs, ExprFunc(func(b *Builder) {
+				b.Ident(r)
+			}))
+		case Querier:
+			exprs = append(exprs, r)
+		}
+	}
+	return exprs
+}
+
 // GroupBy appends the `GROUP BY` clause to the `SELECT` statement.
 func (s *Selector) GroupBy(columns ...string) *Selector {
 	s.group = append(s.group, columns...)
@@ -2798,8 +2935,14 @@ func (s *Selector) Having(p *Predicate) *Selector {
 // Query returns query representation of a `SELECT` statement.
 func (s *Selector) Query() (string, []any) {
 	b := s.Builder.clone()
+	if b.postgres() {
+		s.joinHints(&b)
+	}
 	s.joinPrefix(&b)
 	b.WriteString("SELECT ")
+	if !b.postgres() {
+		s.joinHints(&b)
+	}
 	if s.distinct {
 		b.WriteString("DISTINCT ")
 	}
@@ -2818,7 +2961,7 @@ func (s *Selector) Query() (string, []any) {
 		switch t := from.(type) {
 		case *SelectTable:
 			t.SetDialect(s.dialect)
-			b.WriteString(t.ref())
+			t.ref(&b)
 		case *Selector:
 			t.SetDialect(s.dialect)
 			b.Wrap(func(b *Builder) {
@@ -2838,7 +2981,7 @@ func (s *Selector) Query() (string, []any) {
 		switch view := join.table.(type) {
 		case *SelectTable:
 			view.SetDialect(s.dialect)
-			b.WriteString(view.ref())
+			view.ref(&b)
 		case *Selector:
 			view.SetDialect(s.dialect)
 			b.Wrap(func(b *Builder) {
@@ -2892,6 +3035,12 @@ func (s *Selector) joinPrefix(b *Builder) {
 	}
 }
 
+func (s *Selector) joinHints(b *Builder) {
+	if len(s.hints) > 0 {
+		b.WriteString("/*+ ").WriteString(strings.Join(s.hints, " ")).WriteString(" */ ")
+	}
+}
+
 func (s *Selector) joinLock(b *Builder) {
 	if s.lock == nil {
 		return
@@ -2919,7 +3068,7 @@ func (s *Selector) joinSetOps(b *Builder) {
 		switch view := op.TableView.(type) {
 		case *SelectTable:
 			view.SetDialect(s.dialect)
-			b.WriteString(view.ref())
+			view.ref(b)
 		case *Selector:
 			view.SetDialect(s.dialect)
 			b.Join(view)
@@ -3043,26 +3192,27 @@ func (w *WithBuilder) C(column string) string {
 
 // Query returns query representation of a `WITH` clause.
 func (w *WithBuilder) Query() (string, []any) {
-	w.WriteString("WITH ")
+	b := w.Builder.clone()
+	b.WriteString("WITH ")
 	if w.recursive {
-		w.WriteString("RECURSIVE ")
+		b.WriteString("RECURSIVE ")
 	}
 	for i, cte := range w.ctes {
 		if i > 0 {
-			w.Comma()
+			b.Comma()
 		}
-		w.Ident(cte.name)
+		b.Ident(cte.name)
 		if len(cte.columns) > 0 {
-			w.WriteByte('(')
-			w.IdentComma(cte.columns...)
-			w.WriteByte(')')
+			b.WriteByte('(')
+			b.IdentComma(cte.columns...)
+			b.WriteByte(')')
 		}
-		w.WriteString(" AS ")
-		w.Wrap(func(b *Builder) {
+		b.WriteString(" AS ")
+		b.Wrap(func(b *Builder) {
 			b.Join(cte.s)
 		})
 	}
-	return w.String(), w.args
+	return b.String(), b.args
 }
 
 // implement the table view interface.
@@ -3796,6 +3946,17 @@ func (d *DialectBuilder) With(name string) *WithBuilder {
 	return b
 }
 
+// WithRecursive creates a WithBuilder for the `WITH RECURSIVE` statement for the configured dialect.
+//
+//	Dialect(dialect.Postgres).
+//		WithRecursive("users_view", "id").
+//		As(Select("id").From(Table("users")))
+func (d *DialectBuilder) WithRecursive(name string, columns ...string) *WithBuilder {
+	b := WithRecursive(name, columns...)
+	b.SetDialect(d.dialect)
+	return b
+}
+
 // CreateIndex creates a IndexBuilder for the configured dialect.
 //
 //	Dialect(dialect.Postgres).
@@ -1692,6 +1692,19 @@ func TestSelector_ClearOrder(t *testing.T) {
 	require.Empty(t, args)
 }
 
+func TestSelector_OrderExprs(t *testing.T) {
+	s := Select("*").
+		From(Table("users")).
+		OrderBy(Desc("name")).
+		OrderExpr(Expr("f(`id`)"))
+	query, args := Select("*").
+		From(Table("users")).
+		OrderExpr(s.OrderExprs()...).
+		Query()
+	require.Equal(t, "SELECT * FROM `users` ORDER BY `name` DESC, f(`id`)", query)
+	require.Empty(t, args)
+}
+
 func TestSelector_SelectExpr(t *testing.T) {
 	query, args := SelectExpr(
 		Expr("?", "a"),
@@ -1846,6 +1859,18 @@ func TestSelector_SetOperatorWithRecursive(t *testing.T) {
 	require.Nil(t, args)
 }
 
+func TestSelector_ClonePrefix(t *testing.T) {
+	b := Dialect(dialect.Postgres)
+	with := b.WithRecursive("nodes", "id").As(b.Select("id").From(b.Table("files")))
+	s := b.Select("*").From(b.Table("nodes")).Prefix(with)
+	query, args := s.Clone().Where(EQ("id", 1)).Query()
+	require.Equal(t, `WITH RECURSIVE "nodes"("id") AS (SELECT "id" FROM "files") SELECT * FROM "nodes" WHERE "id" = $1`, query)
+	require.Equal(t, []any{1}, args)
+	query, args = s.Query()
+	require.Equal(t, `WITH RECURSIVE "nodes"("id") AS (SELECT "id" FROM "files") SELECT * FROM "nodes"`, query)
+	require.Nil(t, args)
+}
+
 func TestBuilderContext(t *testing.T) {
 	type key string
 	want := "myval"
@@ -1938,6 +1963,79 @@ func TestSelectWithLock(t *testing.T) {
 	require.EqualError(t, s.Err(), "sql: SELECT .. FOR UPDATE/SHARE not supported in SQLite")
 }
 
+func TestSelectWithHints(t *testing.T) {
+	query, args := Dialect(dialect.MySQL).
+		Select("name").
+		From(Table("users").UseIndex("users_name", "users_age")).
+		Where(EQ("id", 1)).
+		Hint("MAX_EXECUTION_TIME(1000)", "NO_ICP(users)").
+		Query()
+	require.Equal(t, "SELECT /*+ MAX_EXECUTION_TIME(1000) NO_ICP(users) */ `name` FROM `users` USE INDEX (`users_name`, `users_age`) WHERE `id` = ?", query)
+	require.Equal(t, []any{1}, args)
+
+	users, pets := Table("users").As("u").ForceIndex("PRIMARY"), Table("pets").IgnoreIndex("pets_owner")
+	query, _ = Dialect(dialect.MySQL).
+		Select().
+		Distinct().
+		From(users).
+		Join(pets).
+		On(users.C("id"), pets.C("owner_id")).
+		Query()
+	require.Equal(t, "SELECT DISTINCT * FROM `users` AS `u` FORCE INDEX (`PRIMARY`) JOIN `pets` AS `t1` IGNORE INDEX (`pets_owner`) ON `u`.`id` = `t1`.`owner_id`", query)
+
+	query, _ = Dialect(dialect.SQLite).
+		Select().
+		From(Table("users").UseIndex("users_name")).
+		Join(Table("pets").IgnoreIndex()).
+		On("id", "owner_id").
+		Query()
+	require.Equal(t, "SELECT * FROM `users` INDEXED BY `users_name` JOIN `pets` AS `t1` NOT INDEXED ON `id` = `owner_id`", query)
+
+	s := Dialect(dialect.SQLite).
+		Select().
+		From(Table("users").UseIndex("users_name", "users_age"))
+	s.Query()
+	require.EqualError(t, s.Err(), "sql: INDEXED BY expects exactly one index, got 2")
+
+	query, args = Dialect(dialect.Postgres).
+		Select().
+		Prefix(With("active").As(Select("id").From(Table("users")).Where(EQ("active", true)))).
+		From(Table("active")).
+		Hint("SeqScan(users)").
+		Query()
+	require.Equal(t, `/*+ SeqScan(users) */ WITH "active" AS (SELECT "id" FROM "users" WHERE "active") SELECT * FROM "active"`, query)
+	require.Empty(t, args)
+
+	s = Dialect(dialect.Postgres).
+		Select().
+		From(Table("users").UseIndex("users_name"))
+	s.Query()
+	require.EqualError(t, s.Err(), "sql: index hints are not supported in postgres")
+
+	s = Dialect(dialect.MySQL).Select().From(Table("users"))
+	for _, h := range []QueryHint{OptimizerHint("MAX_EXECUTION_TIME(100)"), ForceIndex("users_name"), IgnoreIndex("users_age")} {
+		h(s)
+	}
+	query, _ = s.Query()
+	require.Equal(t, "SELECT /*+ MAX_EXECUTION_TIME(100) */ * FROM `users` FORCE INDEX (`users_name`) IGNORE INDEX (`users_age`)", query)
+	s = Select().From(Select().From(Table("users")).As("u"))
+	UseIndex("users_name")(s)
+	require.EqualError(t, s.Err(), "sql: index hints are not supported on *sql.Selector")
+	// Invalid hints fail the selector before the query is built.
+	s = Dialect(dialect.SQLite).Select().From(Table("users"))
+	UseIndex("users_name", "users_age")(s)
+	require.EqualError(t, s.Err(), "sql: INDEXED BY expects exactly one index, got 2")
+	s = Dialect(dialect.Postgres).Select().From(Table("users"))
+	ForceIndex("users_name")(s)
+	require.EqualError(t, s.Err(), "sql: index hints are not supported in postgres")
+
+	s = Select().From(Table("users")).Hint("BKA(users)")
+	query, _ = s.Clone().Hint("NO_ICP(users)").Query()
+	require.Equal(t, "SELECT /*+ BKA(users) NO_ICP(users) */ * FROM `users`", query)
+	query, _ = s.Query()
+	require.Equal(t, "SELECT /*+ BKA(users) */ * FROM `users`", query)
+}
+
 func TestSelector_UnionOrderBy(t *testing.T) {
 	table := Table("users")
 	query, _ := Dialect(dialect.Postgres).
@@ -9,7 +9,9 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jogly/ent/dialect"
 )
@@ -21,26 +23,33 @@ type Driver struct {
 }
 
 // NewDriver creates a new Driver with the given Conn and dialect.
-func NewDriver(dialect string, c Conn) *Driver {
-	return &Driver{dialect: dialect, Conn: c}
+func NewDriver(dialect string, c Conn, opts ...DriverOption) *Driver {
+	d := &Driver{dialect: dialect, Conn: c}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 // Open wraps the database/sql.Open method and returns a dialect.Driver that implements the an ent/dialect.Driver interface.
-func Open(dialect, source string) (*Driver, error) {
+func Open(dialect, source string, opts ...DriverOption) (*Driver, error) {
 	db, err := sql.Open(dialect, source)
 	if err != nil {
 		return nil, err
 	}
-	return NewDriver(dialect, Conn{db}), nil
+	return NewDriver(dialect, Conn{db}, opts...), nil
 }
 
 // OpenDB wraps the given database/sql.DB method with a Driver.
-func OpenDB(dialect string, db *sql.DB) *Driver {
-	return NewDriver(dialect, Conn{db})
+func OpenDB(dialect string, db *sql.DB, opts ...DriverOption) *Driver {
+	return NewDriver(dialect, Conn{db}, opts...)
 }
 
 // DB returns the underlying *sql.DB instance.
 func (d Driver) DB() *sql.DB {
+	if c, ok := d.ExecQuerier.(interface{ DB() *sql.DB }); ok {
+		return c.DB()
+	}
 	return d.ExecQuerier.(*sql.DB)
 }
 
@@ -66,19 +75,295 @@ func (d *Driver) BeginTx(ctx context.Context, opts *TxOptions) (dialect.Tx, erro
 	if err != nil {
 		return nil, err
 	}
+	conn := Conn{tx}
+	if c, ok := d.ExecQuerier.(*stmtCache); ok {
+		conn = Conn{c.tx(tx)}
+	}
 	return &Tx{
-		Conn: Conn{tx},
-		Tx:   tx,
+		Conn:    conn,
+		Tx:      tx,
+		dialect: d.Dialect(),
 	}, nil
 }
 
-// Close closes the underlying connection.
-func (d *Driver) Close() error { return d.DB().Close() }
+// Close closes the underlying connection, and the cached statements, if any.
+func (d *Driver) Close() error {
+	if c, ok := d.ExecQuerier.(*stmtCache); ok {
+		c.close()
+	}
+	return d.DB().Close()
+}
+
+// Exec executes the statement using the connection pool. If the context holds a statement
+// timeout (see WithStatementTimeout), the statement is executed with the timeout.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	timeout, ok := statementTimeout(ctx)
+	switch {
+	case !ok:
+		return d.Conn.Exec(ctx, query, args, v)
+	case d.Dialect() != dialect.Postgres:
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return d.Conn.Exec(ctx, query, args, v)
+	}
+	tx, err := d.beginTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
+	if err := tx.Conn.Exec(ctx, query, args, v); err != nil {
+		return rollback(tx, err)
+	}
+	return tx.Commit()
+}
+
+// Query executes the query using the connection pool. If the context holds a statement
+// timeout (see WithStatementTimeout), the query is executed with the timeout, and the
+// timeout covers the iteration of the returned rows, until they are closed.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	timeout, ok := statementTimeout(ctx)
+	switch {
+	case !ok:
+		return d.Conn.Query(ctx, query, args, v)
+	case d.Dialect() != dialect.Postgres:
+		return queryTimeout(ctx, d.Conn, timeout, query, args, v)
+	}
+	tx, err := d.beginTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
+	if err := tx.Conn.Query(ctx, query, args, v); err != nil {
+		return rollback(tx, err)
+	}
+	rows := v.(*Rows)
+	rows.ColumnScanner = &closeRows{ColumnScanner: rows.ColumnScanner, close: tx.Commit}
+	return nil
+}
+
+// beginTimeout starts a PostgreSQL transaction, and sets its statement timeout.
+func (d *Driver) beginTimeout(ctx context.Context, timeout time.Duration) (*Tx, error) {
+	tx, err := d.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	t := tx.(*Tx)
+	if err := t.Conn.Exec(ctx, setTimeout(timeout), []any{}, nil); err != nil {
+		return nil, rollback(t, err)
+	}
+	return t, nil
+}
 
 // Tx implements dialect.Tx interface.
 type Tx struct {
 	Conn
 	driver.Tx
+	dialect string
+}
+
+// Exec executes the statement in the transaction. If the context holds a statement timeout
+// (see WithStatementTimeout), the statement is executed with the timeout. In PostgreSQL, the
+// previous timeout of the transaction is restored after the statement was executed.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	timeout, ok := statementTimeout(ctx)
+	switch {
+	case !ok:
+		return t.Conn.Exec(ctx, query, args, v)
+	case t.dialect != dialect.Postgres:
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return t.Conn.Exec(ctx, query, args, v)
+	}
+	reset, err := t.setTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
+	if err := t.Conn.Exec(ctx, query, args, v); err != nil {
+		return err
+	}
+	return reset()
+}
+
+// Query executes the query in the transaction. If the context holds a statement timeout
+// (see WithStatementTimeout), the query is executed with the timeout. In PostgreSQL, the
+// previous timeout of the transaction is restored after the returned rows were closed.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	timeout, ok := statementTimeout(ctx)
+	switch {
+	case !ok:
+		return t.Conn.Query(ctx, query, args, v)
+	case t.dialect != dialect.Postgres:
+		return queryTimeout(ctx, t.Conn, timeout, query, args, v)
+	}
+	reset, err := t.setTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
+	if err := t.Conn.Query(ctx, query, args, v); err != nil {
+		return err
+	}
+	rows := v.(*Rows)
+	rows.ColumnScanner = &closeRows{ColumnScanner: rows.ColumnScanner, close: reset}
+	return nil
+}
+
+// setTimeout sets the statement timeout of the PostgreSQL transaction, and
+// returns a function that restores the timeout that was set before.
+func (t *Tx) setTimeout(ctx context.Context, timeout time.Duration) (func() error, error) {
+	rows := &Rows{}
+	if err := t.Conn.Query(ctx, "SHOW statement_timeout", []any{}, rows); err != nil {
+		return nil, err
+	}
+	prev, err := ScanString(rows)
+	if cerr := rows.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Conn.Exec(ctx, setTimeout(timeout), []any{}, nil); err != nil {
+		return nil, err
+	}
+	return func() error {
+		return t.Conn.Exec(ctx, "SELECT set_config('statement_timeout', $1, true)", []any{prev}, nil)
+	}, nil
+}
+
+// Commit commits the transaction. Errors that were classified by ClassifyError,
+// such as serialization failures, are returned as *Error.
+func (t *Tx) Commit() error {
+	return wrapError(t.Tx.Commit())
+}
+
+// Savepoint starts a nested transaction by creating a savepoint with the given name, which
+// must be a valid identifier. Committing the returned transaction releases the savepoint, and
+// rolling it back undoes the changes that were made after the savepoint was created, without
+// aborting the transaction. Savepoints are supported by MySQL, PostgreSQL and SQLite.
+func (t *Tx) Savepoint(ctx context.Context, name string) (dialect.Tx, error) {
+	if _, err := t.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, err
+	}
+	return &Tx{
+		Conn:    t.Conn,
+		Tx:      &savepoint{ctx: ctx, conn: t.Conn, name: name},
+		dialect: t.dialect,
+	}, nil
+}
+
+// savepoint implements the driver.Tx interface for savepoints.
+type savepoint struct {
+	ctx  context.Context
+	conn Conn
+	name string
+	done bool
+}
+
+// Commit releases the savepoint.
+func (s *savepoint) Commit() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	_, err := s.conn.ExecContext(s.ctx, "RELEASE SAVEPOINT "+s.name)
+	return err
+}
+
+// Rollback rolls back the transaction to the savepoint, and releases it.
+func (s *savepoint) Rollback() error {
+	if s.done {
+		return sql.ErrTxDone
+	}
+	s.done = true
+	if _, err := s.conn.ExecContext(s.ctx, "ROLLBACK TO SAVEPOINT "+s.name); err != nil {
+		return err
+	}
+	_, err := s.conn.ExecContext(s.ctx, "RELEASE SAVEPOINT "+s.name)
+	return err
+}
+
+// timeoutKey is the context key for the statement timeout.
+type timeoutKey struct{}
+
+// WithStatementTimeout returns a new context that limits the execution time of the statements
+// that are executed with it by the Driver and its transactions to d, without cancelling the given
+// context. In PostgreSQL, the timeout is set using `SET LOCAL statement_timeout`, and statements
+// that are executed outside of a transaction are executed in a transaction of their own. Hence,
+// such statements cost three additional round-trips (BEGIN, SET LOCAL and COMMIT), and statements
+// in a transaction cost three as well (SHOW, SET LOCAL and restoring the previous timeout). Use a
+// context deadline, or StatementDeadline, where this cost matters. In other dialects, statements
+// are executed with a context deadline.
+//
+// Note that in MySQL, the execution time of SELECT statements can be also limited using the
+// MAX_EXECUTION_TIME optimizer hint. See Selector.Hint for more info.
+func WithStatementTimeout(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, timeoutKey{}, d)
+}
+
+// statementTimeout returns the statement timeout that is stored in the context, if any.
+func statementTimeout(ctx context.Context) (time.Duration, bool) {
+	d, ok := ctx.Value(timeoutKey{}).(time.Duration)
+	return d, ok && d > 0
+}
+
+// setTimeout returns the statement for setting the statement timeout of a PostgreSQL transaction.
+func setTimeout(d time.Duration) string {
+	return "SET LOCAL statement_timeout = " + strconv.FormatInt(d.Milliseconds(), 10)
+}
+
+// StatementDeadline returns a context in which the statement timeout that is stored in the given
+// context (see WithStatementTimeout), if any, is applied as a context deadline in all dialects.
+// It is used by callers that require the rows of a query to be the standard *sql.Rows, as the rows
+// of PostgreSQL queries that are executed with a statement timeout are wrapped by the driver for
+// restoring the timeout once they are closed. Like context.WithTimeout, the returned cancel function
+// releases the resources of the deadline, and should be called once the rows were closed.
+func StatementDeadline(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout, ok := statementTimeout(ctx)
+	if !ok {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(context.WithValue(ctx, timeoutKey{}, time.Duration(0)), timeout)
+}
+
+// queryTimeout executes the query with a context deadline. The deadline is
+// released once the returned rows were closed, or if the query failed.
+func queryTimeout(ctx context.Context, c Conn, timeout time.Duration, query string, args, v any) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	if err := c.Query(ctx, query, args, v); err != nil {
+		cancel()
+		return err
+	}
+	rows := v.(*Rows)
+	rows.ColumnScanner = &closeRows{ColumnScanner: rows.ColumnScanner, close: func() error {
+		cancel()
+		return nil
+	}}
+	return nil
+}
+
+// closeRows wraps a ColumnScanner with a function that is called after it was closed.
+type closeRows struct {
+	ColumnScanner
+	close func() error
+	done  bool
+}
+
+// Close closes the rows, and calls the close function once.
+func (r *closeRows) Close() error {
+	err := r.ColumnScanner.Close()
+	if r.done {
+		return err
+	}
+	r.done = true
+	if cerr := r.close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// rollback rolls back the given transaction, and returns the error that caused it.
+func rollback(tx dialect.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		err = fmt.Errorf("%w: %v", err, rerr)
+	}
+	return err
 }
 
 // ExecQuerier wraps the standard Exec and Query methods.
@@ -101,12 +386,12 @@ func (c Conn) Exec(ctx context.Context, query string, args, v any) error {
 	switch v := v.(type) {
 	case nil:
 		if _, err := c.ExecContext(ctx, query, argv...); err != nil {
-			return err
+			return wrapError(err)
 		}
 	case *sql.Result:
 		res, err := c.ExecContext(ctx, query, argv...)
 		if err != nil {
-			return err
+			return wrapError(err)
 		}
 		*v = res
 	default:
@@ -127,7 +412,7 @@ func (c Conn) Query(ctx context.Context, query string, args, v any) error {
 	}
 	rows, err := c.QueryContext(ctx, query, argv...)
 	if err != nil {
-		return err
+		return wrapError(err)
 	}
 	*vr = Rows{rows}
 	return nil
@@ -154,6 +439,19 @@ type (
 	TxOptions = sql.TxOptions
 )
 
+// Err returns the error, if any, that was encountered during iteration.
+// Errors that were classified by ClassifyError are returned as *Error.
+func (r Rows) Err() error {
+	return wrapError(r.ColumnScanner.Err())
+}
+
+// Close closes the rows, and returns the error, if any, that was encountered
+// while closing them. Errors that were classified by ClassifyError are returned
+// as *Error.
+func (r Rows) Close() error {
+	return wrapError(r.ColumnScanner.Close())
+}
+
 // NullScanner represents an sql.Scanner that may be null.
 // NullScanner implements the sql.Scanner interface so it can
 // be used as a scan destination, similar to the types above.
@@ -0,0 +1,130 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jogly/ent/dialect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTx_Savepoint(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	drv := OpenDB(dialect.SQLite, db)
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("SAVEPOINT sp2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp2").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("RELEASE SAVEPOINT sp1").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	sp1, err := tx.(*Tx).Savepoint(ctx, "sp1")
+	require.NoError(t, err)
+	require.NoError(t, sp1.Exec(ctx, "INSERT INTO users DEFAULT VALUES", []any{}, nil))
+	sp2, err := sp1.(*Tx).Savepoint(ctx, "sp2")
+	require.NoError(t, err)
+	require.NoError(t, sp2.Rollback())
+	require.ErrorIs(t, sp2.Rollback(), sql.ErrTxDone)
+	require.NoError(t, sp1.Commit())
+	require.ErrorIs(t, sp1.Commit(), sql.ErrTxDone)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatementTimeout(t *testing.T) {
+	ctx := WithStatementTimeout(context.Background(), 1500*time.Millisecond)
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv := OpenDB(dialect.Postgres, db)
+	mock.ExpectBegin()
+	mock.ExpectExec("SET LOCAL statement_timeout = 1500").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT name FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+	mock.ExpectCommit()
+	rows := &Rows{}
+	require.NoError(t, drv.Query(ctx, "SELECT name FROM users", []any{}, rows))
+	name, err := ScanString(rows)
+	require.NoError(t, err)
+	require.Equal(t, "a8m", name)
+	require.NoError(t, rows.Close())
+	require.NoError(t, rows.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SET LOCAL statement_timeout = 1500").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM users").
+		WillReturnError(errors.New("canceling statement due to statement timeout"))
+	mock.ExpectRollback()
+	err = drv.Exec(ctx, "DELETE FROM users", []any{}, nil)
+	require.Equal(t, KindTimeout, ClassifyError(err).Kind)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Restore the timeout of the transaction after the statement was executed.
+	mock.ExpectBegin()
+	mock.ExpectQuery("SHOW statement_timeout").
+		WillReturnRows(sqlmock.NewRows([]string{"statement_timeout"}).AddRow("5s"))
+	mock.ExpectExec("SET LOCAL statement_timeout = 1500").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM users").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SELECT set_config('statement_timeout', $1, true)").
+		WithArgs("5s").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM pets").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	tx, err := drv.Tx(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, tx.Exec(ctx, "DELETE FROM users", []any{}, nil))
+	require.NoError(t, tx.Exec(context.Background(), "DELETE FROM pets", []any{}, nil))
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Apply the timeout as a deadline, and return the standard rows.
+	mock.ExpectQuery("SELECT name FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+	rows = &Rows{}
+	dctx, cancel := StatementDeadline(ctx)
+	require.NoError(t, drv.Query(dctx, "SELECT name FROM users", []any{}, rows))
+	require.IsType(t, &sql.Rows{}, rows.ColumnScanner)
+	require.NoError(t, rows.Close())
+	cancel()
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New()
+	require.NoError(t, err)
+	drv = OpenDB(dialect.SQLite, db)
+	mock.ExpectExec("DELETE FROM users").
+		WillDelayFor(time.Second).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	err = drv.Exec(WithStatementTimeout(ctx, 10*time.Millisecond), "DELETE FROM users", []any{}, nil)
+	require.Error(t, err)
+	require.NoError(t, ctx.Err(), "caller context should not be cancelled")
+	mock.ExpectQuery("SELECT name FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+	rows = &Rows{}
+	require.NoError(t, drv.Query(WithStatementTimeout(ctx, time.Second), "SELECT name FROM users", []any{}, rows))
+	require.IsType(t, &closeRows{}, rows.ColumnScanner, "the deadline is released once the rows are closed")
+	require.NoError(t, rows.Close())
+	require.True(t, rows.ColumnScanner.(*closeRows).done)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,370 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrorKind describes the kind of a database error.
+type ErrorKind int
+
+// List of database error kinds.
+const (
+	// KindUnknown is the kind of errors that were not classified.
+	KindUnknown ErrorKind = iota
+	// KindUnique is the kind of uniqueness constraint violations.
+	KindUnique
+	// KindForeignKey is the kind of foreign-key constraint violations.
+	KindForeignKey
+	// KindNotNull is the kind of not-null constraint violations.
+	KindNotNull
+	// KindCheck is the kind of check constraint violations.
+	KindCheck
+	// KindDeadlock is the kind of errors that resulted from a deadlock between transactions.
+	KindDeadlock
+	// KindSerialization is the kind of serialization failures of concurrent transactions.
+	KindSerialization
+	// KindTimeout is the kind of errors that resulted from a statement, lock or context timeout.
+	KindTimeout
+	// KindConnection is the kind of errors that resulted from a broken database connection.
+	KindConnection
+)
+
+// String implements the fmt.Stringer interface.
+func (k ErrorKind) String() string {
+	switch k {
+	case KindUnique:
+		return "unique"
+	case KindForeignKey:
+		return "foreign key"
+	case KindNotNull:
+		return "not null"
+	case KindCheck:
+		return "check"
+	case KindDeadlock:
+		return "deadlock"
+	case KindSerialization:
+		return "serialization"
+	case KindTimeout:
+		return "timeout"
+	case KindConnection:
+		return "connection"
+	default:
+		return "unknown"
+	}
+}
+
+// Constraint reports if the kind is a constraint violation.
+func (k ErrorKind) Constraint() bool {
+	return k >= KindUnique && k <= KindCheck
+}
+
+// Error is a database error that was classified by its kind. It wraps the error that was
+// returned by the database driver, and holds the name of the constraint, the table and the
+// column that the error refers to, if they were reported by the driver.
+//
+// The Exec and Query methods of Conn return an *Error for errors that were classified.
+// Therefore, callers can use errors.As to extract it, instead of type-asserting the
+// errors of the different drivers.
+//
+//	var e *sql.Error
+//	if errors.As(err, &e) && e.Kind == sql.KindUnique {
+//		// ...
+//	}
+type Error struct {
+	Kind       ErrorKind
+	Constraint string
+	Table      string
+	Column     string
+	// Err is the error that was returned by the driver.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error that was returned by the driver.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ClassifyError returns the classification of the given error, or nil if it was not
+// classified. The PostgreSQL (lib/pq, pgx), MySQL (go-sql-driver/mysql) and SQLite
+// (mattn/go-sqlite3, modernc.org/sqlite) errors are classified by their codes, and
+// errors of other drivers by their messages.
+func ClassifyError(err error) *Error {
+	return classifyError(err, true)
+}
+
+// classifyError classifies the given error. Network and context errors
+// are classified only if the network argument is true.
+func classifyError(err error, network bool) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	e = &Error{Err: err}
+	// Driver errors may be wrapped by other errors (e.g. by the sqlgraph package).
+	for err := err; err != nil && e.Kind == KindUnknown; err = errors.Unwrap(err) {
+		_ = mysqlError(e, err) || postgresError(e, err) || sqliteError(e, err) || network && networkError(e, err)
+	}
+	if e.Kind == KindUnknown {
+		messageError(e, err.Error())
+	}
+	if e.Kind == KindUnknown {
+		return nil
+	}
+	return e
+}
+
+// wrapError wraps the given error with an *Error, if it was reported by the
+// database and classified. Other errors, like context cancellation or network
+// errors, are returned as is to keep comparisons and type assertions working.
+func wrapError(err error) error {
+	if e := classifyError(err, false); e != nil {
+		return e
+	}
+	return err
+}
+
+// mysqlError classifies MySQL errors by their numbers.
+func mysqlError(e *Error, err error) bool {
+	var number uint64
+	if v, ok := errorField(err, "Number"); ok && v.CanUint() {
+		number = v.Uint()
+	} else if m := mysqlNumber.FindStringSubmatch(err.Error()); m != nil {
+		number, _ = strconv.ParseUint(m[1], 10, 16)
+	} else {
+		return false
+	}
+	msg := err.Error()
+	switch number {
+	case 1062, 1586:
+		// Duplicate entry 'a8m' for key 'users.name'
+		e.Kind = KindUnique
+		if m := mysqlDupKey.FindStringSubmatch(msg); m != nil {
+			e.Constraint = m[1]
+			if i := strings.LastIndexByte(m[1], '.'); i > 0 {
+				e.Table, e.Constraint = m[1][:i], m[1][i+1:]
+			}
+		}
+	case 1216, 1217, 1451, 1452:
+		// a foreign key constraint fails (`db`.`pets`, CONSTRAINT `pets_owner` FOREIGN KEY (`owner_id`) REFERENCES ...)
+		e.Kind = KindForeignKey
+		if m := mysqlFK.FindStringSubmatch(msg); m != nil {
+			e.Table, e.Constraint, e.Column = m[1], m[2], m[3]
+		}
+	case 1048, 1364:
+		// Column 'name' cannot be null, or Field 'name' doesn't have a default value.
+		e.Kind = KindNotNull
+		if m := quoted.FindStringSubmatch(msg); m != nil {
+			e.Column = m[1]
+		}
+	case 3819:
+		// Check constraint 'users_chk_1' is violated.
+		e.Kind = KindCheck
+		if m := quoted.FindStringSubmatch(msg); m != nil {
+			e.Constraint = m[1]
+		}
+	case 1213:
+		e.Kind = KindDeadlock
+	case 1205, 3024:
+		// Lock wait timeout exceeded, or maximum statement execution time exceeded.
+		e.Kind = KindTimeout
+	case 1040, 1053, 2002, 2003, 2006, 2013:
+		e.Kind = KindConnection
+	}
+	return true
+}
+
+// postgresError classifies PostgreSQL errors by their SQLSTATE codes.
+func postgresError(e *Error, err error) bool {
+	var code string
+	if s, ok := err.(interface{ SQLState() string }); ok {
+		code = s.SQLState()
+	} else if v, ok := errorField(err, "Code"); ok && v.Kind() == reflect.String && v.Len() == 5 {
+		code = v.String()
+	} else {
+		return false
+	}
+	switch {
+	case code == "23505":
+		e.Kind = KindUnique
+	case code == "23503":
+		e.Kind = KindForeignKey
+	case code == "23502":
+		e.Kind = KindNotNull
+	case code == "23514":
+		e.Kind = KindCheck
+	case code == "40P01":
+		e.Kind = KindDeadlock
+	case code == "40001":
+		e.Kind = KindSerialization
+	case code == "57014", code == "55P03":
+		// Statement timeout (query_canceled), or lock timeout (lock_not_available).
+		e.Kind = KindTimeout
+	case strings.HasPrefix(code, "08"), code == "57P01":
+		e.Kind = KindConnection
+	}
+	// The fields of lib/pq and pgx errors, respectively.
+	e.Constraint = errorString(err, "Constraint", "ConstraintName")
+	e.Table = errorString(err, "Table", "TableName")
+	e.Column = errorString(err, "Column", "ColumnName")
+	if e.Constraint == "" && e.Table == "" && e.Column == "" {
+		postgresDetails(e, err.Error())
+	}
+	return true
+}
+
+// postgresDetails extracts the details of PostgreSQL constraint errors from their messages.
+// e.g. `insert or update on table "pets" violates foreign key constraint "pets_owner_fkey"`.
+func postgresDetails(e *Error, msg string) {
+	if m := pgConstraint.FindStringSubmatch(msg); m != nil {
+		e.Constraint = m[1]
+	}
+	if m := pgTable.FindStringSubmatch(msg); m != nil {
+		e.Table = m[1]
+	}
+	if m := pgColumn.FindStringSubmatch(msg); m != nil {
+		e.Column = m[1]
+	}
+}
+
+// sqliteError classifies SQLite errors by their (extended) result codes.
+func sqliteError(e *Error, err error) bool {
+	var code int64
+	if c, ok := err.(interface{ Code() int }); ok {
+		code = int64(c.Code())
+	} else if v, ok := errorField(err, "ExtendedCode"); ok && v.CanInt() {
+		code = v.Int()
+	} else {
+		return false
+	}
+	switch code {
+	case 2067, 1555: // SQLITE_CONSTRAINT_UNIQUE, SQLITE_CONSTRAINT_PRIMARYKEY
+		e.Kind = KindUnique
+	case 787: // SQLITE_CONSTRAINT_FOREIGNKEY
+		e.Kind = KindForeignKey
+	case 1299: // SQLITE_CONSTRAINT_NOTNULL
+		e.Kind = KindNotNull
+	case 275: // SQLITE_CONSTRAINT_CHECK
+		e.Kind = KindCheck
+	default:
+		switch code & 0xff {
+		case 5, 6: // SQLITE_BUSY, SQLITE_LOCKED
+			e.Kind = KindTimeout
+		}
+	}
+	sqliteDetails(e, err.Error())
+	return true
+}
+
+// sqliteDetails extracts the details of SQLite constraint errors from their
+// messages. e.g. "UNIQUE constraint failed: users.name, users.age".
+func sqliteDetails(e *Error, msg string) {
+	_, details, ok := strings.Cut(msg, "constraint failed: ")
+	if !ok {
+		return
+	}
+	switch e.Kind {
+	case KindUnique, KindNotNull:
+		column, _, _ := strings.Cut(details, ",")
+		e.Table, e.Column, _ = strings.Cut(strings.TrimSpace(column), ".")
+	case KindCheck:
+		e.Constraint = details
+	}
+}
+
+// networkError classifies context and network errors.
+func networkError(e *Error, err error) bool {
+	var ne net.Error
+	switch {
+	case err == context.DeadlineExceeded:
+		e.Kind = KindTimeout
+	case err == driver.ErrBadConn:
+		e.Kind = KindConnection
+	case errors.As(err, &ne):
+		e.Kind = KindConnection
+		if ne.Timeout() {
+			e.Kind = KindTimeout
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// messageError classifies errors of unknown drivers by their messages.
+func messageError(e *Error, msg string) {
+	for _, c := range []struct {
+		kind ErrorKind
+		msgs []string
+	}{
+		{KindUnique, []string{"violates unique constraint", "UNIQUE constraint failed"}},
+		{KindForeignKey, []string{"violates foreign key constraint", "FOREIGN KEY constraint failed"}},
+		{KindNotNull, []string{"violates not-null constraint", "NOT NULL constraint failed"}},
+		{KindCheck, []string{"violates check constraint", "CHECK constraint failed"}},
+		{KindDeadlock, []string{"deadlock detected"}},
+		{KindSerialization, []string{"could not serialize access"}},
+		{KindTimeout, []string{"database is locked", "canceling statement due to statement timeout"}},
+	} {
+		for _, s := range c.msgs {
+			if strings.Contains(msg, s) {
+				e.Kind = c.kind
+				postgresDetails(e, msg)
+				sqliteDetails(e, msg)
+				return
+			}
+		}
+	}
+}
+
+var (
+	mysqlNumber  = regexp.MustCompile(`^Error (\d+)`)
+	mysqlDupKey  = regexp.MustCompile(`for key '([^']+)'`)
+	mysqlFK      = regexp.MustCompile("`([^`]+)`, CONSTRAINT `([^`]+)` FOREIGN KEY \\(`([^`]+)`")
+	quoted       = regexp.MustCompile(`'([^']+)'`)
+	pgConstraint = regexp.MustCompile(`violates [a-z -]+ constraint "([^"]+)"`)
+	pgTable      = regexp.MustCompile(`(?:on table|of relation) "([^"]+)"`)
+	pgColumn     = regexp.MustCompile(`in column "([^"]+)"`)
+)
+
+// errorField returns the value of the exported field with the given
+// name of the given error, if it is a struct or a pointer to a struct.
+func errorField(err error, name string) (reflect.Value, bool) {
+	v := reflect.ValueOf(err)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	f := v.FieldByName(name)
+	return f, f.IsValid()
+}
+
+// errorString returns the value of the first string field of
+// the given error that exists and holds a non-empty value.
+func errorString(err error, names ...string) string {
+	for _, name := range names {
+		if v, ok := errorField(err, name); ok && v.Kind() == reflect.String && v.String() != "" {
+			return v.String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,171 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want *Error
+	}{
+		{
+			err:  &pq.Error{Code: "23505", Message: `duplicate key value violates unique constraint "users_name_key"`, Table: "users", Constraint: "users_name_key"},
+			want: &Error{Kind: KindUnique, Table: "users", Constraint: "users_name_key"},
+		},
+		{
+			err:  &pq.Error{Code: "23503", Table: "pets", Constraint: "pets_owner_fkey"},
+			want: &Error{Kind: KindForeignKey, Table: "pets", Constraint: "pets_owner_fkey"},
+		},
+		{
+			err:  &pq.Error{Code: "23502", Table: "users", Column: "name"},
+			want: &Error{Kind: KindNotNull, Table: "users", Column: "name"},
+		},
+		{
+			err:  &pq.Error{Code: "40001"},
+			want: &Error{Kind: KindSerialization},
+		},
+		{
+			err:  &pq.Error{Code: "57014"},
+			want: &Error{Kind: KindTimeout},
+		},
+		{
+			err:  &pq.Error{Code: "42P01"},
+			want: nil,
+		},
+		{
+			err:  &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a8m' for key 'users.name'"},
+			want: &Error{Kind: KindUnique, Table: "users", Constraint: "name"},
+		},
+		{
+			err:  &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`test`.`pets`, CONSTRAINT `pets_users_pets` FOREIGN KEY (`user_pets`) REFERENCES `users` (`id`) ON DELETE SET NULL)"},
+			want: &Error{Kind: KindForeignKey, Table: "pets", Constraint: "pets_users_pets", Column: "user_pets"},
+		},
+		{
+			err:  &mysql.MySQLError{Number: 1048, Message: "Column 'name' cannot be null"},
+			want: &Error{Kind: KindNotNull, Column: "name"},
+		},
+		{
+			err:  &mysql.MySQLError{Number: 3819, Message: "Check constraint 'users_chk_1' is violated."},
+			want: &Error{Kind: KindCheck, Constraint: "users_chk_1"},
+		},
+		{
+			err:  &mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock; try restarting transaction"},
+			want: &Error{Kind: KindDeadlock},
+		},
+		{
+			err:  sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintUnique},
+			want: &Error{Kind: KindUnique},
+		},
+		{
+			err:  sqlite3.Error{Code: sqlite3.ErrConstraint, ExtendedCode: sqlite3.ErrConstraintForeignKey},
+			want: &Error{Kind: KindForeignKey},
+		},
+		{
+			err:  sqlite3.Error{Code: sqlite3.ErrBusy, ExtendedCode: sqlite3.ErrNoExtended(sqlite3.ErrBusy)},
+			want: &Error{Kind: KindTimeout},
+		},
+		{
+			err:  errors.New("UNIQUE constraint failed: users.name, users.age"),
+			want: &Error{Kind: KindUnique, Table: "users", Column: "name"},
+		},
+		{
+			err:  errors.New("CHECK constraint failed: age > 0"),
+			want: &Error{Kind: KindCheck, Constraint: "age > 0"},
+		},
+		{
+			err:  errors.New(`pq: null value in column "name" of relation "users" violates not-null constraint`),
+			want: &Error{Kind: KindNotNull, Table: "users", Column: "name"},
+		},
+		{
+			err:  errors.New("Error 1451 (23000): Cannot delete or update a parent row"),
+			want: &Error{Kind: KindForeignKey},
+		},
+		{
+			err:  fmt.Errorf("insert nodes: %w", context.DeadlineExceeded),
+			want: &Error{Kind: KindTimeout},
+		},
+		{
+			err:  driver.ErrBadConn,
+			want: &Error{Kind: KindConnection},
+		},
+		{
+			err:  errors.New("syntax error"),
+			want: nil,
+		},
+	}
+	for i, tt := range tests {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			got := ClassifyError(tt.err)
+			if tt.want == nil {
+				require.Nil(t, got)
+				return
+			}
+			tt.want.Err = tt.err
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.err.Error(), got.Error())
+			require.Same(t, got, ClassifyError(fmt.Errorf("wrapped: %w", got)))
+		})
+	}
+}
+
+func TestConn_WrapError(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	drv := OpenDB(dialect.Postgres, db)
+	unique := &pq.Error{Code: "23505", Table: "users"}
+	mock.ExpectExec("INSERT INTO users").WillReturnError(unique)
+	mock.ExpectExec("UPDATE users").WillReturnError(errors.New("syntax error"))
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).RowError(0, &pq.Error{Code: "40P01"}))
+	mock.ExpectExec("DELETE FROM users").WillReturnError(context.DeadlineExceeded)
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+	mock.ExpectQuery("SELECT").WillReturnError(reset)
+
+	err = drv.Exec(ctx, "INSERT INTO users DEFAULT VALUES", []any{}, nil)
+	var e *Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, KindUnique, e.Kind)
+	require.Equal(t, "users", e.Table)
+	var pqErr *pq.Error
+	require.True(t, errors.As(err, &pqErr))
+	require.Same(t, unique, pqErr)
+
+	err = drv.Exec(ctx, "UPDATE users SET name = NULL", []any{}, nil)
+	require.EqualError(t, err, "syntax error")
+	require.False(t, errors.As(err, &e))
+
+	rows := &Rows{}
+	require.NoError(t, drv.Query(ctx, "SELECT id FROM users", []any{}, rows))
+	require.False(t, rows.Next())
+	require.True(t, errors.As(rows.Err(), &e))
+	require.Equal(t, KindDeadlock, e.Kind)
+	require.NoError(t, rows.Close())
+
+	// Errors that were not reported by the database are returned as is.
+	err = drv.Exec(ctx, "DELETE FROM users", []any{}, nil)
+	require.True(t, err == context.DeadlineExceeded)
+	err = drv.Query(ctx, "SELECT id FROM users", []any{}, &Rows{})
+	require.False(t, errors.As(err, &e))
+	var opErr *net.OpError
+	require.True(t, errors.As(err, &opErr))
+	require.Same(t, reset, opErr)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -847,7 +847,11 @@ type db struct{ dialect.ExecQuerier }
 
 func (d *db) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
 	rows := &entsql.Rows{}
+	// Atlas requires the standard rows, and closes them on its own. Hence, statement timeouts
+	// are applied as deadlines, that are released once they have expired or the query failed.
+	ctx, cancel := entsql.StatementDeadline(ctx)
 	if err := d.ExecQuerier.Query(ctx, query, args, rows); err != nil {
+		cancel()
 		return nil, err
 	}
 	return rows.ColumnScanner.(*sql.Rows), nil
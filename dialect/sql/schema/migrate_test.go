@@ -424,3 +424,19 @@ func TestAtlas_StateReader(t *testing.T) {
 		},
 	)
 }
+
+func TestMigrate_StatementTimeout(t *testing.T) {
+	drv, err := sql.Open(dialect.SQLite, "file:timeout?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	defer drv.Close()
+	m, err := NewMigrate(drv)
+	require.NoError(t, err)
+	ctx := sql.WithStatementTimeout(context.Background(), time.Minute)
+	tables := []*Table{{
+		Name:       "users",
+		Columns:    []*Column{{Name: "id", Type: field.TypeInt, Increment: true}},
+		PrimaryKey: []*Column{{Name: "id", Type: field.TypeInt, Increment: true}},
+	}}
+	require.NoError(t, m.Create(ctx, tables...))
+	require.NoError(t, m.Create(ctx, tables...), "inspect the existing table")
+}
@@ -264,6 +264,8 @@ func (d *MySQL) cType(c *Column) (t string) {
 		}
 	case field.TypeFloat32, field.TypeFloat64:
 		t = c.scanTypeOr("double")
+	case field.TypeDecimal:
+		t = c.scanTypeOr("decimal")
 	case field.TypeTime:
 		t = c.scanTypeOr("timestamp")
 		// In MariaDB or in MySQL < v8.0.2, the TIMESTAMP column has both `DEFAULT CURRENT_TIMESTAMP`
@@ -894,6 +896,8 @@ func (d *MySQL) atTypeC(c1 *Column, c2 *schema.Column) error {
 		}
 	case field.TypeFloat32, field.TypeFloat64:
 		t = &schema.FloatType{T: c1.scanTypeOr(mysql.TypeDouble)}
+	case field.TypeDecimal:
+		t = &schema.DecimalType{T: mysql.TypeDecimal}
 	case field.TypeTime:
 		t = &schema.TimeType{T: c1.scanTypeOr(mysql.TypeTimestamp)}
 		// In MariaDB or in MySQL < v8.0.2, the TIMESTAMP column has both `DEFAULT CURRENT_TIMESTAMP`
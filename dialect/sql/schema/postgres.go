@@ -353,6 +353,8 @@ func (d *Postgres) cType(c *Column) (t string) {
 		t = c.scanTypeOr("real")
 	case field.TypeFloat64:
 		t = c.scanTypeOr("double precision")
+	case field.TypeDecimal:
+		t = c.scanTypeOr("numeric")
 	case field.TypeBytes:
 		t = "bytea"
 	case field.TypeJSON:
@@ -718,6 +720,8 @@ func (d *Postgres) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.FloatType{T: c1.scanTypeOr(postgres.TypeReal)}
 	case field.TypeFloat64:
 		t = &schema.FloatType{T: c1.scanTypeOr(postgres.TypeDouble)}
+	case field.TypeDecimal:
+		t = &schema.DecimalType{T: postgres.TypeNumeric}
 	case field.TypeBytes:
 		t = &schema.BinaryType{T: postgres.TypeBytea}
 	case field.TypeUUID:
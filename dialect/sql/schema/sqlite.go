@@ -159,6 +159,10 @@ func (*SQLite) cType(c *Column) (t string) {
 		t = fmt.Sprintf("varchar(%d)", DefaultStringLen)
 	case field.TypeFloat32, field.TypeFloat64:
 		t = "real"
+	case field.TypeDecimal:
+		// SQLite has no decimal type, and the column gets the NUMERIC
+		// affinity. Values are exact only up to 15 significant digits.
+		t = "decimal"
 	case field.TypeTime:
 		t = "datetime"
 	case field.TypeJSON:
@@ -430,6 +434,8 @@ func (d *SQLite) atTypeC(c1 *Column, c2 *schema.Column) error {
 		t = &schema.StringType{T: sqlite.TypeText}
 	case field.TypeFloat32, field.TypeFloat64:
 		t = &schema.FloatType{T: sqlite.TypeReal}
+	case field.TypeDecimal:
+		t = &schema.DecimalType{T: "decimal"}
 	case field.TypeTime:
 		t = &schema.TimeType{T: "datetime"}
 	case field.TypeJSON:
@@ -513,7 +519,11 @@ type tx struct {
 
 func (tx *tx) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
 	rows := &sql.Rows{}
+	// Atlas requires the standard rows, and closes them on its own. Hence, statement timeouts
+	// are applied as deadlines, that are released once they have expired or the query failed.
+	ctx, cancel := sql.StatementDeadline(ctx)
 	if err := tx.Query(ctx, query, args, rows); err != nil {
+		cancel()
 		return nil, err
 	}
 	return rows.ColumnScanner.(*stdsql.Rows), nil
@@ -0,0 +1,308 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlbudget provides guardrails that limit the work of queries and requests. Limits are
+// configured per request using WithLimits, and per schema using the Annotation, and are enforced by
+// the BudgetInterceptor and the BudgetHook of the "sql/budget" feature, and by the Driver of this
+// package. Violations are returned as *ExceededError, and can be allowed explicitly using Override.
+//
+//	client := ent.NewClient(ent.Driver(sqlbudget.NewDriver(drv)))
+//	client.Intercept(ent.BudgetInterceptor())
+//	client.Use(ent.BudgetHook())
+//
+//	// In an HTTP middleware.
+//	ctx := sqlbudget.WithLimits(r.Context(), sqlbudget.Limits{MaxRows: 1000, MaxStatements: 100})
+package sqlbudget
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/schema"
+)
+
+// Check identifies the guardrails that are enforced by the package.
+type Check string
+
+// List of guardrails.
+const (
+	// CheckRows limits the number of rows that are returned by queries.
+	CheckRows Check = "rows"
+	// CheckStatements limits the number of statements that are executed in a request.
+	CheckStatements Check = "statements"
+	// CheckUnbounded refuses to run Update and Delete mutations without predicates.
+	CheckUnbounded Check = "unbounded"
+)
+
+// ExceededError is returned when a query or a mutation violates one of the guardrails.
+type ExceededError struct {
+	// Check holds the violated guardrail.
+	Check Check
+	// Type holds the node type of the query or the mutation, if it is known.
+	Type string
+	// Limit holds the limit that was exceeded, if the guardrail has one.
+	Limit int
+}
+
+// Error implements the error interface.
+func (e *ExceededError) Error() string {
+	switch e.Check {
+	case CheckRows:
+		return fmt.Sprintf("sqlbudget: %s query returns more than %d rows", e.Type, e.Limit)
+	case CheckStatements:
+		return fmt.Sprintf("sqlbudget: request executes more than %d statements", e.Limit)
+	case CheckUnbounded:
+		return fmt.Sprintf("sqlbudget: %s mutation has no predicates", e.Type)
+	default:
+		return fmt.Sprintf("sqlbudget: %s budget exceeded", e.Check)
+	}
+}
+
+// IsExceeded returns a boolean indicating whether the error is an ExceededError.
+func IsExceeded(err error) bool {
+	var e *ExceededError
+	return errors.As(err, &e)
+}
+
+// Limits holds the guardrails of a request.
+type Limits struct {
+	// MaxRows limits the number of rows that are returned by the All and IDs queries
+	// that are executed in the request. It overrides the limits of the schema.
+	MaxRows int
+	// MaxStatements limits the number of statements that are executed by the Driver in the request.
+	MaxStatements int
+}
+
+// budget is the state of a request, as stored in its context.
+type budget struct {
+	Limits
+	executed  *atomic.Int64
+	overrides map[Check]bool
+}
+
+// budgetKey is the context key for the budget of a request.
+type budgetKey struct{}
+
+// WithLimits returns a new context that enforces the given limits on the queries and statements that
+// are executed with it. Statements are counted from scratch in the returned context, and guardrails
+// that were overridden in the parent context using Override remain overridden.
+func WithLimits(ctx context.Context, l Limits) context.Context {
+	b := &budget{Limits: l, executed: new(atomic.Int64)}
+	if parent := fromContext(ctx); parent != nil {
+		b.overrides = parent.overrides
+	}
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+// Override returns a new context that allows the queries and the mutations that are executed with it
+// to exceed the given guardrails, or all guardrails if none was given. For example:
+//
+//	// Delete all users, without a predicate.
+//	client.User.Delete().Exec(sqlbudget.Override(ctx, sqlbudget.CheckUnbounded))
+//
+// Statements that are executed with the returned context are still counted in the request.
+func Override(ctx context.Context, checks ...Check) context.Context {
+	if len(checks) == 0 {
+		checks = []Check{CheckRows, CheckStatements, CheckUnbounded}
+	}
+	b := &budget{executed: new(atomic.Int64), overrides: make(map[Check]bool)}
+	if parent := fromContext(ctx); parent != nil {
+		b.Limits, b.executed = parent.Limits, parent.executed
+		for c := range parent.overrides {
+			b.overrides[c] = true
+		}
+	}
+	for _, c := range checks {
+		b.overrides[c] = true
+	}
+	return context.WithValue(ctx, budgetKey{}, b)
+}
+
+func fromContext(ctx context.Context) *budget {
+	b, _ := ctx.Value(budgetKey{}).(*budget)
+	return b
+}
+
+// Overridden reports if the given guardrail was overridden in the context using Override.
+func Overridden(ctx context.Context, c Check) bool {
+	b := fromContext(ctx)
+	return b != nil && b.overrides[c]
+}
+
+// MaxRows returns the row limit of queries that are executed with the given context: the limit that was
+// set using WithLimits, or the limit of the schema if it was not set, or 0 if the check was overridden.
+func MaxRows(ctx context.Context, schemaLimit int) int {
+	b := fromContext(ctx)
+	switch {
+	case b == nil:
+		return schemaLimit
+	case b.overrides[CheckRows]:
+		return 0
+	case b.MaxRows > 0:
+		return b.MaxRows
+	default:
+		return schemaLimit
+	}
+}
+
+// Annotation configures the guardrails of a schema. For example:
+//
+//	func (User) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			sqlbudget.Annotation{MaxRows: 10000},
+//		}
+//	}
+//
+// Annotations take effect only if the "sql/budget" feature is enabled.
+type Annotation struct {
+	// MaxRows limits the number of rows that are returned by the All and IDs queries of the
+	// schema. The limit of the request, if it was set using WithLimits, takes precedence.
+	MaxRows int `json:"max_rows,omitempty"`
+	// AllowUnbounded allows running Update and Delete mutations of the schema without predicates.
+	AllowUnbounded bool `json:"allow_unbounded,omitempty"`
+}
+
+// Name describes the annotation name.
+func (Annotation) Name() string {
+	return "SQLBudget"
+}
+
+// Driver is a dialect.Driver that limits the number of statements that are executed in a request.
+// Statements are counted in contexts that were created using WithLimits with MaxStatements.
+type Driver struct {
+	dialect.Driver
+}
+
+// NewDriver returns a new Driver that enforces the statement limits of requests on the given driver.
+func NewDriver(drv dialect.Driver) *Driver {
+	return &Driver{Driver: drv}
+}
+
+// Exec counts the statement in its request, and executes it using the underlying driver.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	if err := count(ctx); err != nil {
+		return err
+	}
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query counts the query in its request, and executes it using the underlying driver.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	if err := count(ctx); err != nil {
+		return err
+	}
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// ExecContext counts the statement in its request, and calls the underlying driver ExecContext method if it is supported.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	if err := count(ctx); err != nil {
+		return nil, err
+	}
+	return drv.ExecContext(ctx, query, args...)
+}
+
+// QueryContext counts the query in its request, and calls the underlying driver QueryContext method if it is supported.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	if err := count(ctx); err != nil {
+		return nil, err
+	}
+	return drv.QueryContext(ctx, query, args...)
+}
+
+// Tx starts a transaction that counts its statements.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+// BeginTx calls the underlying driver BeginTx command if it is supported,
+// and returns a transaction that counts its statements.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+// Tx is a dialect.Tx that limits the number of statements that are executed in a request.
+type Tx struct {
+	dialect.Tx
+}
+
+// Exec counts the statement in its request, and executes it using the underlying transaction.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	if err := count(ctx); err != nil {
+		return err
+	}
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+// Query counts the query in its request, and executes it using the underlying transaction.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	if err := count(ctx); err != nil {
+		return err
+	}
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+// Savepoint calls the Savepoint method of the underlying transaction if it is
+// supported, and returns a nested transaction that counts its statements.
+func (t *Tx) Savepoint(ctx context.Context, name string) (dialect.Tx, error) {
+	sp, ok := t.Tx.(interface {
+		Savepoint(context.Context, string) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.Savepoint is not supported")
+	}
+	nested, err := sp.Savepoint(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: nested}, nil
+}
+
+// count counts a statement in the request of the given context, and returns an
+// ExceededError if the request has exceeded its statement limit.
+func count(ctx context.Context) error {
+	b := fromContext(ctx)
+	if b == nil || b.MaxStatements <= 0 {
+		return nil
+	}
+	if n := b.executed.Add(1); n > int64(b.MaxStatements) && !b.overrides[CheckStatements] {
+		return &ExceededError{Check: CheckStatements, Limit: b.MaxStatements}
+	}
+	return nil
+}
+
+var (
+	_ dialect.Driver    = (*Driver)(nil)
+	_ schema.Annotation = (*Annotation)(nil)
+)
@@ -0,0 +1,80 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlbudget
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxRows(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, 10, MaxRows(ctx, 10))
+	require.Equal(t, 0, MaxRows(ctx, 0))
+
+	lctx := WithLimits(ctx, Limits{MaxRows: 5})
+	require.Equal(t, 5, MaxRows(lctx, 10))
+	require.Equal(t, 10, MaxRows(WithLimits(ctx, Limits{MaxStatements: 5}), 10))
+
+	octx := Override(lctx, CheckRows)
+	require.Equal(t, 0, MaxRows(octx, 10))
+	require.True(t, Overridden(octx, CheckRows))
+	require.False(t, Overridden(octx, CheckUnbounded))
+	require.True(t, Overridden(WithLimits(octx, Limits{MaxRows: 1}), CheckRows), "overrides are kept by WithLimits")
+	require.False(t, Overridden(lctx, CheckRows))
+
+	octx = Override(ctx)
+	for _, c := range []Check{CheckRows, CheckStatements, CheckUnbounded} {
+		require.True(t, Overridden(octx, c))
+	}
+}
+
+func TestExceededError(t *testing.T) {
+	err := fmt.Errorf("querying users: %w", &ExceededError{Check: CheckRows, Type: "User", Limit: 10})
+	require.True(t, IsExceeded(err))
+	require.False(t, IsExceeded(fmt.Errorf("sqlbudget")))
+	require.EqualError(t, err, "querying users: sqlbudget: User query returns more than 10 rows")
+	require.EqualError(t, &ExceededError{Check: CheckUnbounded, Type: "User"}, "sqlbudget: User mutation has no predicates")
+}
+
+func TestDriver(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	drv := NewDriver(sql.OpenDB(dialect.SQLite, db))
+	ctx := WithLimits(context.Background(), Limits{MaxStatements: 3})
+	mock.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(ctx, "UPDATE users SET name = ?", []any{"a8m"}, nil))
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM pets").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT name FROM users").WillReturnRows(sqlmock.NewRows([]string{"name"}))
+	mock.ExpectCommit()
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Exec(ctx, "DELETE FROM pets", []any{}, nil))
+	rows := &sql.Rows{}
+	require.NoError(t, tx.Query(ctx, "SELECT name FROM users", []any{}, rows))
+	require.NoError(t, rows.Close())
+	err = tx.Exec(ctx, "DELETE FROM users", []any{}, nil)
+	require.EqualError(t, err, "sqlbudget: request executes more than 3 statements")
+	require.NoError(t, tx.Commit())
+
+	// Overridden contexts share their counter with their parent.
+	octx := Override(ctx, CheckStatements)
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(octx, "DELETE FROM users", []any{}, nil))
+	require.Error(t, drv.Exec(ctx, "DELETE FROM users", []any{}, nil))
+
+	// Contexts without limits are not counted.
+	mock.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(context.Background(), "DELETE FROM users", []any{}, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,473 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlcache provides a dialect.Driver that caches the results of queries in a
+// pluggable Store. Entries are keyed by the rendered SQL and its arguments, and tagged
+// by the tables that they read, in order to allow invalidating them on mutations.
+// Queries that are executed within transactions are never cached.
+package sqlcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/jogly/ent/dialect"
+	entsql "github.com/jogly/ent/dialect/sql"
+)
+
+// ErrNotFound is returned by Store.Get when an entry does not exist in the store.
+var ErrNotFound = errors.New("sqlcache: entry was not found")
+
+type (
+	// Entry is a cached query result.
+	Entry struct {
+		Columns []string
+		Values  [][]driver.Value
+		// Tags are used for invalidating the entry.
+		// See TableTag, NodeTag and NodesTag for more info.
+		Tags []string
+	}
+
+	// A Store stores the cached entries. Implementations must be safe for concurrent use.
+	Store interface {
+		// Get returns the entry stored under the given key, or ErrNotFound if there is no such entry.
+		Get(ctx context.Context, key string) (*Entry, error)
+		// Add stores the entry under the given key. A zero ttl means that the entry does not expire.
+		Add(ctx context.Context, key string, e *Entry, ttl time.Duration) error
+		// Invalidate removes all entries that are tagged with one of the given tags.
+		Invalidate(ctx context.Context, tags ...string) error
+	}
+)
+
+// TableTag returns the tag of all entries that read from the given table.
+func TableTag(table string) string {
+	return table
+}
+
+// NodeTag returns the tag of entries that were created by looking up a single
+// node by its id (see NewNodeContext), and do not depend on other rows of its table.
+func NodeTag(table string, id any) string {
+	return fmt.Sprintf("%s#%v", table, id)
+}
+
+// NodesTag returns the tag of all node entries of the given table (i.e. all entries that
+// are tagged with NodeTag). Mutations that do not know the ids of the nodes they affect
+// should invalidate this tag in addition to the TableTag.
+func NodesTag(table string) string {
+	return table + "#"
+}
+
+// Driver is a dialect.Driver that caches the results of queries.
+type Driver struct {
+	dialect.Driver
+	store Store
+	ttl   time.Duration
+	optIn bool
+}
+
+// Option allows configuring the caching Driver.
+type Option func(*Driver)
+
+// WithStore configures the store of the driver. The default is an LRU with 1024 entries.
+func WithStore(s Store) Option {
+	return func(d *Driver) {
+		d.store = s
+	}
+}
+
+// TTL configures the time-to-live of the cached entries. The default is 0, and
+// means that entries are removed only by invalidations or by the store itself.
+func TTL(ttl time.Duration) Option {
+	return func(d *Driver) {
+		d.ttl = ttl
+	}
+}
+
+// OptIn configures the driver to cache only queries that are executed
+// with a context that was returned by Enable.
+func OptIn() Option {
+	return func(d *Driver) {
+		d.optIn = true
+	}
+}
+
+// NewDriver returns a new Driver that caches the results of the queries of the given driver.
+func NewDriver(drv dialect.Driver, opts ...Option) *Driver {
+	d := &Driver{Driver: drv}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.store == nil {
+		d.store = NewLRU(1024)
+	}
+	return d
+}
+
+// Store returns the store of the driver.
+func (d *Driver) Store() Store {
+	return d.store
+}
+
+// Query returns the cached result of the query if it exists, or executes
+// it using the underlying driver and caches its result otherwise.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	rows, ok := v.(*entsql.Rows)
+	argv, ok1 := args.([]any)
+	if !ok || !ok1 || !d.enabled(ctx) {
+		return d.Driver.Query(ctx, query, args, v)
+	}
+	key, err := Key(query, argv)
+	if err != nil {
+		return err
+	}
+	switch e, err := d.store.Get(ctx, key); {
+	case err == nil:
+		return e.rows(ctx, rows)
+	case !errors.Is(err, ErrNotFound):
+		return err
+	}
+	if err := d.Driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	e, err := scanEntry(rows)
+	if err != nil {
+		return err
+	}
+	e.Tags = tags(ctx, query, argv)
+	if err := d.store.Add(ctx, key, e, d.ttl); err != nil {
+		return err
+	}
+	return e.rows(ctx, rows)
+}
+
+// ExecContext calls the underlying driver ExecContext method if it is supported.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return drv.ExecContext(ctx, query, args...)
+}
+
+// QueryContext calls the underlying driver QueryContext method if it is supported.
+// Note that queries that are executed using QueryContext are not cached.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return drv.QueryContext(ctx, query, args...)
+}
+
+// BeginTx calls the underlying driver BeginTx command if it is supported.
+// Transactions are not cached, and returned as is.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	return drv.BeginTx(ctx, opts)
+}
+
+func (d *Driver) enabled(ctx context.Context) bool {
+	c, _ := ctx.Value(ctxKey{}).(*ctxConfig)
+	switch {
+	case c != nil && c.skip:
+		return false
+	case d.optIn:
+		return c != nil && c.enable
+	default:
+		return true
+	}
+}
+
+// Key returns the cache key of the given query and its arguments.
+func Key(query string, args []any) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(query))
+	for _, arg := range args {
+		if v, ok := arg.(driver.Valuer); ok {
+			dv, err := v.Value()
+			if err != nil {
+				return "", fmt.Errorf("sqlcache: get argument value: %w", err)
+			}
+			arg = dv
+		}
+		fmt.Fprintf(h, "\x00%T:%v", arg, arg)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type (
+	ctxKey    struct{}
+	ctxConfig struct {
+		skip, enable bool
+		node         *nodeRef
+	}
+	// nodeRef identifies a node that is looked up by its id.
+	nodeRef struct {
+		table string
+		id    any
+	}
+)
+
+func withConfig(ctx context.Context, f func(*ctxConfig)) context.Context {
+	c := &ctxConfig{}
+	if prev, ok := ctx.Value(ctxKey{}).(*ctxConfig); ok {
+		*c = *prev
+	}
+	f(c)
+	return context.WithValue(ctx, ctxKey{}, c)
+}
+
+// Skip returns a new context that bypasses the cache. Queries that are executed
+// with this context are neither read from the cache, nor stored in it.
+func Skip(ctx context.Context) context.Context {
+	return withConfig(ctx, func(c *ctxConfig) { c.skip = true })
+}
+
+// Enable returns a new context that enables caching for drivers that were configured with OptIn.
+func Enable(ctx context.Context) context.Context {
+	return withConfig(ctx, func(c *ctxConfig) { c.enable = true })
+}
+
+// NewNodeContext returns a new context that marks the query as a lookup of a single node
+// by its id. Entries of such queries are tagged with NodeTag and NodesTag instead of
+// TableTag, and therefore, they are not invalidated by mutations of other nodes in the
+// table. The marking is ignored by queries that read from other tables, or that do not
+// have the id as their only argument.
+func NewNodeContext(ctx context.Context, table string, id any) context.Context {
+	return withConfig(ctx, func(c *ctxConfig) {
+		c.node = &nodeRef{table: table, id: id}
+	})
+}
+
+// tablesRe matches the tables that follow the FROM and JOIN keywords, including schema-qualified tables.
+var tablesRe = regexp.MustCompile("(?i)\\b(?:FROM|JOIN)\\s+(?:[`\"\\[]?\\w+[`\"\\]]?\\.)?[`\"\\[]?(\\w+)[`\"\\]]?")
+
+// Tables returns the tables that are read by the given query.
+func Tables(query string) []string {
+	var (
+		tables []string
+		seen   = make(map[string]bool)
+	)
+	for _, m := range tablesRe.FindAllStringSubmatch(query, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			tables = append(tables, m[1])
+		}
+	}
+	return tables
+}
+
+// tags returns the tags of the entry of the given query.
+func tags(ctx context.Context, query string, args []any) []string {
+	tables := Tables(query)
+	if c, ok := ctx.Value(ctxKey{}).(*ctxConfig); ok && c.node != nil && len(tables) == 1 && tables[0] == c.node.table &&
+		len(args) == 1 && fmt.Sprint(args[0]) == fmt.Sprint(c.node.id) {
+		return []string{NodeTag(c.node.table, c.node.id), NodesTag(c.node.table)}
+	}
+	tags := make([]string, len(tables))
+	for i, t := range tables {
+		tags[i] = TableTag(t)
+	}
+	return tags
+}
+
+// scanEntry reads all rows into an entry, and closes them.
+func scanEntry(rows *entsql.Rows) (*Entry, error) {
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	e := &Entry{Columns: columns}
+	for rows.Next() {
+		var (
+			scan   = make([]any, len(columns))
+			dest   = make([]any, len(columns))
+			values = make([]driver.Value, len(columns))
+		)
+		for i := range scan {
+			dest[i] = &scan[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i := range scan {
+			values[i] = scan[i]
+		}
+		e.Values = append(e.Values, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// replayDB replays cached entries as standard sql.Rows. Hence, values
+// are converted to scan destinations in the same way as database rows.
+var replayDB = sql.OpenDB(replayConnector{})
+
+// rows sets the given rows to replay the entry.
+func (e *Entry) rows(ctx context.Context, rows *entsql.Rows) error {
+	r, err := replayDB.QueryContext(ctx, "", e)
+	if err != nil {
+		return err
+	}
+	*rows = entsql.Rows{ColumnScanner: r}
+	return nil
+}
+
+type (
+	replayConnector struct{}
+	replayConn      struct{}
+	replayRows      struct {
+		*Entry
+		next int
+	}
+)
+
+func (replayConnector) Connect(context.Context) (driver.Conn, error) { return replayConn{}, nil }
+func (replayConnector) Driver() driver.Driver                        { return replayConnector{} }
+func (replayConnector) Open(string) (driver.Conn, error)             { return replayConn{}, nil }
+
+func (replayConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("sqlcache: prepare is not supported")
+}
+func (replayConn) Close() error { return nil }
+func (replayConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("sqlcache: begin is not supported")
+}
+
+// CheckNamedValue accepts the replayed entry as an argument.
+func (replayConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+// QueryContext returns the rows of the entry that is passed as the first argument.
+func (replayConn) QueryContext(_ context.Context, _ string, args []driver.NamedValue) (driver.Rows, error) {
+	return &replayRows{Entry: args[0].Value.(*Entry)}, nil
+}
+
+func (r *replayRows) Columns() []string { return r.Entry.Columns }
+func (r *replayRows) Close() error      { return nil }
+func (r *replayRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.Values) {
+		return io.EOF
+	}
+	copy(dest, r.Values[r.next])
+	r.next++
+	return nil
+}
+
+// LRU is an in-memory Store that evicts the least recently used entries.
+type LRU struct {
+	mu    sync.Mutex
+	size  int
+	list  *list.List
+	items map[string]*list.Element
+	tags  map[string]map[string]struct{}
+}
+
+type lruItem struct {
+	key     string
+	entry   *Entry
+	expires time.Time
+}
+
+// NewLRU returns a new LRU store that holds up to size entries.
+// A size of 0 means that the number of entries is not limited.
+func NewLRU(size int) *LRU {
+	return &LRU{
+		size:  size,
+		list:  list.New(),
+		items: make(map[string]*list.Element),
+		tags:  make(map[string]map[string]struct{}),
+	}
+}
+
+// Get implements the Store.Get method.
+func (l *LRU) Get(_ context.Context, key string) (*Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.items[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	item := el.Value.(*lruItem)
+	if !item.expires.IsZero() && time.Now().After(item.expires) {
+		l.remove(el)
+		return nil, ErrNotFound
+	}
+	l.list.MoveToFront(el)
+	return item.entry, nil
+}
+
+// Add implements the Store.Add method.
+func (l *LRU) Add(_ context.Context, key string, e *Entry, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if el, ok := l.items[key]; ok {
+		l.remove(el)
+	}
+	item := &lruItem{key: key, entry: e}
+	if ttl > 0 {
+		item.expires = time.Now().Add(ttl)
+	}
+	l.items[key] = l.list.PushFront(item)
+	for _, t := range e.Tags {
+		if l.tags[t] == nil {
+			l.tags[t] = make(map[string]struct{})
+		}
+		l.tags[t][key] = struct{}{}
+	}
+	if l.size > 0 && l.list.Len() > l.size {
+		l.remove(l.list.Back())
+	}
+	return nil
+}
+
+// Invalidate implements the Store.Invalidate method.
+func (l *LRU) Invalidate(_ context.Context, tags ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, t := range tags {
+		for key := range l.tags[t] {
+			l.remove(l.items[key])
+		}
+	}
+	return nil
+}
+
+// Len returns the number of entries in the store.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.list.Len()
+}
+
+func (l *LRU) remove(el *list.Element) {
+	item := l.list.Remove(el).(*lruItem)
+	delete(l.items, item.key)
+	for _, t := range item.entry.Tags {
+		if delete(l.tags[t], item.key); len(l.tags[t]) == 0 {
+			delete(l.tags, t)
+		}
+	}
+}
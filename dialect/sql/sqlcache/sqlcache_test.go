@@ -0,0 +1,165 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlcache
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver_Query(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	drv := NewDriver(sql.OpenDB(dialect.SQLite, db))
+	ctx := context.Background()
+	query := "SELECT `users`.`id`, `users`.`name` FROM `users` WHERE `users`.`age` > ?"
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(30).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a8m").AddRow(2, "nati"))
+	scan := func(ctx context.Context, args ...any) []string {
+		rows := &sql.Rows{}
+		require.NoError(t, drv.Query(ctx, query, args, rows))
+		defer rows.Close()
+		var names []string
+		for rows.Next() {
+			var (
+				id   int
+				name string
+			)
+			require.NoError(t, rows.Scan(&id, &name))
+			names = append(names, name)
+		}
+		require.NoError(t, rows.Err())
+		return names
+	}
+	// Second query is served from the cache.
+	require.Equal(t, []string{"a8m", "nati"}, scan(ctx, 30))
+	require.Equal(t, []string{"a8m", "nati"}, scan(ctx, 30))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Different arguments and skipped contexts are not served from the cache.
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a8m"))
+	require.Equal(t, []string{"a8m"}, scan(ctx, 40))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(30).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a8m"))
+	require.Equal(t, []string{"a8m"}, scan(Skip(ctx), 30))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Invalidation by table.
+	require.NoError(t, drv.Store().Invalidate(ctx, TableTag("users")))
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(30).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "ariel"))
+	require.Equal(t, []string{"ariel"}, scan(ctx, 30))
+	require.Equal(t, []string{"ariel"}, scan(ctx, 30))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Transactions are not cached.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(30).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(3, "ariel"))
+	mock.ExpectCommit()
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	rows := &sql.Rows{}
+	require.NoError(t, tx.Query(ctx, query, []any{30}, rows))
+	require.NoError(t, rows.Close())
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDriver_OptIn(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	drv := NewDriver(sql.OpenDB(dialect.SQLite, db), OptIn(), TTL(time.Minute))
+	ctx := context.Background()
+	query := "SELECT COUNT(*) FROM `users`"
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		rows := &sql.Rows{}
+		require.NoError(t, drv.Query(ctx, query, []any{}, rows))
+		require.NoError(t, rows.Close())
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	for i := 0; i < 2; i++ {
+		rows := &sql.Rows{}
+		require.NoError(t, drv.Query(Enable(ctx), query, []any{}, rows))
+		n, err := sql.ScanInt(rows)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDriver_NodeContext(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	store := NewLRU(0)
+	drv := NewDriver(sql.OpenDB(dialect.Postgres, db), WithStore(store))
+	ctx := NewNodeContext(context.Background(), "users", 1)
+	query := `SELECT "users"."id" FROM "users" WHERE "users"."id" = $1 LIMIT 2`
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	rows := &sql.Rows{}
+	require.NoError(t, drv.Query(ctx, query, []any{1}, rows))
+	require.NoError(t, rows.Close())
+	// Marking is ignored for queries with other arguments.
+	mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	require.NoError(t, drv.Query(ctx, query, []any{2}, rows))
+	require.NoError(t, rows.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, 2, store.Len())
+
+	require.NoError(t, store.Invalidate(ctx, TableTag("users"), NodeTag("users", 2)))
+	require.Equal(t, 1, store.Len())
+	require.NoError(t, store.Invalidate(ctx, NodeTag("users", 1)))
+	require.Zero(t, store.Len())
+}
+
+func TestTables(t *testing.T) {
+	for q, tables := range map[string][]string{
+		"SELECT * FROM `users`": {"users"},
+		`SELECT * FROM "public"."users" JOIN "groups" AS "t1" ON 1`:  {"users", "groups"},
+		"SELECT * FROM users WHERE id IN (SELECT user_id FROM pets)": {"users", "pets"},
+		"SELECT * FROM `users` JOIN `users` AS `t1` ON 1":            {"users"},
+	} {
+		require.Equal(t, tables, Tables(q), q)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	l := NewLRU(2)
+	require.NoError(t, l.Add(ctx, "a", &Entry{Tags: []string{"users"}}, 0))
+	require.NoError(t, l.Add(ctx, "b", &Entry{Tags: []string{"pets"}}, 0))
+	_, err := l.Get(ctx, "a")
+	require.NoError(t, err)
+	// "b" is the least recently used entry.
+	require.NoError(t, l.Add(ctx, "c", &Entry{Tags: []string{"users", "pets"}}, 0))
+	_, err = l.Get(ctx, "b")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, l.Invalidate(ctx, "pets"))
+	_, err = l.Get(ctx, "c")
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = l.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Len(t, l.tags, 1)
+
+	require.NoError(t, l.Add(ctx, "d", &Entry{}, time.Nanosecond))
+	time.Sleep(time.Millisecond)
+	_, err = l.Get(ctx, "d")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, 1, l.Len())
+}
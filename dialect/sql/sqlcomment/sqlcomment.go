@@ -0,0 +1,335 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlcomment provides a dialect.Driver that tags the statements it executes with comments
+// in the sqlcommenter format (https://google.github.io/sqlcommenter/spec), in order to link the
+// entries of slow-query logs and database monitoring tools back to the code that executed them.
+//
+//	drv := sqlcomment.NewDriver(sqldrv,
+//		sqlcomment.StaticTags(sqlcomment.Tags{sqlcomment.KeyApplication: "billing"}),
+//		sqlcomment.WithTaggers(sqlcomment.CallerTagger("example.com/billing/ent")),
+//	)
+//	client := ent.NewClient(ent.Driver(drv))
+//
+//	// In an HTTP middleware.
+//	ctx := sqlcomment.WithTags(r.Context(), sqlcomment.Tags{
+//		sqlcomment.KeyRoute:       "/users/{id}",
+//		sqlcomment.KeyTraceparent: r.Header.Get("traceparent"),
+//	})
+//
+// The comment is appended to the end of the statement, and therefore, it does not change the
+// order of its placeholders in any dialect. The tags are sorted by their keys, and statements
+// with the same tags are rendered identically. Tags with values that change on every request,
+// such as trace ids, make the text of each statement unique. The statement cache of the SQL
+// driver (see sql.StatementCache) executes tagged statements without a prepared statement, unless
+// it ignores trailing comments (see sql.IgnoreTrailingComments), in which case the tags of cached
+// statements are not sent. Result caches that wrap the driver, such as the sqlcache package, should
+// wrap this driver, and not the other way around:
+//
+//	drv := sqlcache.NewDriver(sqlcomment.NewDriver(sqldrv))
+package sqlcomment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect"
+)
+
+// List of common tag keys. The keys that are not prefixed with "ent_"
+// are defined by the sqlcommenter specification.
+const (
+	KeyApplication = "application"
+	KeyFramework   = "framework"
+	KeyRoute       = "route"
+	KeyController  = "controller"
+	KeyAction      = "action"
+	KeyTraceparent = "traceparent"
+	KeyTracestate  = "tracestate"
+	KeyDBDriver    = "db_driver"
+	// KeyCaller holds the function that executed the statement. See CallerTagger.
+	KeyCaller = "caller"
+	// KeyEntType and KeyEntOp hold the node type and the operation of the
+	// query, as defined by the ent.QueryContext. See QueryTagger.
+	KeyEntType = "ent_type"
+	KeyEntOp   = "ent_op"
+)
+
+type (
+	// Tags are the key-value pairs that are rendered in the comment of a statement.
+	Tags map[string]string
+
+	// A Tagger returns the tags of a statement that is executed with the given context.
+	Tagger func(context.Context) Tags
+)
+
+// Merge returns a new Tags that holds the tags of t, overridden by the given tags.
+func (t Tags) Merge(tags Tags) Tags {
+	merged := make(Tags, len(t)+len(tags))
+	for k, v := range t {
+		merged[k] = v
+	}
+	for k, v := range tags {
+		merged[k] = v
+	}
+	return merged
+}
+
+// Driver is a dialect.Driver that tags the statements it executes with SQL comments.
+type Driver struct {
+	dialect.Driver
+	taggers []Tagger
+}
+
+// Option allows configuring the tagging Driver.
+type Option func(*Driver)
+
+// WithTaggers adds the given taggers to the driver. The tags of taggers that were added
+// last override the tags of the taggers that were added before them, and the tags of
+// the ContextTagger and the QueryTagger, which are enabled by default.
+func WithTaggers(taggers ...Tagger) Option {
+	return func(d *Driver) {
+		d.taggers = append(d.taggers, taggers...)
+	}
+}
+
+// StaticTags adds the given tags to all statements that are executed by the driver.
+func StaticTags(tags Tags) Option {
+	return WithTaggers(func(context.Context) Tags {
+		return tags
+	})
+}
+
+// NewDriver returns a new Driver that tags the statements of the given driver.
+func NewDriver(drv dialect.Driver, opts ...Option) *Driver {
+	d := &Driver{Driver: drv, taggers: []Tagger{ContextTagger, QueryTagger}}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Exec tags the statement and executes it using the underlying driver.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Exec(ctx, d.comment(ctx, query), args, v)
+}
+
+// Query tags the query and executes it using the underlying driver.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	return d.Driver.Query(ctx, d.comment(ctx, query), args, v)
+}
+
+// ExecContext tags the statement and calls the underlying driver ExecContext method if it is supported.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return drv.ExecContext(ctx, d.comment(ctx, query), args...)
+}
+
+// QueryContext tags the query and calls the underlying driver QueryContext method if it is supported.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return drv.QueryContext(ctx, d.comment(ctx, query), args...)
+}
+
+// Tx starts a transaction that tags its statements.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d}, nil
+}
+
+// BeginTx calls the underlying driver BeginTx command if it is supported,
+// and returns a transaction that tags its statements.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d}, nil
+}
+
+// comment appends the comment of the tags of the given context to the query.
+func (d *Driver) comment(ctx context.Context, query string) string {
+	var tags Tags
+	for _, t := range d.taggers {
+		tags = tags.Merge(t(ctx))
+	}
+	return Append(query, tags)
+}
+
+// Tx is a dialect.Tx that tags the statements it executes with SQL comments.
+type Tx struct {
+	dialect.Tx
+	drv *Driver
+}
+
+// Exec tags the statement and executes it using the underlying transaction.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	return t.Tx.Exec(ctx, t.drv.comment(ctx, query), args, v)
+}
+
+// Query tags the query and executes it using the underlying transaction.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	return t.Tx.Query(ctx, t.drv.comment(ctx, query), args, v)
+}
+
+// Savepoint calls the Savepoint method of the underlying transaction if it is
+// supported, and returns a nested transaction that tags its statements.
+func (t *Tx) Savepoint(ctx context.Context, name string) (dialect.Tx, error) {
+	sp, ok := t.Tx.(interface {
+		Savepoint(context.Context, string) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.Savepoint is not supported")
+	}
+	nested, err := sp.Savepoint(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: nested, drv: t.drv}, nil
+}
+
+// Comment returns the SQL comment of the given tags in the sqlcommenter format, or an empty
+// string if there are no tags. Keys and values are URL-encoded, and pairs are sorted by their
+// keys. Pairs with empty values are omitted.
+//
+//	Comment(Tags{"route": "/users/{id}", "ent_op": "All"})
+//	// /*ent_op='All',route='%2Fusers%2F%7Bid%7D'*/
+func Comment(tags Tags) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if k == "" || v == "" {
+			continue
+		}
+		// URL-encoding escapes quotes and comment delimiters, as well
+		// as placeholder characters, such as "?" and "$".
+		pairs = append(pairs, escape(k)+"='"+escape(v)+"'")
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	sort.Strings(pairs)
+	return "/*" + strings.Join(pairs, ",") + "*/"
+}
+
+// Append appends the comment of the given tags to the end of the query, and before its terminating
+// semicolon, if it exists. Queries that already end with a comment are returned as is.
+func Append(query string, tags Tags) string {
+	c := Comment(tags)
+	if c == "" {
+		return query
+	}
+	stmt := strings.TrimRight(query, " \t\n")
+	semicolon := strings.HasSuffix(stmt, ";")
+	stmt = strings.TrimRight(strings.TrimSuffix(stmt, ";"), " \t\n")
+	if strings.HasSuffix(stmt, "*/") {
+		return query
+	}
+	stmt += " " + c
+	if semicolon {
+		stmt += ";"
+	}
+	return stmt
+}
+
+// escape URL-encodes the given key or value, as defined by the sqlcommenter specification.
+func escape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// tagsKey is the context key for the tags of statements.
+type tagsKey struct{}
+
+// WithTags returns a new context that tags the statements that are executed with it.
+// The given tags are merged with the tags that were already attached to the context.
+func WithTags(ctx context.Context, tags Tags) context.Context {
+	return context.WithValue(ctx, tagsKey{}, FromContext(ctx).Merge(tags))
+}
+
+// FromContext returns the tags that were attached to the context using WithTags, if any.
+func FromContext(ctx context.Context) Tags {
+	tags, _ := ctx.Value(tagsKey{}).(Tags)
+	return tags
+}
+
+// ContextTagger returns the tags that were attached to the context using WithTags.
+func ContextTagger(ctx context.Context) Tags {
+	return FromContext(ctx)
+}
+
+// QueryTagger returns the node type and the operation of the ent.QueryContext of the context,
+// if it exists. For example, ent_type='User' and ent_op='All' for the statements of
+// `client.User.Query().All(ctx)`, including the statements of its eager-loaded edges.
+func QueryTagger(ctx context.Context) Tags {
+	qc := ent.QueryFromContext(ctx)
+	if qc == nil {
+		return nil
+	}
+	return Tags{KeyEntType: qc.Type, KeyEntOp: qc.Op}
+}
+
+// module is the path of the ent module. e.g. "github.com/jogly/ent".
+var module = strings.TrimSuffix(reflect.TypeOf(Driver{}).PkgPath(), "/dialect/sql/sqlcomment")
+
+// CallerTagger returns a Tagger that tags statements with the function that executed
+// them. Functions of the ent module, the runtime and the database/sql packages are skipped,
+// as well as the functions of the given packages, which should include the package of the
+// generated code. e.g. CallerTagger("example.com/app/ent") for `client.User.Query().All(ctx)`
+// tags the statement with the function that called the All method.
+func CallerTagger(skip ...string) Tagger {
+	skip = append([]string{module, "runtime", "database/sql"}, skip...)
+	return func(context.Context) Tags {
+		pcs := make([]uintptr, 64)
+		frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+		for {
+			f, more := frames.Next()
+			if f.Function != "" && !skipped(f.Function, skip) {
+				return Tags{KeyCaller: f.Function}
+			}
+			if !more {
+				return nil
+			}
+		}
+	}
+}
+
+// skipped reports if the function belongs to one of the given packages, or to their sub-packages.
+func skipped(fn string, pkgs []string) bool {
+	for _, p := range pkgs {
+		if !strings.HasPrefix(fn, p) {
+			continue
+		}
+		if rest := fn[len(p):]; rest == "" || rest[0] == '.' || rest[0] == '/' {
+			return true
+		}
+	}
+	return false
+}
+
+var _ dialect.Driver = (*Driver)(nil)
@@ -0,0 +1,107 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlcomment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComment(t *testing.T) {
+	require.Empty(t, Comment(nil))
+	require.Empty(t, Comment(Tags{"route": ""}))
+	require.Equal(t, "/*ent_op='All',route='%2Fusers%2F%7Bid%7D'*/", Comment(Tags{"route": "/users/{id}", "ent_op": "All"}))
+	require.Equal(t, "/*a%20b='it%27s%20%3F%20%241%20%2A%2F'*/", Comment(Tags{"a b": "it's ? $1 */"}))
+}
+
+func TestAppend(t *testing.T) {
+	tags := Tags{KeyRoute: "/users"}
+	for query, want := range map[string]string{
+		"SELECT * FROM `users` WHERE `id` = ?":       "SELECT * FROM `users` WHERE `id` = ? /*route='%2Fusers'*/",
+		`SELECT * FROM "users" WHERE "id" = $1;`:     `SELECT * FROM "users" WHERE "id" = $1 /*route='%2Fusers'*/;`,
+		"DELETE FROM `users` ; \n":                   "DELETE FROM `users` /*route='%2Fusers'*/;",
+		"SELECT 1 /*route='%2Fpets'*/":               "SELECT 1 /*route='%2Fpets'*/",
+		"SELECT /*+ MAX_EXECUTION_TIME(1) */ * FROM": "SELECT /*+ MAX_EXECUTION_TIME(1) */ * FROM /*route='%2Fusers'*/",
+	} {
+		require.Equal(t, want, Append(query, tags))
+	}
+	require.Equal(t, "SELECT 1", Append("SELECT 1", nil))
+}
+
+func TestDriver(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv := NewDriver(sql.OpenDB(dialect.Postgres, db), StaticTags(Tags{KeyApplication: "api", KeyRoute: "default"}))
+	ctx := WithTags(context.Background(), Tags{KeyRoute: "/users"})
+	ctx = WithTags(ctx, Tags{KeyTraceparent: "00-abc-01"})
+	ctx = ent.NewQueryContext(ctx, &ent.QueryContext{Type: "User", Op: "All"})
+
+	mock.ExpectQuery(`SELECT "name" FROM "users" WHERE "id" = $1 /*application='api',ent_op='All',ent_type='User',route='default',traceparent='00-abc-01'*/`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+	rows := &sql.Rows{}
+	require.NoError(t, drv.Query(ctx, `SELECT "name" FROM "users" WHERE "id" = $1`, []any{1}, rows))
+	require.NoError(t, rows.Close())
+
+	drv = NewDriver(sql.OpenDB(dialect.Postgres, db))
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "name" = $1 WHERE "id" = $2 /*ent_op='All',ent_type='User',route='%2Fusers',traceparent='00-abc-01'*/`).
+		WithArgs("a8m", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM "pets" /*route='%2Fusers'*/`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("RELEASE SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	tx, err := drv.Tx(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, tx.Exec(ctx, `UPDATE "users" SET "name" = $1 WHERE "id" = $2`, []any{"a8m", 1}, nil))
+	sp, err := tx.(*Tx).Savepoint(ctx, "sp")
+	require.NoError(t, err)
+	require.NoError(t, sp.Exec(WithTags(context.Background(), Tags{KeyRoute: "/users"}), `DELETE FROM "pets"`, []any{}, nil))
+	require.NoError(t, sp.Commit())
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDriver_StatementCache(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv := NewDriver(sql.OpenDB(dialect.Postgres, db, sql.StatementCache(8)))
+	// Tagged statements are sent with their tags, and are not cached.
+	for _, tp := range []string{"00-a-01", "00-b-01"} {
+		mock.ExpectExec(`DELETE FROM "users" /*traceparent='` + tp + `'*/`).WillReturnResult(sqlmock.NewResult(0, 1))
+		ctx := WithTags(context.Background(), Tags{KeyTraceparent: tp})
+		require.NoError(t, drv.Exec(ctx, `DELETE FROM "users"`, []any{}, nil))
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv = NewDriver(sql.OpenDB(dialect.Postgres, db, sql.StatementCache(8, sql.IgnoreTrailingComments())))
+	// Per-request tags do not defeat the statement cache, but they are not sent.
+	stmt := mock.ExpectPrepare(`DELETE FROM "users"`)
+	for _, tp := range []string{"00-a-01", "00-b-01"} {
+		stmt.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		ctx := WithTags(context.Background(), Tags{KeyTraceparent: tp})
+		require.NoError(t, drv.Exec(ctx, `DELETE FROM "users"`, []any{}, nil))
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCallerTagger(t *testing.T) {
+	tags := CallerTagger()(context.Background())
+	require.Equal(t, Tags{KeyCaller: "testing.tRunner"}, tags)
+	tags = CallerTagger("testing")(context.Background())
+	require.Nil(t, tags, "all callers were skipped")
+	require.True(t, skipped("example.com/app/ent.(*UserQuery).All", []string{"example.com/app/ent"}))
+	require.True(t, skipped("example.com/app/ent/user.Name", []string{"example.com/app/ent"}))
+	require.False(t, skipped("example.com/app/entity.Name", []string{"example.com/app/ent"}))
+}
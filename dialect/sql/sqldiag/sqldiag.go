@@ -0,0 +1,507 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqldiag provides a diagnostic dialect.Driver that reports slow statements and N+1
+// query patterns, along with the ent.QueryContext and the caller frames of the statements.
+//
+//	drv := sqldiag.NewDriver(sqldrv,
+//		sqldiag.SlowThreshold(200*time.Millisecond),
+//		sqldiag.SkipPackages("example.com/app/ent"),
+//	)
+//	client := ent.NewClient(ent.Driver(drv))
+//
+//	// In an HTTP middleware.
+//	ctx := sqldiag.WithScope(r.Context())
+//
+// Unlike dialect.DebugDriver, which logs every statement, this driver logs only the statements that
+// took longer than the slow threshold, and the queries that were executed repeatedly with different
+// arguments in the same scope (e.g. an HTTP request). For example, loading the posts of each user
+// in a loop, instead of eager-loading them using WithPosts.
+package sqldiag
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect"
+)
+
+type (
+	// SlowQuery describes a statement that took longer than the slow threshold.
+	SlowQuery struct {
+		// Query and Args hold the statement as it was executed.
+		Query string
+		Args  []any
+		// Duration holds the time it took the driver to execute the statement.
+		// For queries, it does not include the time of reading their rows.
+		Duration time.Duration
+		// Type and Op hold the node type and the operation of the ent.QueryContext
+		// of the statement, if it exists. e.g. "User" and "All".
+		Type, Op string
+		// Frames hold the caller frames of the statement, outside of the skipped packages.
+		Frames []runtime.Frame
+	}
+
+	// NPlusOne describes a query that was executed repeatedly with different
+	// arguments in the same scope, and reached the N+1 threshold.
+	NPlusOne struct {
+		// Query holds the normalized query, with its literals and lists of
+		// placeholders replaced by a single placeholder.
+		Query string
+		// Count holds the number of executions with different arguments.
+		Count int
+		// Type and Op hold the node type and the operation of the
+		// ent.QueryContext of the query, if it exists. e.g. "Post" and "All".
+		Type, Op string
+		// Edge holds the table and the column that the query is joined on, or filtered by. For edge
+		// queries, it identifies the edge that is traversed. e.g. "posts.user_posts" for the O2M
+		// query `u.QueryPosts().All(ctx)`, and "user_groups.group_id" for the M2M query
+		// `u.QueryGroups().All(ctx)`. For other queries, it holds their first filtered column,
+		// such as "users.id" for `client.User.Get(ctx, id)`.
+		Edge string
+		// Frames hold the caller frames of the query that reached the threshold.
+		Frames []runtime.Frame
+	}
+)
+
+// String implements the fmt.Stringer interface.
+func (s *SlowQuery) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "slow query (%s)", s.Duration)
+	if s.Type != "" {
+		fmt.Fprintf(&b, " %s.%s", s.Type, s.Op)
+	}
+	fmt.Fprintf(&b, ": query=%v args=%v", s.Query, s.Args)
+	writeFrames(&b, s.Frames)
+	return b.String()
+}
+
+// String implements the fmt.Stringer interface.
+func (n *NPlusOne) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "N+1 query (%d executions)", n.Count)
+	if n.Type != "" {
+		fmt.Fprintf(&b, " %s.%s", n.Type, n.Op)
+	}
+	if n.Edge != "" {
+		fmt.Fprintf(&b, " on %s", n.Edge)
+	}
+	fmt.Fprintf(&b, ": query=%v", n.Query)
+	writeFrames(&b, n.Frames)
+	return b.String()
+}
+
+func writeFrames(b *strings.Builder, frames []runtime.Frame) {
+	for _, f := range frames {
+		fmt.Fprintf(b, "\n\t%s\n\t\t%s:%d", f.Function, f.File, f.Line)
+	}
+}
+
+// Driver is a dialect.Driver that reports slow statements and N+1 query patterns.
+type Driver struct {
+	dialect.Driver
+	slow       time.Duration
+	threshold  int
+	depth      int
+	skip       []string
+	onSlow     func(context.Context, *SlowQuery)
+	onNPlusOne func(context.Context, *NPlusOne)
+	global     *scope
+}
+
+// Option allows configuring the diagnostic Driver.
+type Option func(*Driver)
+
+// SlowThreshold enables the reporting of statements that took longer than d. Slow statements
+// are not reported by default.
+func SlowThreshold(d time.Duration) Option {
+	return func(drv *Driver) {
+		drv.slow = d
+	}
+}
+
+// NPlusOneThreshold sets the number of executions with different arguments after which a query
+// is reported as an N+1 query in its scope. Each query is reported once in a scope. Defaults to 5.
+func NPlusOneThreshold(n int) Option {
+	return func(d *Driver) {
+		d.threshold = n
+	}
+}
+
+// SkipPackages skips the frames of the given packages, and of their sub-packages, when collecting
+// the caller frames of statements. The frames of the ent module, the runtime and the database/sql
+// packages are always skipped. The given packages should include the package of the generated code.
+func SkipPackages(pkgs ...string) Option {
+	return func(d *Driver) {
+		d.skip = append(d.skip, pkgs...)
+	}
+}
+
+// Frames sets the maximum number of caller frames that are collected for reports. Defaults to 5.
+func Frames(n int) Option {
+	return func(d *Driver) {
+		d.depth = n
+	}
+}
+
+// OnSlowQuery sets the function that is called with the slow statements.
+func OnSlowQuery(f func(context.Context, *SlowQuery)) Option {
+	return func(d *Driver) {
+		d.onSlow = f
+	}
+}
+
+// OnNPlusOne sets the function that is called with the N+1 queries.
+func OnNPlusOne(f func(context.Context, *NPlusOne)) Option {
+	return func(d *Driver) {
+		d.onNPlusOne = f
+	}
+}
+
+// Log sets the logging function of the reports that are not handled by OnSlowQuery
+// or OnNPlusOne. Defaults to log.Println.
+func Log(logf func(context.Context, ...any)) Option {
+	return func(d *Driver) {
+		if d.onSlow == nil {
+			d.onSlow = func(ctx context.Context, s *SlowQuery) { logf(ctx, s) }
+		}
+		if d.onNPlusOne == nil {
+			d.onNPlusOne = func(ctx context.Context, n *NPlusOne) { logf(ctx, n) }
+		}
+	}
+}
+
+// TestingT is the interface that is shared between testing.T and
+// testing.B, and the TestingT interface of the generated enttest package.
+type TestingT interface {
+	Error(...any)
+}
+
+// Strict returns an Option that fails the given test on N+1 queries. In strict mode, statements that
+// are executed without a scope are counted in a single scope that lasts for the lifetime of the driver,
+// and therefore, the driver should be created per test. For example:
+//
+//	drv, err := sql.Open(dialect.SQLite, "file:ent?mode=memory&_fk=1")
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	client := enttest.NewClient(t, enttest.WithOptions(
+//		ent.Driver(sqldiag.NewDriver(drv, sqldiag.Strict(t))),
+//	))
+func Strict(t TestingT) Option {
+	return func(d *Driver) {
+		d.global = newScope()
+		d.onNPlusOne = func(_ context.Context, n *NPlusOne) {
+			t.Error(n.String())
+		}
+	}
+}
+
+// NewDriver returns a new Driver that reports the slow statements and
+// the N+1 queries that are executed using the given driver.
+func NewDriver(drv dialect.Driver, opts ...Option) *Driver {
+	d := &Driver{Driver: drv, threshold: 5, depth: 5}
+	for _, opt := range opts {
+		opt(d)
+	}
+	Log(func(_ context.Context, v ...any) { log.Println(v...) })(d)
+	d.skip = append([]string{module, "runtime", "database/sql"}, d.skip...)
+	return d
+}
+
+// Exec executes the statement using the underlying driver, and reports it if it is slow.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	defer d.observe(ctx, query, args, false, time.Now())
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query executes the query using the underlying driver, and reports it if it is slow or an N+1 query.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	defer d.observe(ctx, query, args, true, time.Now())
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// ExecContext calls the underlying driver ExecContext method if it is supported, and reports the statement if it is slow.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	defer d.observe(ctx, query, args, false, time.Now())
+	return drv.ExecContext(ctx, query, args...)
+}
+
+// QueryContext calls the underlying driver QueryContext method if it is supported, and reports the query if it is slow or an N+1 query.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	defer d.observe(ctx, query, args, true, time.Now())
+	return drv.QueryContext(ctx, query, args...)
+}
+
+// Tx starts a transaction that reports its statements.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d}, nil
+}
+
+// BeginTx calls the underlying driver BeginTx command if it is supported,
+// and returns a transaction that reports its statements.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d}, nil
+}
+
+// observe reports the statement if it took longer than the slow threshold, and counts
+// it in its scope, if it is a query. It is called after the statement was executed.
+func (d *Driver) observe(ctx context.Context, query string, args any, read bool, start time.Time) {
+	if elapsed := time.Since(start); d.slow > 0 && elapsed >= d.slow {
+		s := &SlowQuery{Query: query, Args: argsOf(args), Duration: elapsed, Frames: d.frames()}
+		if qc := ent.QueryFromContext(ctx); qc != nil {
+			s.Type, s.Op = qc.Type, qc.Op
+		}
+		d.onSlow(ctx, s)
+	}
+	if !read || d.threshold <= 0 {
+		return
+	}
+	sc := scopeFromContext(ctx)
+	if sc == nil {
+		sc = d.global
+	}
+	if sc == nil {
+		return
+	}
+	norm := Normalize(query)
+	// Statements that return rows, such as INSERT ... RETURNING, are executed
+	// using the Query method, but only SELECT queries are counted.
+	if !selectQuery(norm) {
+		return
+	}
+	if n := sc.add(norm, fmt.Sprint(args), d.threshold); n > 0 {
+		r := &NPlusOne{Query: norm, Count: n, Edge: edgeOf(norm), Frames: d.frames()}
+		if qc := ent.QueryFromContext(ctx); qc != nil {
+			r.Type, r.Op = qc.Type, qc.Op
+		}
+		d.onNPlusOne(ctx, r)
+	}
+}
+
+// module is the path of the ent module. e.g. "github.com/jogly/ent".
+var module = strings.TrimSuffix(reflect.TypeOf(Driver{}).PkgPath(), "/dialect/sql/sqldiag")
+
+// frames returns the caller frames of the statement, outside of the skipped packages.
+func (d *Driver) frames() []runtime.Frame {
+	if d.depth <= 0 {
+		return nil
+	}
+	var (
+		frames []runtime.Frame
+		pcs    = make([]uintptr, 64)
+		it     = runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	)
+	for len(frames) < d.depth {
+		f, more := it.Next()
+		if f.Function != "" && !skipped(f.Function, d.skip) {
+			frames = append(frames, f)
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// skipped reports if the function belongs to one of the given packages, or to their sub-packages.
+func skipped(fn string, pkgs []string) bool {
+	for _, p := range pkgs {
+		if !strings.HasPrefix(fn, p) {
+			continue
+		}
+		if rest := fn[len(p):]; rest == "" || rest[0] == '.' || rest[0] == '/' {
+			return true
+		}
+	}
+	return false
+}
+
+// argsOf returns the arguments of the Exec and Query methods as a slice.
+func argsOf(args any) []any {
+	switch args := args.(type) {
+	case nil:
+		return nil
+	case []any:
+		return args
+	default:
+		return []any{args}
+	}
+}
+
+// Tx is a dialect.Tx that reports the slow statements and the N+1 queries it executes.
+type Tx struct {
+	dialect.Tx
+	drv *Driver
+}
+
+// Exec executes the statement using the underlying transaction, and reports it if it is slow.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	defer t.drv.observe(ctx, query, args, false, time.Now())
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+// Query executes the query using the underlying transaction, and reports it if it is slow or an N+1 query.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	defer t.drv.observe(ctx, query, args, true, time.Now())
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+// Savepoint calls the Savepoint method of the underlying transaction if it is
+// supported, and returns a nested transaction that reports its statements.
+func (t *Tx) Savepoint(ctx context.Context, name string) (dialect.Tx, error) {
+	sp, ok := t.Tx.(interface {
+		Savepoint(context.Context, string) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.Savepoint is not supported")
+	}
+	nested, err := sp.Savepoint(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: nested, drv: t.drv}, nil
+}
+
+// scope counts the executions of the queries of a single unit of work, such as an HTTP request.
+type scope struct {
+	mu      sync.Mutex
+	queries map[string]map[string]struct{}
+}
+
+func newScope() *scope {
+	return &scope{queries: make(map[string]map[string]struct{})}
+}
+
+// add records the execution of the normalized query with the given arguments, and returns the number of
+// executions with different arguments if it has just reached the threshold. Otherwise, it returns 0.
+func (s *scope) add(query, args string, threshold int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	seen, ok := s.queries[query]
+	if !ok {
+		seen = make(map[string]struct{})
+		s.queries[query] = seen
+	}
+	// A nil set marks a query that was already reported.
+	if seen == nil {
+		return 0
+	}
+	seen[args] = struct{}{}
+	if n := len(seen); n >= threshold {
+		s.queries[query] = nil
+		return n
+	}
+	return 0
+}
+
+// scopeKey is the context key for the scope of queries.
+type scopeKey struct{}
+
+// WithScope returns a new context that starts a new scope for the N+1 detection. Queries that are
+// executed repeatedly with different arguments in the same scope are reported as N+1 queries, and
+// queries that are executed without a scope are not counted, unless the driver is in strict mode.
+func WithScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, newScope())
+}
+
+func scopeFromContext(ctx context.Context) *scope {
+	s, _ := ctx.Value(scopeKey{}).(*scope)
+	return s
+}
+
+var (
+	commentRe = regexp.MustCompile(`/\*.*?\*/|--[^\n]*`)
+	literalRe = regexp.MustCompile(`'(?:[^']|'')*'|\$\d+|\b\d+(?:\.\d+)?\b`)
+	listRe    = regexp.MustCompile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+)
+
+// Normalize returns the normalized form of the query that is used for detecting N+1 queries. Comments
+// are removed, literals and placeholders are replaced with "?", and lists of placeholders are collapsed.
+//
+//	Normalize("SELECT * FROM users WHERE id IN ($1, $2) AND age > 30 /*route='/users'*/")
+//	// SELECT * FROM users WHERE id IN (?) AND age > ?
+func Normalize(query string) string {
+	query = commentRe.ReplaceAllString(query, " ")
+	query = literalRe.ReplaceAllString(query, "?")
+	query = listRe.ReplaceAllString(query, "IN (?)")
+	return strings.TrimSpace(spaceRe.ReplaceAllString(query, " "))
+}
+
+// selectQuery reports if the normalized query is a SELECT query.
+func selectQuery(query string) bool {
+	i := strings.IndexAny(query, " (")
+	if i == -1 {
+		return false
+	}
+	switch strings.ToUpper(query[:i]) {
+	case "SELECT", "WITH":
+		return true
+	default:
+		return false
+	}
+}
+
+const ident = "[`\"]?(\\w+)[`\"]?"
+
+var (
+	// joinEdgeRe matches the joins of the edge queries of the generated code. For example, the
+	// join table of M2M edges, or the foreign-key of M2O edges, that are joined with the target table:
+	//
+	//	... JOIN (SELECT ... FROM `user_groups` WHERE `user_groups`.`user_id` = ?) AS `t1` ON `groups`.`id` = `t1`.`group_id`
+	joinEdgeRe = regexp.MustCompile(`FROM ` + ident + `(?: WHERE [^()]*)?\) AS ` + ident + ` ON (?:` + ident + `\.)?` + ident + ` = ` + ident + `\.` + ident)
+	// filterEdgeRe matches the first predicate of queries, such as the foreign-key of O2M edge queries:
+	//
+	//	SELECT ... FROM `posts` WHERE `user_posts` = ?
+	filterEdgeRe = regexp.MustCompile(`^SELECT .*? FROM ` + ident + ` WHERE (?:` + ident + `\.)?` + ident + ` (?:=|IN) `)
+)
+
+// edgeOf returns the table and the column that the normalized query is joined on, or filtered by.
+func edgeOf(query string) string {
+	if m := joinEdgeRe.FindStringSubmatch(query); m != nil {
+		return m[1] + "." + m[6]
+	}
+	if m := filterEdgeRe.FindStringSubmatch(query); m != nil {
+		return m[1] + "." + m[3]
+	}
+	return ""
+}
+
+var _ dialect.Driver = (*Driver)(nil)
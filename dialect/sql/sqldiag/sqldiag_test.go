@@ -0,0 +1,140 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqldiag
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDriver struct {
+	dialect.Driver
+	delay   time.Duration
+	queries []string
+}
+
+func (d *mockDriver) Exec(_ context.Context, query string, _, _ any) error {
+	d.queries = append(d.queries, query)
+	time.Sleep(d.delay)
+	return nil
+}
+
+func (d *mockDriver) Query(_ context.Context, query string, _, _ any) error {
+	d.queries = append(d.queries, query)
+	time.Sleep(d.delay)
+	return nil
+}
+
+func (d *mockDriver) Tx(context.Context) (dialect.Tx, error) { return dialect.NopTx(d), nil }
+
+func TestNormalize(t *testing.T) {
+	for query, want := range map[string]string{
+		"SELECT * FROM users WHERE id IN ($1, $2) AND age > 30 /*route='/users'*/":      "SELECT * FROM users WHERE id IN (?) AND age > ?",
+		"SELECT * FROM `users` WHERE `name` = 'a''8m'\n\tLIMIT 1":                       "SELECT * FROM `users` WHERE `name` = ? LIMIT ?",
+		"SELECT /*+ MAX_EXECUTION_TIME(1) */ `t1`.`id` FROM `t1` WHERE `id` in (?,?,?)": "SELECT `t1`.`id` FROM `t1` WHERE `id` IN (?)",
+	} {
+		require.Equal(t, want, Normalize(query))
+	}
+}
+
+func TestEdgeOf(t *testing.T) {
+	for query, want := range map[string]string{
+		"SELECT DISTINCT `posts`.`id` FROM `posts` WHERE `user_posts` = ?": "posts.user_posts",
+		"SELECT DISTINCT `groups`.`id` FROM `groups` JOIN (SELECT `user_groups`.`group_id` FROM `user_groups` WHERE `user_groups`.`user_id` = ?) AS `t1` ON `groups`.`id` = `t1`.`group_id`": "user_groups.group_id",
+		`SELECT DISTINCT "users"."id" FROM "users" JOIN (SELECT "user_children" FROM "users" WHERE "id" = ?) AS "t1" ON "users"."id" = "t1"."user_children"`:                                 "users.user_children",
+		`SELECT "users"."id" FROM "users" WHERE "users"."id" = ? LIMIT ?`: "users.id",
+		"SELECT COUNT(*) FROM `users`":                                    "",
+	} {
+		require.Equal(t, want, edgeOf(Normalize(query)))
+	}
+}
+
+func TestNPlusOne(t *testing.T) {
+	var reports []*NPlusOne
+	drv := NewDriver(&mockDriver{}, NPlusOneThreshold(3), OnNPlusOne(func(_ context.Context, n *NPlusOne) {
+		reports = append(reports, n)
+	}))
+	query := "SELECT DISTINCT `posts`.`id` FROM `posts` WHERE `user_posts` = ?"
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, drv.Query(ctx, query, []any{i}, nil))
+	}
+	require.Empty(t, reports, "queries without a scope are not counted")
+
+	ctx = ent.NewQueryContext(WithScope(ctx), &ent.QueryContext{Type: "Post", Op: "All"})
+	for i := 0; i < 3; i++ {
+		require.NoError(t, drv.Query(ctx, query, []any{1}, nil))
+		require.NoError(t, drv.Exec(ctx, "UPDATE `posts` SET `likes` = ? WHERE `id` = ?", []any{i, i}, nil))
+	}
+	require.Empty(t, reports, "executions with the same arguments or statements are not counted")
+
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	for i := 2; i < 6; i++ {
+		require.NoError(t, tx.Query(ctx, query, []any{i}, nil))
+	}
+	require.Len(t, reports, 1, "queries are reported once in a scope")
+	r := reports[0]
+	require.Equal(t, 3, r.Count)
+	require.Equal(t, "Post", r.Type)
+	require.Equal(t, "All", r.Op)
+	require.Equal(t, "posts.user_posts", r.Edge)
+	require.Equal(t, Normalize(query), r.Query)
+	require.NotEmpty(t, r.Frames)
+	require.Equal(t, "testing.tRunner", r.Frames[0].Function, "frames of the ent module are skipped")
+	require.True(t, strings.HasPrefix(r.String(), "N+1 query (3 executions) Post.All on posts.user_posts: query="))
+
+	// A new scope counts the queries from scratch.
+	ctx = WithScope(ctx)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, drv.Query(ctx, query, []any{i}, nil))
+	}
+	require.Len(t, reports, 2)
+}
+
+func TestSlowQuery(t *testing.T) {
+	var reports []*SlowQuery
+	mock := &mockDriver{}
+	drv := NewDriver(mock, SlowThreshold(10*time.Millisecond), Frames(1), OnSlowQuery(func(_ context.Context, s *SlowQuery) {
+		reports = append(reports, s)
+	}))
+	ctx := ent.NewQueryContext(context.Background(), &ent.QueryContext{Type: "User", Op: "Count"})
+	require.NoError(t, drv.Query(ctx, "SELECT COUNT(*) FROM `users`", []any{}, nil))
+	require.Empty(t, reports)
+
+	mock.delay = 20 * time.Millisecond
+	require.NoError(t, drv.Query(ctx, "SELECT COUNT(*) FROM `users`", []any{}, nil))
+	require.NoError(t, drv.Exec(context.Background(), "DELETE FROM `users` WHERE `id` = ?", []any{1}, nil))
+	require.Len(t, reports, 2)
+	require.Equal(t, "User", reports[0].Type)
+	require.Equal(t, "Count", reports[0].Op)
+	require.GreaterOrEqual(t, reports[0].Duration, mock.delay)
+	require.Len(t, reports[0].Frames, 1)
+	require.Empty(t, reports[1].Type)
+	require.Equal(t, []any{1}, reports[1].Args)
+	require.Contains(t, reports[1].String(), "query=DELETE FROM `users` WHERE `id` = ? args=[1]")
+}
+
+type mockT struct{ errors []string }
+
+func (t *mockT) Error(args ...any) { t.errors = append(t.errors, fmt.Sprint(args...)) }
+
+func TestStrict(t *testing.T) {
+	mt := &mockT{}
+	drv := NewDriver(&mockDriver{}, Strict(mt))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, drv.Query(context.Background(), "INSERT INTO `users` (`name`) VALUES (?) RETURNING `id`", []any{i}, nil))
+		require.NoError(t, drv.Query(context.Background(), "SELECT `name` FROM `users` WHERE `id` = ?", []any{i}, nil))
+	}
+	require.Len(t, mt.errors, 1)
+	require.Contains(t, mt.errors[0], "N+1 query (5 executions) on users.id")
+}
@@ -0,0 +1,444 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlencrypt
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/dialect/sql/sqlgraph"
+	"github.com/jogly/ent/schema/field"
+)
+
+// EncryptFields encrypts in place the values of the given field specs
+// that are stored in one of the encrypted columns.
+func EncryptFields(ctx context.Context, kp KeyProvider, specs []*sqlgraph.FieldSpec, columns map[string]Mode) error {
+	for _, spec := range specs {
+		mode, ok := columns[spec.Column]
+		if !ok {
+			continue
+		}
+		v, err := EncryptValue(ctx, kp, spec.Value, mode)
+		if err != nil {
+			return fmt.Errorf("encrypt field %q: %w", spec.Column, err)
+		}
+		spec.Value = v
+	}
+	return nil
+}
+
+// FieldEQ returns a predicate to check if the decrypted value of the field
+// is equal to the given value. The field must be encrypted in deterministic
+// mode, and the KeyProvider is taken from the selector context.
+//
+// If the KeyProvider implements the KeyLister interface, the value is matched
+// against its ciphertexts for all keys. Otherwise, only values that were encrypted
+// with the current key are matched.
+func FieldEQ(name string, v any) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if cts, ok := ciphertexts(s, v); ok {
+			s.Where(in(s.C(name), cts))
+		}
+	}
+}
+
+// FieldNEQ returns a predicate to check if the decrypted value of the field
+// is not equal to the given value.
+func FieldNEQ(name string, v any) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if cts, ok := ciphertexts(s, v); ok {
+			s.Where(sql.Not(in(s.C(name), cts)))
+		}
+	}
+}
+
+// FieldIn returns a predicate to check if the decrypted value of the field
+// is IN the given values.
+func FieldIn[T any](name string, vs ...T) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if cts, ok := ciphertexts(s, vs...); ok {
+			s.Where(sql.In(s.C(name), cts...))
+		}
+	}
+}
+
+// FieldNotIn returns a predicate to check if the decrypted value of the field
+// is NOT IN the given values.
+func FieldNotIn[T any](name string, vs ...T) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if cts, ok := ciphertexts(s, vs...); ok {
+			s.Where(sql.NotIn(s.C(name), cts...))
+		}
+	}
+}
+
+func in(c string, cts []any) *sql.Predicate {
+	if len(cts) == 1 {
+		return sql.EQ(c, cts[0])
+	}
+	return sql.In(c, cts...)
+}
+
+// ciphertexts returns the deterministic ciphertexts of the given predicate arguments, using
+// the KeyProvider of the selector context. Errors are recorded on the selector.
+func ciphertexts[T any](s *sql.Selector, vs ...T) ([]any, bool) {
+	cts, err := deterministic(s.Context(), FromContext(s.Context()), vs)
+	if err != nil {
+		s.AddError(err)
+		return nil, false
+	}
+	return cts, true
+}
+
+func deterministic[T any](ctx context.Context, kp KeyProvider, vs []T) ([]any, error) {
+	if kp == nil {
+		return nil, ErrMissingKeyProvider
+	}
+	var ids []string
+	if kl, ok := kp.(KeyLister); ok {
+		var err error
+		if ids, err = kl.KeyIDs(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		id, err := kp.CurrentKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = []string{id}
+	}
+	cts := make([]any, 0, len(vs)*len(ids))
+	for _, v := range vs {
+		b, isString, err := plaintext(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			ct, err := encrypt(ctx, kp, id, b, Deterministic)
+			if err != nil {
+				return nil, err
+			}
+			cts = append(cts, encode(ct, isString))
+		}
+	}
+	return cts, nil
+}
+
+type (
+	// ReencryptSpec holds the information for re-encrypting the
+	// encrypted columns of a table with the current key.
+	ReencryptSpec struct {
+		Table   string
+		Schema  string
+		ID      string // ID column.
+		Columns []*Column
+		// BatchSize defines the number of rows that are loaded
+		// and updated at once. Defaults to 100.
+		BatchSize int
+	}
+
+	// Column describes an encrypted column.
+	Column struct {
+		Name string
+		Type field.Type
+		Mode Mode
+	}
+)
+
+// Reencrypt re-encrypts all values in the encrypted columns of the table that were
+// not encrypted with the current key of the KeyProvider. Rows are scanned in batches
+// ordered by their ID, and rows that contain at least one value encrypted with
+// an old key are updated one by one. It returns the number of updated rows.
+//
+// Reencrypt can be executed while the table is in use, because values that were
+// encrypted with old keys are still readable as long as their keys are provided.
+func Reencrypt(ctx context.Context, drv dialect.Driver, kp KeyProvider, spec *ReencryptSpec) (int, error) {
+	if kp == nil {
+		return 0, ErrMissingKeyProvider
+	}
+	if spec.ID == "" || len(spec.Columns) == 0 {
+		return 0, fmt.Errorf("sqlencrypt: missing id or columns for re-encrypting table %q", spec.Table)
+	}
+	current, err := kp.CurrentKey(ctx)
+	if err != nil {
+		return 0, err
+	}
+	size := spec.BatchSize
+	if size <= 0 {
+		size = 100
+	}
+	var (
+		last    any
+		updated int
+		b       = sql.Dialect(drv.Dialect())
+	)
+	for {
+		rows, err := scanBatch(ctx, drv, b, spec, last, size)
+		if err != nil {
+			return updated, err
+		}
+		for _, r := range rows {
+			update := b.Update(spec.Table).Schema(spec.Schema).Where(sql.EQ(spec.ID, r[0]))
+			for i, c := range spec.Columns {
+				v, ok, err := reencrypt(ctx, kp, current, c, r[i+1])
+				if err != nil {
+					return updated, fmt.Errorf("re-encrypt column %q of row %v: %w", c.Name, r[0], err)
+				}
+				if ok {
+					update.Set(c.Name, v)
+				}
+			}
+			if update.Empty() {
+				continue
+			}
+			query, args := update.Query()
+			if err := drv.Exec(ctx, query, args, nil); err != nil {
+				return updated, err
+			}
+			updated++
+		}
+		if len(rows) < size {
+			return updated, nil
+		}
+		last = rows[len(rows)-1][0]
+	}
+}
+
+// scanBatch loads the next batch of rows (ID first, then the encrypted columns).
+func scanBatch(ctx context.Context, drv dialect.Driver, b *sql.DialectBuilder, spec *ReencryptSpec, last any, size int) ([][]any, error) {
+	columns := make([]string, 0, len(spec.Columns)+1)
+	columns = append(columns, spec.ID)
+	for _, c := range spec.Columns {
+		columns = append(columns, c.Name)
+	}
+	t := b.Table(spec.Table).Schema(spec.Schema)
+	selector := b.Select(t.Columns(columns...)...).
+		From(t).
+		OrderBy(t.C(spec.ID)).
+		Limit(size)
+	if last != nil {
+		selector.Where(sql.GT(t.C(spec.ID), last))
+	}
+	query, args := selector.Query()
+	rows := &sql.Rows{}
+	if err := drv.Query(ctx, query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var batch [][]any
+	for rows.Next() {
+		values := make([]any, len(columns))
+		for i := range values {
+			values[i] = new(any)
+		}
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		for i := range values {
+			values[i] = *values[i].(*any)
+		}
+		batch = append(batch, values)
+	}
+	return batch, rows.Err()
+}
+
+// reencrypt returns the value encrypted with the current key, and false
+// if it is NULL, empty or already encrypted with the current key.
+func reencrypt(ctx context.Context, kp KeyProvider, current string, c *Column, v any) (driver.Value, bool, error) {
+	if v == nil {
+		return nil, false, nil
+	}
+	switch {
+	case c.Type == field.TypeBytes:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, false, fmt.Errorf("unexpected value type %T", v)
+		}
+		if len(b) == 0 {
+			return nil, false, nil
+		}
+		if id, err := KeyID(b); err != nil || id == current {
+			return nil, false, err
+		}
+		plain, err := Decrypt(ctx, kp, b)
+		if err != nil {
+			return nil, false, err
+		}
+		b, err = Encrypt(ctx, kp, plain, c.Mode)
+		return b, err == nil, err
+	default:
+		var s string
+		switch v := v.(type) {
+		case string:
+			s = v
+		case []byte:
+			s = string(v)
+		default:
+			return nil, false, fmt.Errorf("unexpected value type %T", v)
+		}
+		if s == "" {
+			return nil, false, nil
+		}
+		if id, err := stringKeyID(s); err != nil || id == current {
+			return nil, false, err
+		}
+		plain, err := DecryptString(ctx, kp, s)
+		if err != nil {
+			return nil, false, err
+		}
+		s, err = EncryptString(ctx, kp, plain, c.Mode)
+		return s, err == nil, err
+	}
+}
+
+func stringKeyID(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("sqlencrypt: decoding ciphertext: %w", err)
+	}
+	return KeyID(b)
+}
+
+// DecryptRows returns a ColumnScanner that decrypts the values of the given encrypted
+// columns while scanning the rows. It is used by the generated code for scanning the
+// fields that were selected by the Select and GroupBy builders. Note that the results
+// of aggregation functions over encrypted columns are scanned as is.
+func DecryptRows(ctx context.Context, kp KeyProvider, rows sql.ColumnScanner, columns ...*Column) sql.ColumnScanner {
+	return &decryptRows{ColumnScanner: rows, ctx: ctx, kp: kp, columns: columns}
+}
+
+type decryptRows struct {
+	sql.ColumnScanner
+	ctx     context.Context
+	kp      KeyProvider
+	columns []*Column
+	// encrypted holds the encrypted column of each of
+	// the result columns, and is resolved on first scan.
+	encrypted []*Column
+}
+
+// Scan implements the sql.ColumnScanner interface.
+func (r *decryptRows) Scan(dest ...any) error {
+	if r.encrypted == nil {
+		names, err := r.Columns()
+		if err != nil {
+			return err
+		}
+		r.encrypted = make([]*Column, len(names))
+		for i, name := range names {
+			for _, c := range r.columns {
+				if c.Name == name {
+					r.encrypted[i] = c
+				}
+			}
+		}
+	}
+	args := make([]any, len(dest))
+	for i := range dest {
+		args[i] = dest[i]
+		if i < len(r.encrypted) && r.encrypted[i] != nil {
+			args[i] = &decryptScanner{rows: r, column: r.encrypted[i], dest: dest[i]}
+		}
+	}
+	return r.ColumnScanner.Scan(args...)
+}
+
+// decryptScanner decrypts the scanned value of an encrypted
+// column, and assigns the plaintext to its destination.
+type decryptScanner struct {
+	rows   *decryptRows
+	column *Column
+	dest   any
+}
+
+// Scan implements the sql.Scanner interface.
+func (s *decryptScanner) Scan(src any) error {
+	var ct []byte
+	switch v := src.(type) {
+	case nil:
+	case string:
+		ct = []byte(v)
+	case []byte:
+		ct = v
+	default:
+		return fmt.Errorf("sqlencrypt: unexpected value type %T for column %q", src, s.column.Name)
+	}
+	if len(ct) > 0 {
+		var err error
+		if s.column.Type == field.TypeBytes {
+			src, err = Decrypt(s.rows.ctx, s.rows.kp, ct)
+		} else {
+			src, err = DecryptString(s.rows.ctx, s.rows.kp, string(ct))
+		}
+		if err != nil {
+			return fmt.Errorf("sqlencrypt: decrypt column %q: %w", s.column.Name, err)
+		}
+	}
+	if err := assign(s.dest, src); err != nil {
+		return fmt.Errorf("sqlencrypt: scan column %q: %w", s.column.Name, err)
+	}
+	return nil
+}
+
+// assign assigns the given plaintext value (nil, string or []byte)
+// to the destination pointer that was passed to the Scan method.
+func assign(dest, src any) error {
+	if sc, ok := dest.(stdsql.Scanner); ok {
+		return sc.Scan(src)
+	}
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("destination not a pointer: %T", dest)
+	}
+	rv = rv.Elem()
+	if src == nil {
+		switch rv.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice:
+			rv.Set(reflect.Zero(rv.Type()))
+			return nil
+		default:
+			return fmt.Errorf("converting NULL to %s is unsupported", rv.Kind())
+		}
+	}
+	switch v := src.(type) {
+	case []byte:
+		switch {
+		case rv.Kind() == reflect.String:
+			rv.SetString(string(v))
+			return nil
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			rv.SetBytes(append([]byte(nil), v...))
+			return nil
+		}
+	case string:
+		switch {
+		case rv.Kind() == reflect.String:
+			rv.SetString(v)
+			return nil
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			rv.SetBytes([]byte(v))
+			return nil
+		}
+	}
+	switch rv.Kind() {
+	case reflect.Interface:
+		rv.Set(reflect.ValueOf(src))
+		return nil
+	case reflect.Pointer:
+		ptr := reflect.New(rv.Type().Elem())
+		if err := assign(ptr.Interface(), src); err != nil {
+			return err
+		}
+		rv.Set(ptr)
+		return nil
+	}
+	return fmt.Errorf("unsupported destination type %T for value of type %T", dest, src)
+}
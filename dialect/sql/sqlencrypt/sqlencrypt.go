@@ -0,0 +1,416 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlencrypt provides the runtime support for encrypted fields. Values
+// are encrypted using AES-GCM envelope encryption: each value is encrypted with
+// a random data key that is wrapped (encrypted) using a key-encryption key that
+// is obtained from a KeyProvider. The id of the key-encryption key is stored
+// along with the value, to allow rotating keys without downtime.
+package sqlencrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// Mode defines the encryption mode of a field.
+type Mode uint8
+
+const (
+	// Randomized mode encrypts each value with a random data key and nonce.
+	// Hence, encrypting the same value twice produces different ciphertexts
+	// and the field cannot be used in predicates.
+	Randomized Mode = iota
+	// Deterministic mode always produces the same ciphertext for the same value
+	// and key. It leaks equality of values, but allows using the field in
+	// equality predicates (EQ, NEQ, In, NotIn).
+	Deterministic
+)
+
+// String implements the fmt.Stringer interface.
+func (m Mode) String() string {
+	switch m {
+	case Randomized:
+		return "randomized"
+	case Deterministic:
+		return "deterministic"
+	default:
+		return fmt.Sprintf("Mode(%d)", m)
+	}
+}
+
+// A KeyProvider provides the key-encryption keys used for encrypting and
+// decrypting values. Implementations are expected to cache keys that are
+// loaded from external services (e.g. KMS), as Key is called for each value.
+type KeyProvider interface {
+	// CurrentKey returns the id of the key that is used for encrypting new values.
+	CurrentKey(context.Context) (string, error)
+	// Key returns the key with the given id. Keys must be 16, 24, or 32 bytes
+	// long to select AES-128, AES-192, or AES-256.
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// A KeyLister is an optional interface that can be implemented by KeyProviders
+// for listing the ids of all keys that are available for decryption. When it is
+// implemented, the predicates of deterministic encrypted fields match values that
+// were encrypted with any of the listed keys, and not only with the current key.
+type KeyLister interface {
+	KeyIDs(context.Context) ([]string, error)
+}
+
+// Keyring is a static KeyProvider that holds its keys in memory.
+//
+//	sqlencrypt.Keyring{
+//		Current: "v2",
+//		Keys: map[string][]byte{
+//			"v1": oldKey,
+//			"v2": newKey,
+//		},
+//	}
+type Keyring struct {
+	Current string
+	Keys    map[string][]byte
+}
+
+// CurrentKey implements the KeyProvider interface.
+func (k Keyring) CurrentKey(context.Context) (string, error) {
+	if _, ok := k.Keys[k.Current]; !ok {
+		return "", fmt.Errorf("sqlencrypt: current key %q was not found in keyring", k.Current)
+	}
+	return k.Current, nil
+}
+
+// Key implements the KeyProvider interface.
+func (k Keyring) Key(_ context.Context, id string) ([]byte, error) {
+	key, ok := k.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("sqlencrypt: key %q was not found in keyring", id)
+	}
+	return key, nil
+}
+
+// KeyIDs implements the KeyLister interface.
+func (k Keyring) KeyIDs(context.Context) ([]string, error) {
+	ids := make([]string, 0, len(k.Keys))
+	for id := range k.Keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// ErrMissingKeyProvider is returned when values are encrypted or decrypted without a KeyProvider.
+var ErrMissingKeyProvider = errors.New("sqlencrypt: missing KeyProvider")
+
+const (
+	version   = 1
+	nonceSize = 12
+	dekSize   = 32
+)
+
+// Encrypt encrypts the given plaintext with the current key of the KeyProvider.
+func Encrypt(ctx context.Context, kp KeyProvider, plaintext []byte, mode Mode) ([]byte, error) {
+	if kp == nil {
+		return nil, ErrMissingKeyProvider
+	}
+	id, err := kp.CurrentKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(ctx, kp, id, plaintext, mode)
+}
+
+// encrypt encrypts the given plaintext with the key that matches the given id.
+func encrypt(ctx context.Context, kp KeyProvider, id string, plaintext []byte, mode Mode) ([]byte, error) {
+	if len(id) > 255 {
+		return nil, fmt.Errorf("sqlencrypt: key id %q exceeds 255 bytes", id)
+	}
+	kek, err := kp.Key(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// The header is stored in plaintext, and authenticated as additional data.
+	hdr := append([]byte{version, byte(mode), byte(len(id))}, id...)
+	switch mode {
+	case Randomized:
+		dek := make([]byte, dekSize)
+		if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+			return nil, err
+		}
+		// Wrap the data key with the key-encryption key.
+		out, err := seal(kek, hdr, randomNonce(), dek, hdr)
+		if err != nil {
+			return nil, err
+		}
+		return seal(dek, out, randomNonce(), plaintext, hdr)
+	case Deterministic:
+		key, nonce := derive(kek, "enc"), derive(kek, "nonce")
+		// The nonce is derived from the plaintext (synthetic IV). Hence,
+		// it is reused only when the same plaintext is encrypted.
+		return seal(key, hdr, mac(nonce, hdr, plaintext)[:nonceSize], plaintext, hdr)
+	default:
+		return nil, fmt.Errorf("sqlencrypt: unknown encryption mode %d", mode)
+	}
+}
+
+// Decrypt decrypts the given ciphertext that was created by Encrypt.
+func Decrypt(ctx context.Context, kp KeyProvider, ciphertext []byte) ([]byte, error) {
+	if kp == nil {
+		return nil, ErrMissingKeyProvider
+	}
+	h, err := parse(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	kek, err := kp.Key(ctx, h.id)
+	if err != nil {
+		return nil, err
+	}
+	switch h.mode {
+	case Randomized:
+		dek, rest, err := open(kek, h.body, nonceSize+dekSize+16, h.header)
+		if err != nil {
+			return nil, err
+		}
+		plaintext, _, err := open(dek, rest, len(rest), h.header)
+		return plaintext, err
+	case Deterministic:
+		plaintext, _, err := open(derive(kek, "enc"), h.body, len(h.body), h.header)
+		return plaintext, err
+	default:
+		return nil, fmt.Errorf("sqlencrypt: unknown encryption mode %d", h.mode)
+	}
+}
+
+// CiphertextSize returns the maximum size of the stored value of a plaintext with the given
+// size in bytes. That is, the size of its ciphertext with a key id of up to 255 bytes, encoded
+// in base64 for string fields. It is used for sizing the columns of encrypted fields.
+func CiphertextSize(n int64, mode Mode, isString bool) int64 {
+	// Header (version, mode, key id length and key id), and a nonce and a tag for each sealed value.
+	n += 3 + 255 + nonceSize + 16
+	if mode == Randomized {
+		// The wrapped data key.
+		n += nonceSize + dekSize + 16
+	}
+	if isString {
+		n = int64(base64.StdEncoding.EncodedLen(int(n)))
+	}
+	return n
+}
+
+// KeyID returns the id of the key that was used for encrypting the given ciphertext.
+func KeyID(ciphertext []byte) (string, error) {
+	h, err := parse(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return h.id, nil
+}
+
+// EncryptString encrypts the given string and returns its ciphertext encoded in base64.
+func EncryptString(ctx context.Context, kp KeyProvider, s string, mode Mode) (string, error) {
+	b, err := Encrypt(ctx, kp, []byte(s), mode)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+// DecryptString decrypts the given base64 ciphertext that was created by EncryptString.
+func DecryptString(ctx context.Context, kp KeyProvider, s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("sqlencrypt: decoding ciphertext: %w", err)
+	}
+	if b, err = Decrypt(ctx, kp, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// EncryptValue encrypts the given field value. Values with an underlying string
+// type are returned as base64-encoded strings, and values with an underlying
+// []byte type are returned as []byte.
+func EncryptValue(ctx context.Context, kp KeyProvider, v any, mode Mode) (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, isString, err := plaintext(v)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := Encrypt(ctx, kp, b, mode)
+	if err != nil {
+		return nil, err
+	}
+	return encode(ct, isString), nil
+}
+
+// plaintext returns the bytes of the given field value, and
+// reports if the value has an underlying string type.
+func plaintext(v any) ([]byte, bool, error) {
+	if vr, ok := v.(driver.Valuer); ok {
+		dv, err := vr.Value()
+		if err != nil {
+			return nil, false, err
+		}
+		v = dv
+	}
+	switch rv := reflect.ValueOf(v); {
+	case rv.Kind() == reflect.String:
+		return []byte(rv.String()), true, nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return rv.Bytes(), false, nil
+	default:
+		return nil, false, fmt.Errorf("sqlencrypt: unsupported value type %T", v)
+	}
+}
+
+// encode returns the driver value of the given ciphertext.
+func encode(ct []byte, isString bool) driver.Value {
+	if isString {
+		return base64.StdEncoding.EncodeToString(ct)
+	}
+	return ct
+}
+
+// DecryptValues decrypts in place the given field values. Each value must be a
+// pointer to a type with an underlying string or []byte type, or a pointer to
+// a pointer of such types (i.e. nillable fields). Nil and empty values are skipped.
+func DecryptValues(ctx context.Context, kp KeyProvider, ptrs ...any) error {
+	for _, ptr := range ptrs {
+		rv := reflect.ValueOf(ptr)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() {
+			return fmt.Errorf("sqlencrypt: expect non-nil pointer for decrypt, got %T", ptr)
+		}
+		if rv = rv.Elem(); rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				continue
+			}
+			rv = rv.Elem()
+		}
+		switch {
+		case rv.Kind() == reflect.String:
+			if rv.Len() == 0 {
+				continue
+			}
+			s, err := DecryptString(ctx, kp, rv.String())
+			if err != nil {
+				return err
+			}
+			rv.SetString(s)
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			if rv.Len() == 0 {
+				continue
+			}
+			b, err := Decrypt(ctx, kp, rv.Bytes())
+			if err != nil {
+				return err
+			}
+			rv.SetBytes(b)
+		default:
+			return fmt.Errorf("sqlencrypt: unsupported value type %T", ptr)
+		}
+	}
+	return nil
+}
+
+type ctxKey struct{}
+
+// NewContext returns a new context with the given KeyProvider attached.
+// It is used by the generated code for passing the KeyProvider to the
+// predicates of encrypted fields.
+func NewContext(parent context.Context, kp KeyProvider) context.Context {
+	return context.WithValue(parent, ctxKey{}, kp)
+}
+
+// FromContext returns the KeyProvider stored in a context, or nil if there isn't one.
+func FromContext(ctx context.Context) KeyProvider {
+	kp, _ := ctx.Value(ctxKey{}).(KeyProvider)
+	return kp
+}
+
+// envelope holds the parsed representation of a ciphertext.
+type envelope struct {
+	id     string
+	mode   Mode
+	header []byte
+	body   []byte
+}
+
+func parse(b []byte) (*envelope, error) {
+	if len(b) < 3 || b[0] != version || len(b) < 3+int(b[2]) {
+		return nil, errors.New("sqlencrypt: invalid ciphertext")
+	}
+	n := 3 + int(b[2])
+	return &envelope{id: string(b[3:n]), mode: Mode(b[1]), header: b[:n], body: b[n:]}, nil
+}
+
+// seal encrypts and authenticates the plaintext, and appends
+// the nonce and the result to dst.
+func seal(key, dst, nonce, plaintext, data []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	dst = append(dst[:len(dst):len(dst)], nonce...)
+	return aead.Seal(dst, nonce, plaintext, data), nil
+}
+
+// open decrypts the first n bytes of b (nonce included),
+// and returns the plaintext and the remaining bytes.
+func open(key, b []byte, n int, data []byte) ([]byte, []byte, error) {
+	if n < nonceSize || len(b) < n {
+		return nil, nil, errors.New("sqlencrypt: invalid ciphertext")
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := aead.Open(nil, b[:nonceSize], b[nonceSize:n], data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("sqlencrypt: decrypting ciphertext: %w", err)
+	}
+	return plaintext, b[n:], nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("sqlencrypt: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func randomNonce() []byte {
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		panic(err)
+	}
+	return nonce
+}
+
+// derive derives a sub-key from the key-encryption key for the deterministic mode.
+func derive(kek []byte, purpose string) []byte {
+	return mac(kek, []byte("ent/sqlencrypt/deterministic/"+purpose))
+}
+
+func mac(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha256.New, key)
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum(nil)
+}
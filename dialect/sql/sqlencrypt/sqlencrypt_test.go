@@ -0,0 +1,268 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlencrypt_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/base64"
+	"regexp"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/dialect/sql/sqlencrypt"
+	"github.com/jogly/ent/dialect/sql/sqlgraph"
+	"github.com/jogly/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func keyring(current string) sqlencrypt.Keyring {
+	return sqlencrypt.Keyring{
+		Current: current,
+		Keys: map[string][]byte{
+			"v1": bytes.Repeat([]byte{1}, 32),
+			"v2": bytes.Repeat([]byte{2}, 16),
+		},
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	ctx := context.Background()
+	kr := keyring("v1")
+	for _, mode := range []sqlencrypt.Mode{sqlencrypt.Randomized, sqlencrypt.Deterministic} {
+		t.Run(mode.String(), func(t *testing.T) {
+			ct1, err := sqlencrypt.Encrypt(ctx, kr, []byte("secret"), mode)
+			require.NoError(t, err)
+			require.NotContains(t, string(ct1), "secret")
+			id, err := sqlencrypt.KeyID(ct1)
+			require.NoError(t, err)
+			require.Equal(t, "v1", id)
+			ct2, err := sqlencrypt.Encrypt(ctx, kr, []byte("secret"), mode)
+			require.NoError(t, err)
+			require.Equal(t, mode == sqlencrypt.Deterministic, bytes.Equal(ct1, ct2))
+
+			pt, err := sqlencrypt.Decrypt(ctx, kr, ct1)
+			require.NoError(t, err)
+			require.Equal(t, "secret", string(pt))
+
+			// Values encrypted with old keys are still readable.
+			pt, err = sqlencrypt.Decrypt(ctx, keyring("v2"), ct1)
+			require.NoError(t, err)
+			require.Equal(t, "secret", string(pt))
+
+			// Tampered ciphertexts are rejected.
+			ct1[len(ct1)-1] ^= 1
+			_, err = sqlencrypt.Decrypt(ctx, kr, ct1)
+			require.Error(t, err)
+			// Key ids are authenticated.
+			ct2[3] = '2'
+			_, err = sqlencrypt.Decrypt(ctx, kr, ct2)
+			require.Error(t, err)
+		})
+	}
+	_, err := sqlencrypt.Encrypt(ctx, nil, []byte("secret"), sqlencrypt.Randomized)
+	require.ErrorIs(t, err, sqlencrypt.ErrMissingKeyProvider)
+	_, err = sqlencrypt.Encrypt(ctx, keyring("v3"), []byte("secret"), sqlencrypt.Randomized)
+	require.Error(t, err)
+	_, err = sqlencrypt.Decrypt(ctx, kr, []byte("plaintext"))
+	require.Error(t, err)
+}
+
+func TestEncryptValue(t *testing.T) {
+	ctx := context.Background()
+	kr := keyring("v1")
+	type Name string
+	v, err := sqlencrypt.EncryptValue(ctx, kr, Name("a8m"), sqlencrypt.Randomized)
+	require.NoError(t, err)
+	require.IsType(t, "", v)
+	v, err = sqlencrypt.EncryptValue(ctx, kr, []byte("a8m"), sqlencrypt.Randomized)
+	require.NoError(t, err)
+	require.IsType(t, []byte(nil), v)
+	v, err = sqlencrypt.EncryptValue(ctx, kr, nil, sqlencrypt.Randomized)
+	require.NoError(t, err)
+	require.Nil(t, v)
+	_, err = sqlencrypt.EncryptValue(ctx, kr, 1, sqlencrypt.Randomized)
+	require.Error(t, err)
+
+	s, err := sqlencrypt.EncryptString(ctx, kr, "a8m", sqlencrypt.Randomized)
+	require.NoError(t, err)
+	b, err := sqlencrypt.Encrypt(ctx, kr, []byte("secret"), sqlencrypt.Deterministic)
+	require.NoError(t, err)
+	var (
+		name   = Name(s)
+		bs     = b
+		null   *string
+		empty  string
+		nillab = &s
+	)
+	err = sqlencrypt.DecryptValues(ctx, kr, &name, &bs, &null, &empty, &nillab)
+	require.NoError(t, err)
+	require.Equal(t, Name("a8m"), name)
+	require.Equal(t, "secret", string(bs))
+	require.Nil(t, null)
+	require.Empty(t, empty)
+	require.Equal(t, "a8m", *nillab)
+	require.Error(t, sqlencrypt.DecryptValues(ctx, kr, name))
+}
+
+func TestEncryptFields(t *testing.T) {
+	ctx := context.Background()
+	kr := keyring("v1")
+	specs := []*sqlgraph.FieldSpec{
+		{Column: "name", Type: field.TypeString, Value: "a8m"},
+		{Column: "ssn", Type: field.TypeString, Value: "123"},
+		{Column: "key", Type: field.TypeBytes, Value: []byte("key")},
+	}
+	err := sqlencrypt.EncryptFields(ctx, kr, specs, map[string]sqlencrypt.Mode{"ssn": sqlencrypt.Randomized, "key": sqlencrypt.Deterministic})
+	require.NoError(t, err)
+	require.Equal(t, "a8m", specs[0].Value)
+	ssn, err := sqlencrypt.DecryptString(ctx, kr, specs[1].Value.(string))
+	require.NoError(t, err)
+	require.Equal(t, "123", ssn)
+	key, err := sqlencrypt.Decrypt(ctx, kr, specs[2].Value.([]byte))
+	require.NoError(t, err)
+	require.Equal(t, "key", string(key))
+}
+
+func TestPredicates(t *testing.T) {
+	ctx := context.Background()
+	kr := keyring("v1")
+	enc := func(k sqlencrypt.KeyProvider, v string) string {
+		s, err := sqlencrypt.EncryptString(ctx, k, v, sqlencrypt.Deterministic)
+		require.NoError(t, err)
+		return s
+	}
+	b := sql.Dialect(dialect.Postgres)
+
+	s := b.Select("*").From(sql.Table("users")).WithContext(sqlencrypt.NewContext(ctx, kr))
+	sqlencrypt.FieldEQ("email", "a@b")(s)
+	query, args := s.Query()
+	require.NoError(t, s.Err())
+	require.Equal(t, `SELECT * FROM "users" WHERE "users"."email" IN ($1, $2)`, query)
+	require.Equal(t, []any{enc(kr, "a@b"), enc(keyring("v2"), "a@b")}, args)
+
+	// KeyProviders that do not implement the KeyLister interface match only the current key.
+	s = b.Select("*").From(sql.Table("users")).WithContext(sqlencrypt.NewContext(ctx, provider{kr}))
+	sqlencrypt.FieldNEQ("email", "a@b")(s)
+	sqlencrypt.FieldNotIn("email", "c@d", "e@f")(s)
+	query, args = s.Query()
+	require.NoError(t, s.Err())
+	require.Equal(t, `SELECT * FROM "users" WHERE (NOT ("users"."email" = $1)) AND "users"."email" NOT IN ($2, $3)`, query)
+	require.Equal(t, []any{enc(kr, "a@b"), enc(kr, "c@d"), enc(kr, "e@f")}, args)
+
+	s = b.Select("*").From(sql.Table("users"))
+	sqlencrypt.FieldIn("email", "a@b")(s)
+	require.EqualError(t, s.Err(), sqlencrypt.ErrMissingKeyProvider.Error())
+}
+
+// provider wraps a Keyring without exposing its KeyIDs method.
+type provider struct{ kr sqlencrypt.Keyring }
+
+func (p provider) CurrentKey(ctx context.Context) (string, error) { return p.kr.CurrentKey(ctx) }
+func (p provider) Key(ctx context.Context, id string) ([]byte, error) {
+	return p.kr.Key(ctx, id)
+}
+
+func TestReencrypt(t *testing.T) {
+	ctx := context.Background()
+	v1, v2 := keyring("v1"), keyring("v2")
+	enc := func(k sqlencrypt.KeyProvider, v string) string {
+		s, err := sqlencrypt.EncryptString(ctx, k, v, sqlencrypt.Randomized)
+		require.NoError(t, err)
+		return s
+	}
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `users`.`id`, `users`.`ssn`, `users`.`key` FROM `users` ORDER BY `users`.`id` LIMIT 2")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ssn", "key"}).
+			AddRow(1, enc(v1, "1"), nil).
+			AddRow(2, enc(v2, "2"), nil))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `ssn` = ? WHERE `id` = ?")).
+		WithArgs(reencrypted{kp: v2, v: "1"}, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `users`.`id`, `users`.`ssn`, `users`.`key` FROM `users` WHERE `users`.`id` > ? ORDER BY `users`.`id` LIMIT 2")).
+		WithArgs(2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "ssn", "key"}).
+			AddRow(3, enc(v1, "3"), []byte{}))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `ssn` = ? WHERE `id` = ?")).
+		WithArgs(reencrypted{kp: v2, v: "3"}, 3).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	n, err := sqlencrypt.Reencrypt(ctx, sql.OpenDB(dialect.MySQL, db), v2, &sqlencrypt.ReencryptSpec{
+		Table: "users",
+		ID:    "id",
+		Columns: []*sqlencrypt.Column{
+			{Name: "ssn", Type: field.TypeString},
+			{Name: "key", Type: field.TypeString, Mode: sqlencrypt.Deterministic},
+		},
+		BatchSize: 2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDecryptRows(t *testing.T) {
+	ctx := context.Background()
+	kp := keyring("v1")
+	ssn, err := sqlencrypt.EncryptString(ctx, kp, "123", sqlencrypt.Randomized)
+	require.NoError(t, err)
+	key, err := sqlencrypt.Encrypt(ctx, kp, []byte("k"), sqlencrypt.Deterministic)
+	require.NoError(t, err)
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `name`, `ssn`, `key` FROM `users`")).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "ssn", "key"}).
+			AddRow("a8m", []byte(ssn), key).
+			AddRow("nati", nil, nil))
+	rows := &sql.Rows{}
+	err = sql.OpenDB(dialect.MySQL, db).Query(ctx, "SELECT `name`, `ssn`, `key` FROM `users`", []any{}, rows)
+	require.NoError(t, err)
+	defer rows.Close()
+	var v []struct {
+		Name string
+		SSN  *string `sql:"ssn"`
+		Key  []byte
+	}
+	err = sql.ScanSlice(sqlencrypt.DecryptRows(ctx, kp, rows,
+		&sqlencrypt.Column{Name: "ssn", Type: field.TypeString},
+		&sqlencrypt.Column{Name: "key", Type: field.TypeBytes, Mode: sqlencrypt.Deterministic},
+	), &v)
+	require.NoError(t, err)
+	require.Len(t, v, 2)
+	require.Equal(t, "a8m", v[0].Name)
+	require.Equal(t, "123", *v[0].SSN)
+	require.Equal(t, []byte("k"), v[0].Key)
+	require.Nil(t, v[1].SSN)
+	require.Nil(t, v[1].Key)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// reencrypted matches values that were encrypted with the current key of the KeyProvider.
+type reencrypted struct {
+	kp sqlencrypt.KeyProvider
+	v  string
+}
+
+func (r reencrypted) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return false
+	}
+	id, err := sqlencrypt.KeyID(b)
+	if err != nil {
+		return false
+	}
+	current, _ := r.kp.CurrentKey(context.Background())
+	pt, err := sqlencrypt.DecryptString(context.Background(), r.kp, s)
+	return err == nil && id == current && pt == r.v
+}
@@ -30,6 +30,11 @@ type (
 		// Type holds the node type (schema name).
 		Type string
 
+		// Composite indicates that the node is identified by its CompositeID and that
+		// the relations that reference it are stored in a column for each of its fields.
+		// It is false for edge schemas, that are identified by their foreign-keys.
+		Composite bool
+
 		// Fields maps from field names to their spec.
 		Fields map[string]*FieldSpec
 
@@ -243,33 +248,41 @@ func (e *state) evalBinary(expr *entql.BinaryExpr) *sql.Predicate {
 func (e *state) evalEdge(name string, exprs ...entql.Expr) *sql.Predicate {
 	edge, ok := e.context.Edges[name]
 	expect(ok, "edge %q was not found for node %q", name, e.context.Type)
-	var fromC, toC string
+	var from, to StepOption
 	switch {
 	case edge.To.ID != nil:
-		toC = edge.To.ID.Column
+		to = To(edge.To.Table, edge.To.ID.Column)
+	case edge.To.Composite:
+		to = CompositeTo(edge.To.Table, idColumns(edge.To.CompositeID))
 	// Edge-owner points to its edge schema.
 	case edge.To.CompositeID != nil && !edge.Spec.Inverse:
-		toC = edge.To.CompositeID[0].Column
+		to = To(edge.To.Table, edge.To.CompositeID[0].Column)
 	// Edge-backref points to its edge schema.
 	case edge.To.CompositeID != nil && edge.Spec.Inverse:
-		toC = edge.To.CompositeID[1].Column
+		to = To(edge.To.Table, edge.To.CompositeID[1].Column)
 	default:
 		panic(evalError{fmt.Sprintf("expect id definition for edge %q", name)})
 	}
 	switch {
 	case e.context.ID != nil:
-		fromC = e.context.ID.Column
+		from = From(e.context.Table, e.context.ID.Column)
+	case e.context.Composite:
+		from = CompositeFrom(e.context.Table, idColumns(e.context.CompositeID))
 	case e.context.CompositeID != nil && (edge.Spec.Rel == M2O || (edge.Spec.Rel == O2O && edge.Spec.Inverse)):
 		// An edge-schema with a composite id can query
 		// only edges that it owns (holds the foreign-key).
+		from = From(e.context.Table, "")
 	default:
 		panic(evalError{fmt.Sprintf("unexpected edge-query from an edge-schema %q", e.context.Type)})
 	}
 	step := NewStep(
-		From(e.context.Table, fromC),
-		To(edge.To.Table, toC),
+		from,
+		to,
 		Edge(edge.Spec.Rel, edge.Spec.Inverse, edge.Spec.Table, edge.Spec.Columns...),
 	)
+	if p := edge.Spec.Polymorphic; p != nil {
+		step.Edge.Polymorphic = p
+	}
 	selector := e.selector.Clone().SetP(nil)
 	selector.SetTotal(e.Total())
 	if len(exprs) == 0 {
@@ -73,6 +73,8 @@ func TestGraph_EvalP(t *testing.T) {
 	require.NoError(t, err)
 	err = g.AddE("users", &EdgeSpec{Rel: M2M, Inverse: true, Table: "user_groups", Columns: []string{"user_id", "group_id"}}, "group", "user")
 	require.NoError(t, err)
+	err = g.AddE("favorite_group", &EdgeSpec{Rel: M2O, Table: "users", Columns: []string{"favorite_id"}, Polymorphic: &PolymorphicSpec{Column: "favorite_type", Type: "group"}}, "user", "group")
+	require.NoError(t, err)
 
 	tests := []struct {
 		s         *sql.Selector
@@ -133,6 +135,18 @@ func TestGraph_EvalP(t *testing.T) {
 			p:         entql.HasEdge("groups"),
 			wantQuery: `SELECT * FROM "users" WHERE "users"."uid" IN (SELECT "user_groups"."user_id" FROM "user_groups")`,
 		},
+		{
+			s:         sql.Dialect(dialect.Postgres).Select().From(sql.Table("users")),
+			p:         entql.HasEdge("favorite_group"),
+			wantQuery: `SELECT * FROM "users" WHERE "users"."favorite_id" IS NOT NULL AND "users"."favorite_type" = $1`,
+			wantArgs:  []any{"group"},
+		},
+		{
+			s:         sql.Dialect(dialect.Postgres).Select().From(sql.Table("users")),
+			p:         entql.HasEdgeWith("favorite_group", entql.FieldEQ("name", "GitHub")),
+			wantQuery: `SELECT * FROM "users" WHERE "users"."favorite_id" IN (SELECT "groups"."gid" FROM "groups" WHERE "groups"."name" = $1) AND "users"."favorite_type" = $2`,
+			wantArgs:  []any{"GitHub", "group"},
+		},
 		{
 			s:         sql.Dialect(dialect.Postgres).Select().From(sql.Table("users")),
 			p:         entql.HasEdgeWith("pets", entql.Or(entql.FieldEQ("name", "pedro"), entql.FieldEQ("name", "xabi"))),
@@ -169,3 +183,55 @@ func TestGraph_EvalP(t *testing.T) {
 		})
 	}
 }
+
+func TestGraph_EvalP_Composite(t *testing.T) {
+	g := &Schema{
+		Nodes: []*Node{
+			{
+				Type: "account",
+				NodeSpec: NodeSpec{
+					Table:       "accounts",
+					CompositeID: []*FieldSpec{{Column: "tenant_id"}, {Column: "external_id"}},
+				},
+				Composite: true,
+				Fields: map[string]*FieldSpec{
+					"external_id": {Column: "external_id", Type: field.TypeString},
+				},
+			},
+			{
+				Type: "card",
+				NodeSpec: NodeSpec{
+					Table: "cards",
+					ID:    &FieldSpec{Column: "id"},
+				},
+				Fields: map[string]*FieldSpec{
+					"number": {Column: "number", Type: field.TypeString},
+				},
+			},
+		},
+	}
+	g.MustAddE("cards", &EdgeSpec{Rel: O2M, Table: "cards", Columns: []string{"account_tenant_id", "account_external_id"}}, "account", "card")
+	g.MustAddE("account", &EdgeSpec{Rel: M2O, Inverse: true, Table: "cards", Columns: []string{"account_tenant_id", "account_external_id"}}, "card", "account")
+	g.MustAddE("friends", &EdgeSpec{Rel: M2M, Table: "account_friends", Columns: []string{"account_tenant_id", "account_external_id", "friend_tenant_id", "friend_external_id"}}, "account", "account")
+
+	s := sql.Select().From(sql.Table("accounts"))
+	err := g.EvalP("account", entql.HasEdgeWith("cards", entql.FieldEQ("number", "1")), s)
+	require.NoError(t, err)
+	query, args := s.Query()
+	require.Equal(t, "SELECT * FROM `accounts` WHERE (`accounts`.`tenant_id`, `accounts`.`external_id`) IN (SELECT `cards`.`account_tenant_id`, `cards`.`account_external_id` FROM `cards` WHERE `cards`.`number` = ?)", query)
+	require.Equal(t, []any{"1"}, args)
+
+	s = sql.Select().From(sql.Table("cards"))
+	err = g.EvalP("card", entql.HasEdgeWith("account", entql.FieldEQ("external_id", "a")), s)
+	require.NoError(t, err)
+	query, args = s.Query()
+	require.Equal(t, "SELECT * FROM `cards` WHERE (`cards`.`account_tenant_id`, `cards`.`account_external_id`) IN (SELECT `accounts`.`tenant_id`, `accounts`.`external_id` FROM `accounts` WHERE `accounts`.`external_id` = ?)", query)
+	require.Equal(t, []any{"a"}, args)
+
+	s = sql.Select().From(sql.Table("accounts"))
+	err = g.EvalP("account", entql.HasEdge("friends"), s)
+	require.NoError(t, err)
+	query, args = s.Query()
+	require.Equal(t, "SELECT * FROM `accounts` WHERE (`accounts`.`tenant_id`, `accounts`.`external_id`) IN (SELECT `account_friends`.`account_tenant_id`, `account_friends`.`account_external_id` FROM `account_friends`)", query)
+	require.Empty(t, args)
+}
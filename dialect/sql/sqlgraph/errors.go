@@ -7,37 +7,93 @@ package sqlgraph
 import (
 	"errors"
 	"strings"
+
+	"github.com/jogly/ent/dialect/sql"
 )
 
 // IsConstraintError returns true if the error resulted from a database constraint violation.
 func IsConstraintError(err error) bool {
 	var e *ConstraintError
-	return errors.As(err, &e) || IsUniqueConstraintError(err) || IsForeignKeyConstraintError(err)
+	return errors.As(err, &e) ||
+		IsUniqueConstraintError(err) ||
+		IsForeignKeyConstraintError(err) ||
+		IsNotNullConstraintError(err) ||
+		IsCheckConstraintError(err)
 }
 
 // IsUniqueConstraintError reports if the error resulted from a DB uniqueness constraint violation.
 // e.g. duplicate value in unique index.
 func IsUniqueConstraintError(err error) bool {
-	for _, s := range []string{
+	return isErrorKind(err, sql.KindUnique, []string{
 		"Error 1062",                 // MySQL
 		"violates unique constraint", // Postgres
 		"UNIQUE constraint failed",   // SQLite
-	} {
-		if strings.Contains(err.Error(), s) {
-			return true
-		}
-	}
-	return false
+	})
 }
 
 // IsForeignKeyConstraintError reports if the error resulted from a database foreign-key constraint violation.
 // e.g. parent row does not exist.
 func IsForeignKeyConstraintError(err error) bool {
-	for _, s := range []string{
+	return isErrorKind(err, sql.KindForeignKey, []string{
 		"Error 1451",                      // MySQL (Cannot delete or update a parent row).
 		"Error 1452",                      // MySQL (Cannot add or update a child row).
 		"violates foreign key constraint", // Postgres
 		"FOREIGN KEY constraint failed",   // SQLite
+	})
+}
+
+// IsNotNullConstraintError reports if the error resulted from a database not-null constraint violation.
+// e.g. NULL value in a required column.
+func IsNotNullConstraintError(err error) bool {
+	return isErrorKind(err, sql.KindNotNull, []string{
+		"Error 1048",                   // MySQL (Column cannot be null).
+		"violates not-null constraint", // Postgres
+		"NOT NULL constraint failed",   // SQLite
+	})
+}
+
+// IsCheckConstraintError reports if the error resulted from a database check constraint violation.
+func IsCheckConstraintError(err error) bool {
+	return isErrorKind(err, sql.KindCheck, []string{
+		"Error 3819",                // MySQL
+		"violates check constraint", // Postgres
+		"CHECK constraint failed",   // SQLite
+	})
+}
+
+// isErrorKind reports if the error was classified with the given kind, either by
+// the sqlgraph package or by sql.ClassifyError, or if its message contains one of
+// the given strings.
+func isErrorKind(err error, kind sql.ErrorKind, msgs []string) bool {
+	if err == nil {
+		return false
+	}
+	var ce *ConstraintError
+	if errors.As(err, &ce) && ce.Kind == kind {
+		return true
+	}
+	if e := sql.ClassifyError(err); e != nil && e.Kind == kind {
+		return true
+	}
+	for _, s := range msgs {
+		if strings.Contains(err.Error(), s) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRetryableError reports if the error resulted from a conflict between concurrent transactions,
+// and the transaction that failed can be retried. e.g. serialization failure or deadlock.
+func IsRetryableError(err error) bool {
+	if e := sql.ClassifyError(err); e != nil && (e.Kind == sql.KindDeadlock || e.Kind == sql.KindSerialization) {
+		return true
+	}
+	for _, s := range []string{
+		"Error 1213",                 // MySQL (Deadlock found when trying to get lock).
+		"could not serialize access", // Postgres (40001).
+		"deadlock detected",          // Postgres (40P01).
+		"database is locked",         // SQLite (SQLITE_BUSY).
 	} {
 		if strings.Contains(err.Error(), s) {
 			return true
@@ -45,3 +101,10 @@ func IsForeignKeyConstraintError(err error) bool {
 	}
 	return false
 }
+
+// IsConflictError reports if the error resulted from a versioned update of a node
+// whose concurrency token was modified since it was read.
+func IsConflictError(err error) bool {
+	var e *ConflictError
+	return errors.As(err, &e)
+}
@@ -0,0 +1,323 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+)
+
+type (
+	// Plan is the execution plan of a query, as reported by the database.
+	Plan struct {
+		// Raw holds the output of the EXPLAIN statement as returned by
+		// the database. One line per row, or the JSON document in PostgreSQL.
+		Raw string
+		// Nodes holds the root nodes of the plan.
+		Nodes []*PlanNode
+	}
+
+	// PlanNode is a single step in the execution plan of a query.
+	PlanNode struct {
+		// Detail describes the step. For example, "SCAN users" in
+		// SQLite, "Index Scan" in PostgreSQL or "ref" in MySQL.
+		Detail string
+		// Table and Index hold the table that is accessed by the step,
+		// and the index that is used to access it, if there are any.
+		Table, Index string
+		// Children holds the steps that this step depends on.
+		Children []*PlanNode
+	}
+
+	// ExplainOption allows configuring the EXPLAIN statement that is executed by Explain.
+	ExplainOption func(*explainOptions)
+
+	explainOptions struct {
+		analyze bool
+	}
+)
+
+// ExplainAnalyze executes the query in order to report its actual execution
+// plan (EXPLAIN ANALYZE). It is supported by MySQL (8.0.18+) and PostgreSQL.
+func ExplainAnalyze() ExplainOption {
+	return func(o *explainOptions) {
+		o.analyze = true
+	}
+}
+
+// Explain returns the execution plan of the given query, using the EXPLAIN statement of the
+// dialect of the driver: EXPLAIN QUERY PLAN in SQLite, EXPLAIN (FORMAT JSON) in PostgreSQL,
+// and EXPLAIN (or EXPLAIN ANALYZE) in MySQL.
+func Explain(ctx context.Context, drv dialect.Driver, q sql.Querier, opts ...ExplainOption) (*Plan, error) {
+	o := &explainOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if s, ok := q.(interface{ Err() error }); ok {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+	}
+	query, args := q.Query()
+	switch d := drv.Dialect(); d {
+	case dialect.SQLite:
+		if o.analyze {
+			return nil, fmt.Errorf("sqlgraph: EXPLAIN ANALYZE is not supported by dialect %q", d)
+		}
+		return explainSQLite(ctx, drv, query, args)
+	case dialect.Postgres:
+		return explainPostgres(ctx, drv, query, args, o)
+	case dialect.MySQL:
+		if o.analyze {
+			return explainMySQLTree(ctx, drv, query, args)
+		}
+		return explainMySQL(ctx, drv, query, args)
+	default:
+		return nil, fmt.Errorf("sqlgraph: EXPLAIN is not supported by dialect %q", d)
+	}
+}
+
+// UsesIndex reports if one of the steps of the plan accesses a table using the given index.
+func (p *Plan) UsesIndex(name string) bool {
+	var found bool
+	p.Walk(func(n *PlanNode) bool {
+		found = strings.EqualFold(n.Index, name)
+		return !found
+	})
+	return found
+}
+
+// Walk calls fn for each node of the plan in depth-first order,
+// until fn returns false.
+func (p *Plan) Walk(fn func(*PlanNode) bool) {
+	var walk func([]*PlanNode) bool
+	walk = func(nodes []*PlanNode) bool {
+		for _, n := range nodes {
+			if !fn(n) || !walk(n.Children) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(p.Nodes)
+}
+
+// String returns the raw plan.
+func (p *Plan) String() string {
+	return p.Raw
+}
+
+var (
+	// sqliteStep matches the table and the index of "SCAN" and "SEARCH" steps. For example:
+	//
+	//	SCAN users
+	//	SEARCH TABLE users USING COVERING INDEX user_name (name=?)
+	//
+	sqliteStep   = regexp.MustCompile(`^(?:SCAN|SEARCH)(?: TABLE)? (\S+)(?:.* USING (?:COVERING |AUTOMATIC |PARTIAL )*INDEX (\S+))?`)
+	sqlitePKStep = regexp.MustCompile(` USING (?:INTEGER )?PRIMARY KEY`)
+	// mysqlStep matches the table and the index of the steps of the MySQL tree format. For example:
+	//
+	//	-> Table scan on users
+	//	-> Index lookup on users using user_name (name='a8m')
+	//
+	mysqlStep = regexp.MustCompile(` on (\S+)(?: using (\S+))?`)
+)
+
+func explainSQLite(ctx context.Context, drv dialect.Driver, query string, args []any) (*Plan, error) {
+	rows := &sql.Rows{}
+	if err := drv.Query(ctx, "EXPLAIN QUERY PLAN "+query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var (
+		raw   []string
+		plan  = &Plan{}
+		nodes = make(map[int]*PlanNode)
+	)
+	for rows.Next() {
+		var (
+			id, parent, notused int
+			detail              string
+		)
+		if err := rows.Scan(&id, &parent, &notused, &detail); err != nil {
+			return nil, err
+		}
+		raw = append(raw, detail)
+		n := &PlanNode{Detail: detail}
+		if m := sqliteStep.FindStringSubmatch(detail); m != nil {
+			n.Table, n.Index = m[1], m[2]
+			if n.Index == "" && sqlitePKStep.MatchString(detail) {
+				n.Index = "PRIMARY KEY"
+			}
+		}
+		nodes[id] = n
+		if p, ok := nodes[parent]; ok {
+			p.Children = append(p.Children, n)
+		} else {
+			plan.Nodes = append(plan.Nodes, n)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	plan.Raw = strings.Join(raw, "\n")
+	return plan, nil
+}
+
+func explainPostgres(ctx context.Context, drv dialect.Driver, query string, args []any, o *explainOptions) (*Plan, error) {
+	stmt := "EXPLAIN (FORMAT JSON) "
+	if o.analyze {
+		stmt = "EXPLAIN (ANALYZE, FORMAT JSON) "
+	}
+	rows := &sql.Rows{}
+	if err := drv.Query(ctx, stmt+query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var raw string
+	for rows.Next() {
+		if err := rows.Scan(&raw); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	type node struct {
+		Type  string  `json:"Node Type"`
+		Table string  `json:"Relation Name"`
+		Index string  `json:"Index Name"`
+		Plans []*node `json:"Plans"`
+	}
+	var doc []struct {
+		Plan *node `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		return nil, fmt.Errorf("sqlgraph: decoding postgres plan: %w", err)
+	}
+	var convert func(*node) *PlanNode
+	convert = func(n *node) *PlanNode {
+		pn := &PlanNode{Detail: n.Type, Table: n.Table, Index: n.Index}
+		for _, c := range n.Plans {
+			pn.Children = append(pn.Children, convert(c))
+		}
+		return pn
+	}
+	plan := &Plan{Raw: raw}
+	for _, d := range doc {
+		if d.Plan != nil {
+			plan.Nodes = append(plan.Nodes, convert(d.Plan))
+		}
+	}
+	return plan, nil
+}
+
+func explainMySQL(ctx context.Context, drv dialect.Driver, query string, args []any) (*Plan, error) {
+	rows := &sql.Rows{}
+	if err := drv.Query(ctx, "EXPLAIN "+query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		raw  []string
+		plan = &Plan{}
+	)
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		var (
+			line []string
+			n    = &PlanNode{}
+		)
+		for i, c := range columns {
+			v := values[i].String
+			line = append(line, v)
+			switch strings.ToLower(c) {
+			case "type":
+				n.Detail = v
+			case "table":
+				n.Table = v
+			case "key":
+				n.Index = v
+			}
+		}
+		raw = append(raw, strings.Join(line, "\t"))
+		plan.Nodes = append(plan.Nodes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	plan.Raw = strings.Join(raw, "\n")
+	return plan, nil
+}
+
+func explainMySQLTree(ctx context.Context, drv dialect.Driver, query string, args []any) (*Plan, error) {
+	rows := &sql.Rows{}
+	if err := drv.Query(ctx, "EXPLAIN ANALYZE "+query, args, rows); err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var raw []string
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return nil, err
+		}
+		raw = append(raw, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	plan := &Plan{Raw: strings.Join(raw, "\n")}
+	// Each step is printed in a separate line starting with an arrow,
+	// and is indented deeper than the step it belongs to.
+	type entry struct {
+		indent int
+		node   *PlanNode
+	}
+	var stack []entry
+	for _, line := range strings.Split(plan.Raw, "\n") {
+		detail := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(detail, "->") {
+			continue
+		}
+		indent := len(line) - len(detail)
+		detail = strings.TrimSpace(strings.TrimPrefix(detail, "->"))
+		if i := strings.Index(detail, "  ("); i > 0 {
+			detail = detail[:i]
+		}
+		n := &PlanNode{Detail: detail}
+		if m := mysqlStep.FindStringSubmatch(detail); m != nil {
+			n.Table, n.Index = m[1], m[2]
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			plan.Nodes = append(plan.Nodes, n)
+		} else {
+			p := stack[len(stack)-1].node
+			p.Children = append(p.Children, n)
+		}
+		stack = append(stack, entry{indent: indent, node: n})
+	}
+	return plan, nil
+}
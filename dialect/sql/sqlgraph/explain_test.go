@@ -0,0 +1,122 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+	t.Run("SQLite", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		drv := sql.OpenDB(dialect.SQLite, db)
+		selector := sql.Dialect(dialect.SQLite).
+			Select("*").
+			From(sql.Table("users")).
+			Where(sql.EQ("name", "a8m"))
+		mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN QUERY PLAN SELECT * FROM `users` WHERE `name` = ?")).
+			WithArgs("a8m").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "parent", "notused", "detail"}).
+				AddRow(2, 0, 0, "SEARCH users USING INDEX user_name (name=?)").
+				AddRow(5, 0, 0, "CORRELATED SCALAR SUBQUERY 1").
+				AddRow(8, 5, 0, "SEARCH TABLE pets USING INTEGER PRIMARY KEY (rowid=?)"))
+		plan, err := Explain(ctx, drv, selector)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Len(t, plan.Nodes, 2)
+		require.Equal(t, &PlanNode{Detail: "SEARCH users USING INDEX user_name (name=?)", Table: "users", Index: "user_name"}, plan.Nodes[0])
+		require.Len(t, plan.Nodes[1].Children, 1)
+		require.Equal(t, "pets", plan.Nodes[1].Children[0].Table)
+		require.True(t, plan.UsesIndex("user_name"))
+		require.True(t, plan.UsesIndex("PRIMARY KEY"))
+		require.False(t, plan.UsesIndex("user_age"))
+		require.Equal(t, "SEARCH users USING INDEX user_name (name=?)\nCORRELATED SCALAR SUBQUERY 1\nSEARCH TABLE pets USING INTEGER PRIMARY KEY (rowid=?)", plan.Raw)
+
+		_, err = Explain(ctx, drv, selector, ExplainAnalyze())
+		require.Error(t, err)
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		drv := sql.OpenDB(dialect.Postgres, db)
+		selector := sql.Dialect(dialect.Postgres).
+			Select("*").
+			From(sql.Table("users")).
+			Where(sql.EQ("name", "a8m"))
+		raw := `[{"Plan": {"Node Type": "Nested Loop", "Plans": [{"Node Type": "Index Scan", "Relation Name": "users", "Index Name": "user_name"}, {"Node Type": "Seq Scan", "Relation Name": "pets"}]}}]`
+		mock.ExpectQuery(regexp.QuoteMeta(`EXPLAIN (ANALYZE, FORMAT JSON) SELECT * FROM "users" WHERE "name" = $1`)).
+			WithArgs("a8m").
+			WillReturnRows(sqlmock.NewRows([]string{"QUERY PLAN"}).AddRow(raw))
+		plan, err := Explain(ctx, drv, selector, ExplainAnalyze())
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Equal(t, raw, plan.Raw)
+		require.Equal(t, []*PlanNode{
+			{
+				Detail: "Nested Loop",
+				Children: []*PlanNode{
+					{Detail: "Index Scan", Table: "users", Index: "user_name"},
+					{Detail: "Seq Scan", Table: "pets"},
+				},
+			},
+		}, plan.Nodes)
+		require.True(t, plan.UsesIndex("user_name"))
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		drv := sql.OpenDB(dialect.MySQL, db)
+		selector := sql.Dialect(dialect.MySQL).
+			Select("*").
+			From(sql.Table("users")).
+			Where(sql.EQ("name", "a8m"))
+		mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN SELECT * FROM `users` WHERE `name` = ?")).
+			WithArgs("a8m").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "select_type", "table", "type", "possible_keys", "key", "rows", "Extra"}).
+				AddRow(1, "SIMPLE", "users", "ref", "user_name", "user_name", 1, nil))
+		plan, err := Explain(ctx, drv, selector)
+		require.NoError(t, err)
+		require.Equal(t, []*PlanNode{{Detail: "ref", Table: "users", Index: "user_name"}}, plan.Nodes)
+		require.Equal(t, "1\tSIMPLE\tusers\tref\tuser_name\tuser_name\t1\t", plan.Raw)
+
+		tree := "-> Nested loop inner join  (cost=0.70 rows=1)\n" +
+			"    -> Index lookup on users using user_name (name='a8m')  (cost=0.35 rows=1)\n" +
+			"    -> Table scan on pets  (cost=0.35 rows=1)\n"
+		mock.ExpectQuery(regexp.QuoteMeta("EXPLAIN ANALYZE SELECT * FROM `users` WHERE `name` = ?")).
+			WithArgs("a8m").
+			WillReturnRows(sqlmock.NewRows([]string{"EXPLAIN"}).AddRow(tree))
+		plan, err = Explain(ctx, drv, selector, ExplainAnalyze())
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+		require.Equal(t, []*PlanNode{
+			{
+				Detail: "Nested loop inner join",
+				Children: []*PlanNode{
+					{Detail: "Index lookup on users using user_name (name='a8m')", Table: "users", Index: "user_name"},
+					{Detail: "Table scan on pets", Table: "pets"},
+				},
+			},
+		}, plan.Nodes)
+	})
+
+	t.Run("Gremlin", func(t *testing.T) {
+		db, _, err := sqlmock.New()
+		require.NoError(t, err)
+		_, err = Explain(ctx, sql.OpenDB(dialect.Gremlin, db), sql.Select("*").From(sql.Table("users")))
+		require.Error(t, err)
+	})
+}
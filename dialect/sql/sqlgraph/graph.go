@@ -10,9 +10,11 @@ import (
 	"context"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
+	"strings"
 
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/sql"
@@ -51,6 +53,10 @@ func (r Rel) String() (s string) {
 // A ConstraintError represents an error from mutation that violates a specific constraint.
 type ConstraintError struct {
 	msg string
+	// Kind is the kind of the violated constraint, and Table
+	// and Column are the table and column it refers to.
+	Kind          sql.ErrorKind
+	Table, Column string
 }
 
 func (e ConstraintError) Error() string { return e.msg }
@@ -66,6 +72,10 @@ type Step struct {
 		Table string
 		// Column to join with. Usually the "id" column.
 		Column string
+		// Columns to join with, in case the identifier of the source is composite.
+		// If set, Column is ignored and V (if not a selector) holds a []driver.Value
+		// with a value for each column.
+		Columns []string
 	}
 	// Edge holds the edge information for getting the neighbors.
 	Edge struct {
@@ -79,9 +89,16 @@ type Step struct {
 		// Columns of the edge.
 		// In O2O and M2O, it holds the foreign-key column. Hence, len == 1.
 		// In M2M, it holds the primary-key columns of the join table. Hence, len == 2.
+		// If the edge references a type with a composite identifier, the foreign-key
+		// spans a column for each of the identifier columns, and in M2M, the columns
+		// that reference the owner of the edge are followed by the columns of the other side.
 		Columns []string
 		// Inverse indicates if the edge is an inverse edge.
 		Inverse bool
+		// Polymorphic holds the type discriminator of polymorphic edges. If set,
+		// only rows that store the given type in the discriminator column are
+		// considered neighbors. Currently, it is supported only on M2O edges.
+		Polymorphic *PolymorphicSpec
 	}
 	// To is the dest of the path (the neighbors).
 	To struct {
@@ -92,6 +109,9 @@ type Step struct {
 		Schema string
 		// Column to join with. Usually the "id" column.
 		Column string
+		// Columns to join with, in case the identifier of the
+		// neighbors is composite. If set, Column is ignored.
+		Columns []string
 	}
 }
 
@@ -117,6 +137,26 @@ func To(table, column string) StepOption {
 	}
 }
 
+// CompositeFrom is like From, but for sources with a composite identifier.
+// If v is not a selector, it holds the values of the identifier columns.
+func CompositeFrom(table string, columns []string, v ...any) StepOption {
+	return func(s *Step) {
+		s.From.Table = table
+		s.From.Columns = columns
+		if len(v) > 0 {
+			s.From.V = v[0]
+		}
+	}
+}
+
+// CompositeTo is like To, but for neighbors with a composite identifier.
+func CompositeTo(table string, columns []string) StepOption {
+	return func(s *Step) {
+		s.To.Table = table
+		s.To.Columns = columns
+	}
+}
+
 // Edge sets the edge info for getting the neighbors.
 func Edge(rel Rel, inverse bool, table string, columns ...string) StepOption {
 	return func(s *Step) {
@@ -127,6 +167,14 @@ func Edge(rel Rel, inverse bool, table string, columns ...string) StepOption {
 	}
 }
 
+// Polymorphic sets the type discriminator of a polymorphic edge. The column
+// resides in the edge table, next to the foreign-key column of the edge.
+func Polymorphic(column, typ string) StepOption {
+	return func(s *Step) {
+		s.Edge.Polymorphic = &PolymorphicSpec{Column: column, Type: typ}
+	}
+}
+
 // NewStep gets list of options and returns a configured step.
 //
 //	NewStep(
@@ -148,32 +196,32 @@ func Neighbors(dialect string, s *Step) (q *sql.Selector) {
 	builder := sql.Dialect(dialect)
 	switch r := s.Edge.Rel; {
 	case r == M2M:
-		pk1, pk2 := s.Edge.Columns[1], s.Edge.Columns[0]
-		if s.Edge.Inverse {
-			pk1, pk2 = pk2, pk1
-		}
+		pk2, pk1 := s.m2mColumns()
 		to := builder.Table(s.To.Table).Schema(s.To.Schema)
 		join := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
-		match := builder.Select(join.C(pk1)).
+		match := builder.Select(columns(join, pk1)...).
 			From(join).
-			Where(sql.EQ(join.C(pk2), s.From.V))
+			Where(matchValue(columns(join, pk2), s.From.V))
 		q = builder.Select().
 			From(to).
-			Join(match).
-			On(to.C(s.To.Column), match.C(pk1))
+			Join(match)
+		joinOn(q, columns(to, s.toColumns()), columns(match, pk1))
 	case r == M2O || (r == O2O && s.Edge.Inverse):
 		t1 := builder.Table(s.To.Table).Schema(s.To.Schema)
-		t2 := builder.Select(s.Edge.Columns[0]).
+		t2 := builder.Select(s.Edge.Columns...).
 			From(builder.Table(s.Edge.Table).Schema(s.Edge.Schema)).
-			Where(sql.EQ(s.From.Column, s.From.V))
+			Where(matchValue(s.fromColumns(), s.From.V))
+		if p := s.Edge.Polymorphic; p != nil {
+			t2.Where(sql.EQ(p.Column, p.Type))
+		}
 		q = builder.Select().
 			From(t1).
-			Join(t2).
-			On(t1.C(s.To.Column), t2.C(s.Edge.Columns[0]))
+			Join(t2)
+		joinOn(q, columns(t1, s.toColumns()), columns(t2, s.Edge.Columns))
 	case r == O2M || (r == O2O && !s.Edge.Inverse):
 		q = builder.Select().
 			From(builder.Table(s.To.Table).Schema(s.To.Schema)).
-			Where(sql.EQ(s.Edge.Columns[0], s.From.V))
+			Where(matchValue(s.Edge.Columns, s.From.V))
 	}
 	return q
 }
@@ -185,63 +233,417 @@ func SetNeighbors(dialect string, s *Step) (q *sql.Selector) {
 	builder := sql.Dialect(dialect)
 	switch r := s.Edge.Rel; {
 	case r == M2M:
-		pk1, pk2 := s.Edge.Columns[1], s.Edge.Columns[0]
-		if s.Edge.Inverse {
-			pk1, pk2 = pk2, pk1
-		}
+		pk2, pk1 := s.m2mColumns()
 		to := builder.Table(s.To.Table).Schema(s.To.Schema)
-		set.Select(set.C(s.From.Column))
+		set.Select(columns(set, s.fromColumns())...)
 		join := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
-		match := builder.Select(join.C(pk1)).
+		match := builder.Select(columns(join, pk1)...).
 			From(join).
-			Join(set).
-			On(join.C(pk2), set.C(s.From.Column))
+			Join(set)
+		joinOn(match, columns(join, pk2), columns(set, s.fromColumns()))
 		q = builder.Select().
 			From(to).
-			Join(match).
-			On(to.C(s.To.Column), match.C(pk1))
+			Join(match)
+		joinOn(q, columns(to, s.toColumns()), columns(match, pk1))
 	case r == M2O || (r == O2O && s.Edge.Inverse):
 		t1 := builder.Table(s.To.Table).Schema(s.To.Schema)
-		set.Select(set.C(s.Edge.Columns[0]))
+		set.Select(columns(set, s.Edge.Columns)...)
+		if p := s.Edge.Polymorphic; p != nil {
+			set.Where(sql.EQ(set.C(p.Column), p.Type))
+		}
 		q = builder.Select().
 			From(t1).
-			Join(set).
-			On(t1.C(s.To.Column), set.C(s.Edge.Columns[0]))
+			Join(set)
+		joinOn(q, columns(t1, s.toColumns()), columns(set, s.Edge.Columns))
 	case r == O2M || (r == O2O && !s.Edge.Inverse):
 		t1 := builder.Table(s.To.Table).Schema(s.To.Schema)
-		set.Select(set.C(s.From.Column))
+		set.Select(columns(set, s.fromColumns())...)
 		q = builder.Select().
 			From(t1).
-			Join(set).
-			On(t1.C(s.Edge.Columns[0]), set.C(s.From.Column))
+			Join(set)
+		joinOn(q, columns(t1, s.Edge.Columns), columns(set, s.fromColumns()))
 	}
 	return q
 }
 
+// fromColumns returns the identifier columns of the step source.
+func (s *Step) fromColumns() []string {
+	if len(s.From.Columns) > 0 {
+		return s.From.Columns
+	}
+	return []string{s.From.Column}
+}
+
+// toColumns returns the identifier columns of the step neighbors.
+func (s *Step) toColumns() []string {
+	if len(s.To.Columns) > 0 {
+		return s.To.Columns
+	}
+	return []string{s.To.Column}
+}
+
+// m2mColumns returns the columns of the join table that reference
+// the source of an M2M step, and the columns that reference its neighbors.
+func (s *Step) m2mColumns() (from, to []string) {
+	if s.Edge.Inverse {
+		n := len(s.toColumns())
+		return s.Edge.Columns[n:], s.Edge.Columns[:n]
+	}
+	n := len(s.fromColumns())
+	return s.Edge.Columns[:n], s.Edge.Columns[n:]
+}
+
+// columns returns the given columns qualified by the table (or selector) name.
+func columns(t interface{ C(string) string }, names []string) []string {
+	cs := make([]string, len(names))
+	for i := range names {
+		cs[i] = t.C(names[i])
+	}
+	return cs
+}
+
+// joinOn sets the join condition of the last join of the selector, which matches the
+// given columns pairwise. Composite identifiers are joined using multiple columns.
+func joinOn(s *sql.Selector, c1, c2 []string) {
+	if len(c1) == 1 {
+		s.On(c1[0], c2[0])
+		return
+	}
+	ps := make([]*sql.Predicate, len(c1))
+	for i := range c1 {
+		ps[i] = sql.ColumnsEQ(c1[i], c2[i])
+	}
+	s.OnP(sql.And(ps...))
+}
+
+// matchValue returns a predicate for matching the given columns with the value of
+// an identifier. The values of composite identifiers are held in a []driver.Value.
+func matchValue(columns []string, v any) *sql.Predicate {
+	if len(columns) == 1 {
+		return sql.EQ(columns[0], v)
+	}
+	vs, _ := v.([]driver.Value)
+	if len(vs) != len(columns) {
+		return sql.P(func(b *sql.Builder) {
+			b.AddError(fmt.Errorf("sql/sqlgraph: expect %d values for composite identifier %v, got: %v", len(columns), columns, v))
+		})
+	}
+	ps := make([]*sql.Predicate, len(columns))
+	for i := range columns {
+		ps[i] = sql.EQ(columns[i], vs[i])
+	}
+	return sql.And(ps...)
+}
+
+// inColumns returns a predicate for checking if the columns are in the results of
+// the given query. Multiple columns are compared as a row value. e.g. (a, b) IN (...).
+func inColumns(columns []string, query *sql.Selector) *sql.Predicate {
+	if len(columns) == 1 {
+		return sql.In(columns[0], query)
+	}
+	return sql.P(func(b *sql.Builder) {
+		b.Wrap(func(b *sql.Builder) {
+			b.IdentComma(columns...)
+		})
+		b.WriteOp(sql.OpIn)
+		b.Wrap(func(b *sql.Builder) {
+			b.Join(query)
+		})
+	})
+}
+
+// DepthColumn is the column that holds the depth of the nodes that are returned by
+// RecursiveNeighbors. i.e. the length of the shortest path from the source nodes.
+const DepthColumn = "depth"
+
+// RecursiveNeighbors returns a Selector for evaluating the path-step recursively, and getting
+// the nodes that are reachable from one vertex or a set of vertices (a sql.Selector) through
+// the edge, such as the descendants of a node in a tree. The source and the neighbors of the
+// step must reside in the same table, and a depth of 0 means that traversal is not limited.
+//
+// The traversal does not visit a node twice on the same path (i.e. a source node is not returned
+// as its own neighbor in case of cycles), and each node is returned once, with the length of its
+// shortest path from the source nodes stored in the DepthColumn. Nodes can be ordered by their
+// depth using the OrderByDepth function.
+func RecursiveNeighbors(dialect string, s *Step, depth int) *sql.Selector {
+	builder := sql.Dialect(dialect)
+	view := builder.Table(recursiveView)
+	depths := builder.Select(view.C(s.To.Column)).
+		AppendSelectExprAs(sql.Raw(sql.Min(view.C(DepthColumn))), DepthColumn).
+		From(view).
+		GroupBy(view.C(s.To.Column)).
+		As(recursiveDepth)
+	t1 := builder.Table(s.To.Table).Schema(s.To.Schema)
+	return builder.Select().
+		From(t1).
+		Join(depths).
+		On(t1.C(s.To.Column), depths.C(s.To.Column)).
+		Prefix(recursiveNeighbors(builder, s, depth))
+}
+
+// recursiveNeighbors returns the recursive view that holds the nodes that are reachable from
+// the source of the step, along with the depth and the path of each visit. A node may appear
+// in the view multiple times, once for each path that leads to it.
+func recursiveNeighbors(builder *sql.DialectBuilder, s *Step, depth int) *sql.WithBuilder {
+	from, to := edgeColumns(s)
+	t1 := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
+	base := builder.Select(t1.C(to)).
+		AppendSelectExpr(sql.Expr("1"), pathBase(concat(",", column(t1.C(from)), ",", column(t1.C(to)), ","))).
+		From(t1).
+		Where(sql.NotNull(t1.C(to)))
+	switch set := s.From.V.(type) {
+	case *sql.Selector:
+		base.Where(sql.In(t1.C(from), set.Select(set.C(s.From.Column))))
+	default:
+		base.Where(sql.EQ(t1.C(from), set))
+	}
+	t2, prev := builder.Table(s.Edge.Table).Schema(s.Edge.Schema), builder.Table(recursiveView)
+	recurse := builder.Select().
+		From(t2).
+		Join(prev).
+		On(t2.C(from), prev.C(s.To.Column))
+	recurse.Select(t2.C(to)).
+		AppendSelectExpr(
+			sql.ExprFunc(func(b *sql.Builder) {
+				b.Ident(prev.C(DepthColumn)).WriteOp(sql.OpAdd).WriteString("1")
+			}),
+			concat(column(prev.C(recursivePath)), column(t2.C(to)), ","),
+		).
+		Where(sql.NotNull(t2.C(to))).
+		// Skip nodes that were already visited on the same path.
+		Where(sql.Not(sql.P(func(b *sql.Builder) {
+			b.Ident(prev.C(recursivePath)).WriteString(" LIKE ").Join(concat("%,", column(t2.C(to)), ",%"))
+		})))
+	if depth > 0 {
+		recurse.Where(sql.LT(prev.C(DepthColumn), depth))
+	}
+	return builder.WithRecursive(recursiveView, s.To.Column, DepthColumn, recursivePath).
+		As(base.UnionAll(recurse))
+}
+
+// edgeColumns returns the columns of the step edge table, where each row in the
+// table connects the node stored in the "from" column to its neighbor stored in
+// the "to" column.
+func edgeColumns(s *Step) (from, to string) {
+	switch r := s.Edge.Rel; {
+	case r == M2M:
+		from, to = s.Edge.Columns[0], s.Edge.Columns[1]
+		if s.Edge.Inverse {
+			from, to = to, from
+		}
+	case r == M2O || (r == O2O && s.Edge.Inverse):
+		from, to = s.From.Column, s.Edge.Columns[0]
+	case r == O2M || (r == O2O && !s.Edge.Inverse):
+		from, to = s.Edge.Columns[0], s.To.Column
+	}
+	return from, to
+}
+
+// OrderByDepth orders the nodes returned by RecursiveNeighbors by their depth.
+func OrderByDepth(s *sql.Selector) {
+	s.OrderBy(sql.Dialect(s.Dialect()).Table(recursiveDepth).C(DepthColumn))
+}
+
+// OrderByDepthDesc orders the nodes returned by RecursiveNeighbors by their depth in descending order.
+func OrderByDepthDesc(s *sql.Selector) {
+	s.OrderBy(sql.Desc(sql.Dialect(s.Dialect()).Table(recursiveDepth).C(DepthColumn)))
+}
+
+// PathSpec holds the information for finding
+// paths between two nodes in the graph.
+type PathSpec struct {
+	// Step describes the edge that is traversed, and the
+	// identifier of the source node is stored in Step.From.V.
+	Step *Step
+	// Target is the identifier of the target node.
+	Target any
+	// Depth limits the length of the paths, where
+	// 0 means that the length is not limited.
+	Depth int
+}
+
+// ShortestPath returns the identifiers of the nodes on the shortest path from the source node of the
+// PathSpec to its target (both ends included), formatted as strings. If the target is not reachable
+// from the source, nil is returned. A node is always reachable from itself by an empty path, and in
+// this case, the returned path holds only the node itself.
+//
+// The path is found using a breadth-first search that queries the edges of one level of the graph
+// at a time. Each node is visited at most once, and therefore, the cost of the search is linear in
+// the size of the graph reachable from the source (bounded by Depth), at the price of one query for
+// each level of the search.
+func ShortestPath(ctx context.Context, drv dialect.Driver, spec *PathSpec) ([]string, error) {
+	source, err := idKey(spec.Step.From.V)
+	if err != nil {
+		return nil, err
+	}
+	target, err := idKey(spec.Target)
+	if err != nil {
+		return nil, err
+	}
+	if source == target {
+		return []string{fmt.Sprint(source)}, nil
+	}
+	parent := map[any]any{source: nil}
+	frontier := []any{spec.Step.From.V}
+	for depth := 1; len(frontier) > 0 && (spec.Depth <= 0 || depth <= spec.Depth); depth++ {
+		edges, err := pathEdges(ctx, drv, spec.Step, frontier)
+		if err != nil {
+			return nil, err
+		}
+		frontier = frontier[:0]
+		for _, e := range edges {
+			from, to := e[0], e[1]
+			if _, ok := parent[to]; ok {
+				continue
+			}
+			parent[to] = from
+			if to == target {
+				var path []string
+				for n := any(to); n != nil; n = parent[n] {
+					path = append(path, fmt.Sprint(n))
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path, nil
+			}
+			frontier = append(frontier, to)
+		}
+	}
+	return nil, nil
+}
+
+// Reachable reports if the target node of the PathSpec is reachable from its source node.
+// Like ShortestPath, a node is reachable from itself, and the search visits each node once.
+func Reachable(ctx context.Context, drv dialect.Driver, spec *PathSpec) (bool, error) {
+	path, err := ShortestPath(ctx, drv, spec)
+	if err != nil {
+		return false, err
+	}
+	return path != nil, nil
+}
+
+// pathEdges returns the edges (pairs of normalized identifiers) that
+// connect the given nodes to their neighbors through the step edge.
+func pathEdges(ctx context.Context, drv dialect.Driver, s *Step, nodes []any) ([][2]any, error) {
+	from, to := edgeColumns(s)
+	var edges [][2]any
+	for len(nodes) > 0 {
+		n := len(nodes)
+		if m := maxArgs(drv.Dialect()); n > m {
+			n = m
+		}
+		builder := sql.Dialect(drv.Dialect())
+		t1 := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
+		query, args := builder.Select(t1.C(from), t1.C(to)).
+			From(t1).
+			Where(sql.And(sql.In(t1.C(from), nodes[:n]...), sql.NotNull(t1.C(to)))).
+			Query()
+		rows := &sql.Rows{}
+		if err := drv.Query(ctx, query, args, rows); err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var e [2]any
+			if err := rows.Scan(&e[0], &e[1]); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			for i := range e {
+				k, err := idKey(e[i])
+				if err != nil {
+					rows.Close()
+					return nil, err
+				}
+				e[i] = k
+			}
+			edges = append(edges, e)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		nodes = nodes[n:]
+	}
+	return edges, nil
+}
+
+const (
+	// Names of the recursive view and the table that holds the depth of its nodes.
+	recursiveView  = "recursive_neighbors"
+	recursiveDepth = "recursive_depth"
+	// recursivePath holds the nodes that were visited on each path of the
+	// recursive view as a list of comma-separated identifiers. e.g. ",1,2,3,".
+	recursivePath = "path"
+)
+
+// column represents a column identifier in concat expressions.
+type column string
+
+// concat returns an expression for concatenating the given string
+// literals and column identifiers, according to the SQL dialect.
+func concat(parts ...any) sql.Querier {
+	return sql.ExprFunc(func(b *sql.Builder) {
+		mysql := b.Dialect() == dialect.MySQL
+		if mysql {
+			b.WriteString("CONCAT(")
+		}
+		for i, p := range parts {
+			switch {
+			case i > 0 && mysql:
+				b.Comma()
+			case i > 0:
+				b.WriteString(" || ")
+			}
+			switch p := p.(type) {
+			case column:
+				b.Ident(string(p))
+			case string:
+				b.WriteString("'" + p + "'")
+			}
+		}
+		if mysql {
+			b.WriteByte(')')
+		}
+	})
+}
+
+// pathBase wraps the path expression of the base query of the recursive view. In MySQL, the type
+// of recursive columns is determined by the base query, and it is widened to hold longer paths.
+func pathBase(path sql.Querier) sql.Querier {
+	return sql.ExprFunc(func(b *sql.Builder) {
+		if b.Dialect() != dialect.MySQL {
+			b.Join(path)
+			return
+		}
+		b.WriteString("CAST(").Join(path).WriteString(" AS CHAR(4096))")
+	})
+}
+
 // HasNeighbors applies on the given Selector a neighbors check.
 func HasNeighbors(q *sql.Selector, s *Step) {
 	builder := sql.Dialect(q.Dialect())
 	switch r := s.Edge.Rel; {
 	case r == M2M:
-		pk1 := s.Edge.Columns[0]
-		if s.Edge.Inverse {
-			pk1 = s.Edge.Columns[1]
-		}
+		pk1, _ := s.m2mColumns()
 		join := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
 		q.Where(
-			sql.In(
-				q.C(s.From.Column),
-				builder.Select(join.C(pk1)).From(join),
+			inColumns(
+				columns(q, s.fromColumns()),
+				builder.Select(columns(join, pk1)...).From(join),
 			),
 		)
 	case r == M2O || (r == O2O && s.Edge.Inverse):
-		q.Where(sql.NotNull(q.C(s.Edge.Columns[0])))
+		pred := sql.NotNull(q.C(s.Edge.Columns[0]))
+		if p := s.Edge.Polymorphic; p != nil {
+			pred = sql.And(pred, sql.EQ(q.C(p.Column), p.Type))
+		}
+		q.Where(pred)
 	case r == O2M || (r == O2O && !s.Edge.Inverse):
 		to := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
 		q.Where(
-			sql.In(
-				q.C(s.From.Column),
-				builder.Select(to.C(s.Edge.Columns[0])).
+			inColumns(
+				columns(q, s.fromColumns()),
+				builder.Select(columns(to, s.Edge.Columns)...).
 					From(to).
 					Where(sql.NotNull(to.C(s.Edge.Columns[0]))),
 			),
@@ -255,35 +657,36 @@ func HasNeighborsWith(q *sql.Selector, s *Step, pred func(*sql.Selector)) {
 	builder := sql.Dialect(q.Dialect())
 	switch r := s.Edge.Rel; {
 	case r == M2M:
-		pk1, pk2 := s.Edge.Columns[1], s.Edge.Columns[0]
-		if s.Edge.Inverse {
-			pk1, pk2 = pk2, pk1
-		}
+		pk2, pk1 := s.m2mColumns()
 		to := builder.Table(s.To.Table).Schema(s.To.Schema)
 		edge := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
-		join := builder.Select(edge.C(pk2)).
+		join := builder.Select(columns(edge, pk2)...).
 			From(edge).
-			Join(to).
-			On(edge.C(pk1), to.C(s.To.Column))
+			Join(to)
+		joinOn(join, columns(edge, pk1), columns(to, s.toColumns()))
 		matches := builder.Select().From(to)
 		matches.WithContext(q.Context())
 		pred(matches)
 		join.FromSelect(matches)
-		q.Where(sql.In(q.C(s.From.Column), join))
+		q.Where(inColumns(columns(q, s.fromColumns()), join))
 	case r == M2O || (r == O2O && s.Edge.Inverse):
 		to := builder.Table(s.To.Table).Schema(s.To.Schema)
-		matches := builder.Select(to.C(s.To.Column)).
+		matches := builder.Select(columns(to, s.toColumns())...).
 			From(to)
 		matches.WithContext(q.Context())
 		pred(matches)
-		q.Where(sql.In(q.C(s.Edge.Columns[0]), matches))
+		in := inColumns(columns(q, s.Edge.Columns), matches)
+		if p := s.Edge.Polymorphic; p != nil {
+			in = sql.And(in, sql.EQ(q.C(p.Column), p.Type))
+		}
+		q.Where(in)
 	case r == O2M || (r == O2O && !s.Edge.Inverse):
 		to := builder.Table(s.Edge.Table).Schema(s.Edge.Schema)
-		matches := builder.Select(to.C(s.Edge.Columns[0])).
+		matches := builder.Select(columns(to, s.Edge.Columns)...).
 			From(to)
 		matches.WithContext(q.Context())
 		pred(matches)
-		q.Where(sql.In(q.C(s.From.Column), matches))
+		q.Where(inColumns(columns(q, s.fromColumns()), matches))
 	}
 }
 
@@ -301,6 +704,10 @@ type (
 	EdgeTarget struct {
 		Nodes  []driver.Value
 		IDSpec *FieldSpec
+		// CompositeID holds the identifier columns of the target nodes
+		// in case their identifier is composite. Then, IDSpec is nil and
+		// each of the Nodes holds a []driver.Value with the column values.
+		CompositeID []*FieldSpec
 		// Additional fields can be set on the
 		// edge join table. Valid for M2M edges.
 		Fields []*FieldSpec
@@ -316,6 +723,15 @@ type (
 		Columns []string
 		Bidi    bool        // bidirectional edge.
 		Target  *EdgeTarget // target nodes.
+		// Polymorphic holds the type discriminator of polymorphic
+		// edges. It is used only for querying the graph schema.
+		Polymorphic *PolymorphicSpec
+	}
+
+	// PolymorphicSpec holds the type discriminator of polymorphic edges.
+	PolymorphicSpec struct {
+		Column string // discriminator column.
+		Type   string // type of the neighbors.
 	}
 
 	// EdgeSpecs used for perform common operations on list of edges.
@@ -328,7 +744,7 @@ type (
 		Schema      string
 		Columns     []string
 		ID          *FieldSpec   // primary key.
-		CompositeID []*FieldSpec // composite id (edge schema).
+		CompositeID []*FieldSpec // composite id (e.g. edge schema).
 	}
 )
 
@@ -337,6 +753,30 @@ func NewFieldSpec(column string, typ field.Type) *FieldSpec {
 	return &FieldSpec{Column: column, Type: typ}
 }
 
+// columns returns the identifier columns of the target nodes.
+func (e *EdgeTarget) columns() []string {
+	if len(e.CompositeID) == 0 {
+		return []string{e.IDSpec.Column}
+	}
+	return idColumns(e.CompositeID)
+}
+
+// m2mColumns returns the columns of the join table that reference the
+// given (source) nodes of an M2M edge, and the columns that reference
+// its target nodes. The values of composite ids are []driver.Value.
+func (e *EdgeSpec) m2mColumns(ids []driver.Value) (from, to []string) {
+	n := 1
+	if len(ids) > 0 {
+		if id, ok := ids[0].([]driver.Value); ok {
+			n = len(id)
+		}
+	}
+	if e.Inverse {
+		return e.Columns[len(e.Columns)-n:], e.Columns[:len(e.Columns)-n]
+	}
+	return e.Columns[:n], e.Columns[n:]
+}
+
 // FieldValues returns the values of additional fields that were set on the join-table.
 func (e *EdgeTarget) FieldValues() []any {
 	vs := make([]any, len(e.Fields))
@@ -355,6 +795,10 @@ type (
 		ID     *FieldSpec
 		Fields []*FieldSpec
 		Edges  []*EdgeSpec
+		// CompositeID holds the identifier columns of nodes with a composite identifier
+		// that are linked to other nodes through external tables (M2M and O2M edges).
+		// The values of these columns are taken from the Fields and Edges of the spec.
+		CompositeID []*FieldSpec
 
 		// The OnConflict option allows providing on-conflict
 		// options to the INSERT statement.
@@ -438,9 +882,26 @@ type (
 		Predicate func(*sql.Selector)
 		Modifiers []func(*sql.UpdateBuilder)
 
+		// Version holds the expected value of the concurrency token of the node
+		// (e.g. a version or an updated_at column). If set, the node is updated
+		// only if its column holds this value, and a ConflictError is returned
+		// otherwise.
+		Version *FieldSpec
+
 		ScanValues func(columns []string) ([]any, error)
 		Assign     func(columns []string, values []any) error
 	}
+
+	// BatchUpdateSpec holds the information for updating multiple
+	// nodes in the graph, each with its own values.
+	BatchUpdateSpec struct {
+		Nodes []*UpdateSpec
+
+		// BatchSize limits the number of nodes that are updated by each
+		// statement. It defaults to 1000, and it is further limited by the
+		// maximum number of arguments that are supported by the dialect.
+		BatchSize int
+	}
 )
 
 // NewUpdateSpec creates a new node update spec.
@@ -514,6 +975,28 @@ func UpdateNodes(ctx context.Context, drv dialect.Driver, spec *UpdateSpec) (int
 	return cr.nodes(ctx, drv)
 }
 
+// BatchUpdate applies the UpdateSpecs of the BatchUpdateSpec on their nodes. Nodes that
+// change the same columns are updated using one statement for each chunk of nodes. That is,
+// UPDATE ... FROM (VALUES ...) in PostgreSQL, UPDATE ... JOIN (SELECT ...) in MySQL, and CASE
+// expressions in SQLite. Note that the nodes must belong to the same table and be identified by
+// a single id, and their specs cannot contain predicates or modifiers.
+//
+// The versions of nodes with versioned updates are checked (and their rows are locked) before
+// they are updated, and a ConflictError that reports all conflicting nodes is returned if any
+// of them does not hold its expected version.
+func BatchUpdate(ctx context.Context, drv dialect.Driver, spec *BatchUpdateSpec) error {
+	tx, err := drv.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	gr := graph{tx: tx, builder: sql.Dialect(drv.Dialect())}
+	cr := &batchUpdater{BatchUpdateSpec: spec, graph: gr, dialect: drv.Dialect()}
+	if err := cr.nodes(ctx); err != nil {
+		return rollback(tx, err)
+	}
+	return tx.Commit()
+}
+
 // NotFoundError returns when trying to update an
 // entity, and it was not found in the database.
 type NotFoundError struct {
@@ -525,6 +1008,31 @@ func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("record with id %v not found in table %s", e.id, e.table)
 }
 
+// ConflictError returns when trying to update an entity whose
+// concurrency token (e.g. version) does not hold the expected
+// value, because it was modified since it was read.
+type ConflictError struct {
+	table string
+	nodes []*NodeSpec
+}
+
+func (e *ConflictError) Error() string {
+	if len(e.nodes) == 1 {
+		return fmt.Sprintf("record with id %v in table %s was modified concurrently", nodeID(e.nodes[0]), e.table)
+	}
+	ids := make([]driver.Value, len(e.nodes))
+	for i, n := range e.nodes {
+		ids[i] = nodeID(n)
+	}
+	return fmt.Sprintf("records with ids %v in table %s were modified concurrently", ids, e.table)
+}
+
+// Nodes returns the specs of the nodes that were modified concurrently. That is,
+// the Node field of the UpdateSpecs whose version did not hold the expected value.
+func (e *ConflictError) Nodes() []*NodeSpec {
+	return e.nodes
+}
+
 // DeleteSpec holds the information for delete one
 // or more nodes in the graph.
 type DeleteSpec struct {
@@ -595,6 +1103,73 @@ func QueryNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) error
 	return qr.nodes(ctx, drv)
 }
 
+// NodeIterator iterates over the rows of a graph query, and scans
+// them one at a time using the ScanValues and Assign functions of its
+// QuerySpec. The iterator holds an open database connection until it is
+// exhausted or closed, and therefore, it must be closed after use.
+type NodeIterator struct {
+	spec    *QuerySpec
+	rows    *sql.Rows
+	columns []string
+	err     error
+}
+
+// IterateNodes executes the graph query and returns an iterator for scanning its nodes.
+func IterateNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) (*NodeIterator, error) {
+	builder := sql.Dialect(drv.Dialect())
+	qr := &query{graph: graph{builder: builder}, QuerySpec: spec}
+	selector, err := qr.selector(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := drv.Query(ctx, query, args, rows); err != nil {
+		return nil, err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+	return &NodeIterator{spec: spec, rows: rows, columns: columns}, nil
+}
+
+// Next scans the next row of the query and assigns it using the Assign function of the
+// QuerySpec. It returns false when there are no more rows, or when an error occurred.
+func (i *NodeIterator) Next() bool {
+	if i.err != nil || !i.rows.Next() {
+		return false
+	}
+	values, err := i.spec.ScanValues(i.columns)
+	if err != nil {
+		i.err = err
+		return false
+	}
+	if err := i.rows.Scan(values...); err != nil {
+		i.err = err
+		return false
+	}
+	if err := i.spec.Assign(i.columns, values); err != nil {
+		i.err = err
+		return false
+	}
+	return true
+}
+
+// Err returns the error, if any, that was encountered during iteration.
+func (i *NodeIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return i.rows.Err()
+}
+
+// Close closes the underlying rows of the iterator.
+func (i *NodeIterator) Close() error {
+	return i.rows.Close()
+}
+
 // CountNodes counts the nodes in the given graph query.
 func CountNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) (int, error) {
 	builder := sql.Dialect(drv.Dialect())
@@ -602,6 +1177,40 @@ func CountNodes(ctx context.Context, drv dialect.Driver, spec *QuerySpec) (int,
 	return qr.count(ctx, drv)
 }
 
+// LimitNeighbors returns a query modifier that applies the given limit and offset
+// on each partition of the query, instead of on the query as a whole. It is used
+// by eager-loading queries to limit the neighbors loaded for each node, where the
+// partitionBy column holds the identifier of the node (e.g. its foreign-key).
+//
+// The ORDER BY clause of the query is moved into the window that numbers the rows
+// of each partition, and rows are returned ordered by their position in it. Zero
+// limit means that only the offset is applied.
+func LimitNeighbors(partitionBy string, limit, offset int) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		var (
+			columns = s.UnqualifiedColumns()
+			src     = s.Clone().As(s.TableName()).ClearOrder()
+			rn      = sql.RowNumber().PartitionBy(partitionBy).OrderExpr(s.OrderExprs()...)
+		)
+		src.AppendSelectExprAs(rn, rowNumber)
+		window := sql.Dialect(s.Dialect()).
+			Select(columns...).
+			From(src).
+			OrderBy(src.C(rowNumber)).
+			WithContext(s.Context())
+		if offset > 0 {
+			window.Where(sql.GT(src.C(rowNumber), offset))
+		}
+		if limit > 0 {
+			window.Where(sql.LTE(src.C(rowNumber), offset+limit))
+		}
+		*s = *window
+	}
+}
+
+// rowNumber is the column name of the window row numbers.
+const rowNumber = "row_number"
+
 // EdgeQuerySpec holds the information for querying
 // edges in the graph.
 type EdgeQuerySpec struct {
@@ -644,6 +1253,64 @@ func QueryEdges(ctx context.Context, drv dialect.Driver, spec *EdgeQuerySpec) er
 	return rows.Err()
 }
 
+// AggregateSpec holds the information for aggregating
+// the neighbors of nodes in the graph.
+type AggregateSpec struct {
+	// From is the query of the neighbors, and Column holds the
+	// column that identifies their node (e.g. its foreign-key).
+	From   *sql.Selector
+	Column string
+	// Aggregates are the aggregation functions that are applied
+	// on the neighbors of each node, e.g. COUNT(*).
+	Aggregates []func(*sql.Selector) string
+
+	ScanValue func() any
+	Assign    func(id any, values []sql.NullFloat64) error
+}
+
+// AggregateNeighbors groups the neighbors of the AggregateSpec by the node they belong to,
+// and scans the results of the aggregation functions for each node. Nodes without neighbors
+// are not returned by the query, and the ORDER BY clause of the neighbors query is ignored.
+func AggregateNeighbors(ctx context.Context, drv dialect.Driver, spec *AggregateSpec) error {
+	if len(spec.Aggregates) == 0 {
+		return fmt.Errorf("sqlgraph: missing aggregation functions for neighbors of %q", spec.Column)
+	}
+	selector := spec.From.ClearOrder().SetDistinct(false)
+	columns := make([]string, 0, len(spec.Aggregates)+1)
+	columns = append(columns, spec.Column)
+	for _, fn := range spec.Aggregates {
+		columns = append(columns, fn(selector))
+	}
+	selector.Select(columns...).GroupBy(spec.Column)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := drv.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id     = spec.ScanValue()
+			values = make([]sql.NullFloat64, len(spec.Aggregates))
+			dest   = make([]any, 0, len(columns))
+		)
+		dest = append(dest, id)
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		if err := spec.Assign(id, values); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 type query struct {
 	graph
 	*QuerySpec
@@ -755,26 +1422,12 @@ type updater struct {
 
 func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 	var (
-		id         driver.Value
-		idp        *sql.Predicate
 		addEdges   = EdgeSpecs(u.Edges.Add).GroupRel()
 		clearEdges = EdgeSpecs(u.Edges.Clear).GroupRel()
 	)
-	switch {
-	// In case it is not an edge schema, the id holds the PK
-	// of the node used for linking it with the other nodes.
-	case u.Node.ID != nil:
-		id = u.Node.ID.Value
-		idp = sql.EQ(u.Node.ID.Column, id)
-	case len(u.Node.CompositeID) == 2:
-		idp = sql.And(
-			sql.EQ(u.Node.CompositeID[0].Column, u.Node.CompositeID[0].Value),
-			sql.EQ(u.Node.CompositeID[1].Column, u.Node.CompositeID[1].Value),
-		)
-	case len(u.Node.CompositeID) != 2:
-		return fmt.Errorf("sql/sqlgraph: invalid composite id for update table %q", u.Node.Table)
-	default:
-		return fmt.Errorf("sql/sqlgraph: missing node id for update table %q", u.Node.Table)
+	idp, err := matchNode(u.Node)
+	if err != nil {
+		return fmt.Errorf("sql/sqlgraph: %w for update table %q", err, u.Node.Table)
 	}
 	update := u.builder.Update(u.Node.Table).Schema(u.Node.Schema).Where(idp)
 	if pred := u.Predicate; pred != nil {
@@ -782,6 +1435,9 @@ func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 		pred(selector)
 		update.FromSelect(selector)
 	}
+	if v := u.Version; v != nil {
+		update.Where(sql.EQ(v.Column, v.Value))
+	}
 	if err := u.setTableColumns(update, addEdges, clearEdges); err != nil {
 		return err
 	}
@@ -803,17 +1459,20 @@ func (u *updater) node(ctx context.Context, tx dialect.ExecQuerier) error {
 		}
 		// In case there are zero affected rows by this statement, we need to distinguish
 		// between the case of "record was not found" and "record was not changed".
-		if affected == 0 && u.Predicate != nil {
+		// If the update is versioned, the latter means that the record was modified
+		// since it was read.
+		if affected == 0 && (u.Predicate != nil || u.Version != nil) {
 			if err := u.ensureExists(ctx); err != nil {
 				return err
 			}
+			if u.Version != nil {
+				return &ConflictError{table: u.Node.Table, nodes: []*NodeSpec{u.Node}}
+			}
 		}
 	}
-	if id != nil {
-		// Not an edge schema.
-		if err := u.setExternalEdges(ctx, []driver.Value{id}, addEdges, clearEdges); err != nil {
-			return err
-		}
+	// The id (or the composite id) of the node is used for linking it with the other nodes.
+	if err := u.setExternalEdges(ctx, []driver.Value{nodeID(u.Node)}, addEdges, clearEdges); err != nil {
+		return err
 	}
 	// Ignore querying the database when there's nothing
 	// to scan into it.
@@ -844,23 +1503,13 @@ func (u *updater) nodes(ctx context.Context, drv dialect.Driver) (int, error) {
 				From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema)).
 				WithContext(ctx)
 	)
-	switch {
-	// In case it is not an edge schema, the id holds the PK of
-	// the returned nodes are used for updating external tables.
-	case u.Node.ID != nil:
-		selector.Select(u.Node.ID.Column)
-	case len(u.Node.CompositeID) == 2:
-		// Other edge-schemas (M2M tables) cannot be updated by this operation.
-		// Also, in case there is a need to update an external foreign-key, it must
-		// be a single value and the user should use the "update by id" API instead.
-		if multiple {
-			return 0, fmt.Errorf("sql/sqlgraph: update edge schema table %q cannot update external tables", u.Node.Table)
-		}
-	case len(u.Node.CompositeID) != 2:
-		return 0, fmt.Errorf("sql/sqlgraph: invalid composite id for update table %q", u.Node.Table)
-	default:
-		return 0, fmt.Errorf("sql/sqlgraph: missing node id for update table %q", u.Node.Table)
+	// The ids (or the composite ids) of the returned
+	// nodes are used for updating external tables.
+	idColumns, err := nodeColumns(u.Node)
+	if err != nil {
+		return 0, fmt.Errorf("sql/sqlgraph: %w for update table %q", err, u.Node.Table)
 	}
+	selector.Select(idColumns...)
 	if err := u.setTableColumns(update, addEdges, clearEdges); err != nil {
 		return 0, err
 	}
@@ -887,7 +1536,7 @@ func (u *updater) nodes(ctx context.Context, drv dialect.Driver) (int, error) {
 			return 0, fmt.Errorf("querying table %s: %w", u.Node.Table, err)
 		}
 		defer rows.Close()
-		if err := sql.ScanSlice(rows, &ids); err != nil {
+		if ids, err = scanNodeIDs(rows, len(idColumns)); err != nil {
 			return 0, fmt.Errorf("scan node ids: %w", err)
 		}
 		if err := rows.Close(); err != nil {
@@ -896,7 +1545,7 @@ func (u *updater) nodes(ctx context.Context, drv dialect.Driver) (int, error) {
 		if len(ids) == 0 {
 			return 0, nil
 		}
-		update.Where(matchID(u.Node.ID.Column, ids))
+		update.Where(matchColumns(idColumns, ids))
 		// In case of multi statement update, that change can
 		// affect more than 1 table, and therefore, we return
 		// the list of ids as number of affected records.
@@ -956,38 +1605,77 @@ func (u *updater) setExternalEdges(ctx context.Context, ids []driver.Value, addE
 
 // setTableColumns sets the table columns and foreign_keys used in insert.
 func (u *updater) setTableColumns(update *sql.UpdateBuilder, addEdges, clearEdges map[Rel][]*EdgeSpec) error {
+	return updateColumns(u.Fields, addEdges, clearEdges, func(c columnUpdate) {
+		switch c.op {
+		case opNull:
+			update.SetNull(c.column)
+		case opSet:
+			update.Set(c.column, c.value)
+		case opAdd:
+			update.Add(c.column, c.value)
+		}
+	})
+}
+
+// The operations that can be applied on a column by an UPDATE statement.
+const (
+	opSet  = "="    // column = ?
+	opAdd  = "+"    // column = column + ?
+	opNull = "NULL" // column = NULL
+)
+
+// columnUpdate describes an update of a single column of a node.
+type columnUpdate struct {
+	column string
+	op     string
+	value  driver.Value
+}
+
+// updateColumns calls the given function for each column of the node table
+// that is changed by the given field mutations and (foreign-key) edges.
+func updateColumns(fields FieldMut, addEdges, clearEdges map[Rel][]*EdgeSpec, fn func(columnUpdate)) error {
 	// Avoid multiple assignments to the same column.
 	setEdges := make(map[string]bool)
 	for _, e := range addEdges[M2O] {
-		setEdges[e.Columns[0]] = true
+		for _, c := range e.Columns {
+			setEdges[c] = true
+		}
 	}
 	for _, e := range addEdges[O2O] {
 		if e.Inverse || e.Bidi {
-			setEdges[e.Columns[0]] = true
+			for _, c := range e.Columns {
+				setEdges[c] = true
+			}
 		}
 	}
-	for _, fi := range u.Fields.Clear {
-		update.SetNull(fi.Column)
+	for _, fi := range fields.Clear {
+		fn(columnUpdate{column: fi.Column, op: opNull})
 	}
 	for _, e := range clearEdges[M2O] {
-		if col := e.Columns[0]; !setEdges[col] {
-			update.SetNull(col)
+		for _, col := range e.Columns {
+			if !setEdges[col] {
+				fn(columnUpdate{column: col, op: opNull})
+			}
 		}
 	}
 	for _, e := range clearEdges[O2O] {
-		col := e.Columns[0]
-		if (e.Inverse || e.Bidi) && !setEdges[col] {
-			update.SetNull(col)
+		if !e.Inverse && !e.Bidi {
+			continue
+		}
+		for _, col := range e.Columns {
+			if !setEdges[col] {
+				fn(columnUpdate{column: col, op: opNull})
+			}
 		}
 	}
-	err := setTableColumns(u.Fields.Set, addEdges, func(column string, value driver.Value) {
-		update.Set(column, value)
+	err := setTableColumns(fields.Set, addEdges, func(column string, value driver.Value) {
+		fn(columnUpdate{column: column, op: opSet, value: value})
 	})
 	if err != nil {
 		return err
 	}
-	for _, fi := range u.Fields.Add {
-		update.Add(fi.Column, fi.Value)
+	for _, fi := range fields.Add {
+		fn(columnUpdate{column: fi.Column, op: opAdd, value: fi.Value})
 	}
 	return nil
 }
@@ -1002,10 +1690,7 @@ func (u *updater) scan(rows *sql.Rows) error {
 		if err := rows.Err(); err != nil {
 			return err
 		}
-		if len(u.Node.CompositeID) == 2 {
-			return &NotFoundError{table: u.Node.Table, id: []driver.Value{u.Node.CompositeID[0].Value, u.Node.CompositeID[1].Value}}
-		}
-		return &NotFoundError{table: u.Node.Table, id: u.Node.ID.Value}
+		return &NotFoundError{table: u.Node.Table, id: nodeID(u.Node)}
 	}
 	values, err := u.ScanValues(columns)
 	if err != nil {
@@ -1021,8 +1706,14 @@ func (u *updater) scan(rows *sql.Rows) error {
 }
 
 func (u *updater) ensureExists(ctx context.Context) error {
-	exists := u.builder.Select().From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema)).Where(sql.EQ(u.Node.ID.Column, u.Node.ID.Value))
-	u.Predicate(exists)
+	idp, err := matchNode(u.Node)
+	if err != nil {
+		return err
+	}
+	exists := u.builder.Select().From(u.builder.Table(u.Node.Table).Schema(u.Node.Schema)).Where(idp)
+	if u.Predicate != nil {
+		u.Predicate(exists)
+	}
 	query, args := u.builder.SelectExpr(sql.Exists(exists)).Query()
 	rows := &sql.Rows{}
 	if err := u.tx.Query(ctx, query, args, rows); err != nil {
@@ -1034,11 +1725,623 @@ func (u *updater) ensureExists(ctx context.Context) error {
 		return err
 	}
 	if !found {
-		return &NotFoundError{table: u.Node.Table, id: u.Node.ID.Value}
+		return &NotFoundError{table: u.Node.Table, id: nodeID(u.Node)}
 	}
 	return nil
 }
 
+type batchUpdater struct {
+	graph
+	*BatchUpdateSpec
+	dialect string
+}
+
+// batchUpdate holds the nodes that change the same columns
+// in the same way, and are updated by the same statements.
+type batchUpdate struct {
+	columns []columnUpdate
+	ids     []driver.Value
+	values  [][]driver.Value
+	// The version column and the expected version of
+	// each node, in case the updates are versioned.
+	version  string
+	versions []driver.Value
+}
+
+func (u *batchUpdater) nodes(ctx context.Context) error {
+	if len(u.Nodes) == 0 {
+		return nil
+	}
+	var (
+		node    = u.Nodes[0].Node
+		keys    []string
+		batches = make(map[string]*batchUpdate)
+	)
+	for _, n := range u.Nodes {
+		switch {
+		case n.Node.Table != node.Table || n.Node.Schema != node.Schema:
+			return fmt.Errorf("sql/sqlgraph: batch update of different tables: %q and %q", node.Table, n.Node.Table)
+		case n.Node.ID == nil:
+			return fmt.Errorf("sql/sqlgraph: batch update of table %q requires a single node id", n.Node.Table)
+		case n.Predicate != nil || len(n.Modifiers) > 0:
+			return fmt.Errorf("sql/sqlgraph: batch update of table %q does not support predicates and modifiers", n.Node.Table)
+		}
+		var (
+			key     strings.Builder
+			columns []columnUpdate
+		)
+		// Versioned updates are batched separately, as their
+		// statements also match the version of each node.
+		if n.Version != nil {
+			key.WriteString("version " + n.Version.Column + ",")
+		}
+		err := updateColumns(n.Fields, EdgeSpecs(n.Edges.Add).GroupRel(), EdgeSpecs(n.Edges.Clear).GroupRel(), func(c columnUpdate) {
+			key.WriteString(c.column + " " + c.op + ",")
+			columns = append(columns, c)
+		})
+		if err != nil {
+			return err
+		}
+		// Nodes without column updates may still have changes in external tables.
+		if len(columns) == 0 {
+			continue
+		}
+		b, ok := batches[key.String()]
+		if !ok {
+			b = &batchUpdate{columns: columns}
+			if n.Version != nil {
+				b.version = n.Version.Column
+			}
+			batches[key.String()] = b
+			keys = append(keys, key.String())
+		}
+		values := make([]driver.Value, len(columns))
+		for i, c := range columns {
+			values[i] = c.value
+		}
+		b.ids = append(b.ids, n.Node.ID.Value)
+		b.values = append(b.values, values)
+		if n.Version != nil {
+			b.versions = append(b.versions, n.Version.Value)
+		}
+	}
+	if err := u.checkVersions(ctx, node); err != nil {
+		return err
+	}
+	for _, k := range keys {
+		b := batches[k]
+		size := u.batchSize(b)
+		for i := 0; i < len(b.ids); i += size {
+			j := i + size
+			if j > len(b.ids) {
+				j = len(b.ids)
+			}
+			query, args := u.statement(node, b, i, j).Query()
+			if err := u.tx.Exec(ctx, query, args, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for _, n := range u.Nodes {
+		addEdges, clearEdges := EdgeSpecs(n.Edges.Add).GroupRel(), EdgeSpecs(n.Edges.Clear).GroupRel()
+		if err := (&updater{UpdateSpec: n, graph: u.graph}).setExternalEdges(ctx, []driver.Value{n.Node.ID.Value}, addEdges, clearEdges); err != nil {
+			return err
+		}
+	}
+	return u.scan(ctx)
+}
+
+// batchSize returns the number of nodes that are updated by each statement of the given batch.
+func (u *batchUpdater) batchSize(b *batchUpdate) int {
+	size := u.BatchSize
+	if size <= 0 {
+		size = 1000
+	}
+	// Each node passes its id, its expected version in case the updates are versioned,
+	// and a value for each column that is not set to NULL. In SQLite, the id is passed
+	// again in the CASE expression of each of these columns.
+	args := 1
+	if b.version != "" {
+		args++
+	}
+	for _, c := range b.columns {
+		switch {
+		case c.op == opNull:
+		case u.dialect == dialect.SQLite:
+			args += 2
+		default:
+			args++
+		}
+	}
+	if n := maxArgs(u.dialect) / args; n < size {
+		size = n
+	}
+	return size
+}
+
+// statement returns the UPDATE statement for the nodes of the batch in the range [i, j).
+func (u *batchUpdater) statement(node *NodeSpec, b *batchUpdate, i, j int) *sql.Builder {
+	var (
+		stmt  = &sql.Builder{}
+		id    = node.ID.Column
+		alias = "_values"
+		table = func(stmt *sql.Builder) {
+			if node.Schema != "" {
+				stmt.Ident(node.Schema).WriteByte('.')
+			}
+			stmt.Ident(node.Table)
+		}
+	)
+	stmt.SetDialect(u.dialect)
+	stmt.WriteString("UPDATE ")
+	table(stmt)
+	switch u.dialect {
+	case dialect.Postgres:
+		// UPDATE "t" SET "c" = "_values"."c" FROM (VALUES (...), ...) AS "_values" ("id", "c") WHERE "t"."id" = "_values"."id"
+		stmt.WriteString(" SET ")
+		for k, c := range b.columns {
+			if k > 0 {
+				stmt.Comma()
+			}
+			stmt.Ident(c.column).WriteString(" = ")
+			switch c.op {
+			case opNull:
+				stmt.WriteString("NULL")
+			case opAdd:
+				stmt.Ident(node.Table).WriteByte('.').Ident(c.column).WriteString(" + ")
+				fallthrough
+			default:
+				stmt.Ident(alias).WriteByte('.').Ident(c.column)
+			}
+		}
+		// The first row selects no values from the table, and is used for resolving the types
+		// of the columns in the VALUES list, as PostgreSQL resolves untyped arguments as text.
+		columns, names := []string{id}, []string{id}
+		for _, c := range b.columns {
+			if c.op != opNull {
+				columns, names = append(columns, c.column), append(names, c.column)
+			}
+		}
+		if b.version != "" {
+			columns, names = append(columns, b.version), append(names, versionAlias)
+		}
+		stmt.WriteString(" FROM (VALUES (")
+		for k, c := range columns {
+			if k > 0 {
+				stmt.Comma()
+			}
+			stmt.WriteString("(SELECT ").Ident(c).WriteString(" FROM ")
+			table(stmt)
+			stmt.WriteString(" LIMIT 0)")
+		}
+		stmt.WriteByte(')')
+		for r := i; r < j; r++ {
+			stmt.Comma().Wrap(func(stmt *sql.Builder) {
+				stmt.Arg(b.ids[r])
+				for k, c := range b.columns {
+					if c.op != opNull {
+						stmt.Comma().Arg(b.values[r][k])
+					}
+				}
+				if b.version != "" {
+					stmt.Comma().Arg(b.versions[r])
+				}
+			})
+		}
+		stmt.WriteString(") AS ").Ident(alias).Pad().Wrap(func(stmt *sql.Builder) {
+			stmt.IdentComma(names...)
+		})
+		stmt.WriteString(" WHERE ").Ident(node.Table).WriteByte('.').Ident(id).
+			WriteString(" = ").Ident(alias).WriteByte('.').Ident(id)
+		if b.version != "" {
+			stmt.WriteString(" AND ").Ident(node.Table).WriteByte('.').Ident(b.version).
+				WriteString(" = ").Ident(alias).WriteByte('.').Ident(versionAlias)
+		}
+	case dialect.MySQL:
+		// UPDATE `t` JOIN (SELECT ? AS `id`, ? AS `c` UNION ALL SELECT ?, ?) AS `_values` ON `t`.`id` = `_values`.`id` SET `t`.`c` = `_values`.`c`
+		stmt.WriteString(" JOIN (")
+		for r := i; r < j; r++ {
+			if r > i {
+				stmt.WriteString(" UNION ALL ")
+			}
+			stmt.WriteString("SELECT ").Arg(b.ids[r])
+			if r == i {
+				stmt.WriteString(" AS ").Ident(id)
+			}
+			for k, c := range b.columns {
+				if c.op == opNull {
+					continue
+				}
+				stmt.Comma().Arg(b.values[r][k])
+				if r == i {
+					stmt.WriteString(" AS ").Ident(c.column)
+				}
+			}
+			if b.version != "" {
+				stmt.Comma().Arg(b.versions[r])
+				if r == i {
+					stmt.WriteString(" AS ").Ident(versionAlias)
+				}
+			}
+		}
+		stmt.WriteString(") AS ").Ident(alias).WriteString(" ON ").
+			Ident(node.Table).WriteByte('.').Ident(id).WriteString(" = ").Ident(alias).WriteByte('.').Ident(id)
+		if b.version != "" {
+			stmt.WriteString(" AND ").Ident(node.Table).WriteByte('.').Ident(b.version).
+				WriteString(" = ").Ident(alias).WriteByte('.').Ident(versionAlias)
+		}
+		stmt.WriteString(" SET ")
+		for k, c := range b.columns {
+			if k > 0 {
+				stmt.Comma()
+			}
+			stmt.Ident(node.Table).WriteByte('.').Ident(c.column).WriteString(" = ")
+			switch c.op {
+			case opNull:
+				stmt.WriteString("NULL")
+			case opAdd:
+				stmt.Ident(node.Table).WriteByte('.').Ident(c.column).WriteString(" + ")
+				fallthrough
+			default:
+				stmt.Ident(alias).WriteByte('.').Ident(c.column)
+			}
+		}
+	default:
+		// UPDATE `t` SET `c` = CASE `id` WHEN ? THEN ? ... ELSE `c` END WHERE `id` IN (...)
+		stmt.WriteString(" SET ")
+		for k, c := range b.columns {
+			if k > 0 {
+				stmt.Comma()
+			}
+			stmt.Ident(c.column).WriteString(" = ")
+			if c.op == opNull {
+				stmt.WriteString("NULL")
+				continue
+			}
+			stmt.WriteString("CASE ").Ident(id)
+			for r := i; r < j; r++ {
+				stmt.WriteString(" WHEN ").Arg(b.ids[r]).WriteString(" THEN ")
+				if c.op == opAdd {
+					stmt.Ident(c.column).WriteString(" + ")
+				}
+				stmt.Arg(b.values[r][k])
+			}
+			stmt.WriteString(" ELSE ").Ident(c.column).WriteString(" END")
+		}
+		stmt.WriteString(" WHERE ")
+		if b.version != "" {
+			// ... WHERE (`id` = ? AND `version` = ?) OR ...
+			for r := i; r < j; r++ {
+				if r > i {
+					stmt.WriteString(" OR ")
+				}
+				stmt.Wrap(func(stmt *sql.Builder) {
+					stmt.Ident(id).WriteString(" = ").Arg(b.ids[r]).WriteString(" AND ").
+						Ident(b.version).WriteString(" = ").Arg(b.versions[r])
+				})
+			}
+			break
+		}
+		stmt.Ident(id).WriteString(" IN ").Wrap(func(stmt *sql.Builder) {
+			for r := i; r < j; r++ {
+				if r > i {
+					stmt.Comma()
+				}
+				stmt.Arg(b.ids[r])
+			}
+		})
+	}
+	return stmt
+}
+
+// versionAlias is the name of the column that holds the
+// expected versions of the nodes in the batch statements.
+const versionAlias = "_version"
+
+// checkVersions ensures that the nodes with versioned updates hold their expected versions, and
+// locks their rows until the end of the transaction in dialects that support row-level locking.
+// Conflicting nodes are reported using one ConflictError, and a NotFoundError is returned if
+// one of these nodes does not exist.
+func (u *batchUpdater) checkVersions(ctx context.Context, node *NodeSpec) error {
+	var (
+		nodes []*UpdateSpec
+		idc   = node.ID.Column
+	)
+	for _, n := range u.Nodes {
+		if n.Version != nil {
+			nodes = append(nodes, n)
+		}
+	}
+	// Each node passes its id and its expected version.
+	size := maxArgs(u.dialect) / 2
+	matched := make(map[any]struct{}, len(nodes))
+	for i := 0; i < len(nodes); i += size {
+		j := i + size
+		if j > len(nodes) {
+			j = len(nodes)
+		}
+		ps := make([]*sql.Predicate, 0, j-i)
+		for _, n := range nodes[i:j] {
+			ps = append(ps, sql.And(sql.EQ(idc, n.Node.ID.Value), sql.EQ(n.Version.Column, n.Version.Value)))
+		}
+		selector := u.builder.Select(idc).
+			From(u.builder.Table(node.Table).Schema(node.Schema)).
+			Where(sql.Or(ps...))
+		// SQLite does not support row-level locking, and the database
+		// is locked by the first write statement of the transaction.
+		if u.dialect != dialect.SQLite {
+			selector.ForUpdate()
+		}
+		if err := u.scanIDs(ctx, selector, matched); err != nil {
+			return err
+		}
+	}
+	var missing []*UpdateSpec
+	for _, n := range nodes {
+		k, err := idKey(n.Node.ID.Value)
+		if err != nil {
+			return err
+		}
+		if _, ok := matched[k]; !ok {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	// Distinguish between nodes that were not found and
+	// nodes that were modified since they were read.
+	found := make(map[any]struct{}, len(missing))
+	for i := 0; i < len(missing); i += maxArgs(u.dialect) {
+		j := i + maxArgs(u.dialect)
+		if j > len(missing) {
+			j = len(missing)
+		}
+		ids := make([]driver.Value, 0, j-i)
+		for _, n := range missing[i:j] {
+			ids = append(ids, n.Node.ID.Value)
+		}
+		selector := u.builder.Select(idc).
+			From(u.builder.Table(node.Table).Schema(node.Schema)).
+			Where(matchID(idc, ids))
+		if err := u.scanIDs(ctx, selector, found); err != nil {
+			return err
+		}
+	}
+	conflicts := make([]*NodeSpec, 0, len(missing))
+	for _, n := range missing {
+		k, err := idKey(n.Node.ID.Value)
+		if err != nil {
+			return err
+		}
+		if _, ok := found[k]; !ok {
+			return &NotFoundError{table: node.Table, id: n.Node.ID.Value}
+		}
+		conflicts = append(conflicts, n.Node)
+	}
+	return &ConflictError{table: node.Table, nodes: conflicts}
+}
+
+// scanIDs queries the ids selected by the given selector, and adds their keys to the given set.
+func (u *batchUpdater) scanIDs(ctx context.Context, selector *sql.Selector, ids map[any]struct{}) error {
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := u.tx.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id any
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf("failed scanning rows: %w", err)
+		}
+		k, err := idKey(id)
+		if err != nil {
+			return err
+		}
+		ids[k] = struct{}{}
+	}
+	return rows.Err()
+}
+
+// scan queries the updated nodes, and assigns their values using the
+// ScanValues and Assign functions of their specs. Nodes that were not
+// found in the database are reported using a NotFoundError.
+func (u *batchUpdater) scan(ctx context.Context) error {
+	var (
+		keys   []string
+		groups = make(map[string][]*UpdateSpec)
+	)
+	// Nodes that select the same columns are queried together.
+	for _, n := range u.Nodes {
+		if n.ScanValues == nil {
+			continue
+		}
+		k := strings.Join(n.Node.Columns, ",")
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], n)
+	}
+	for _, k := range keys {
+		nodes := groups[k]
+		for i := 0; i < len(nodes); i += math.MaxUint16 - 1 {
+			j := i + math.MaxUint16 - 1
+			if j > len(nodes) {
+				j = len(nodes)
+			}
+			if err := u.scanNodes(ctx, nodes[i:j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (u *batchUpdater) scanNodes(ctx context.Context, nodes []*UpdateSpec) error {
+	var (
+		columns = nodes[0].Node.Columns
+		idc     = nodes[0].Node.ID.Column
+		ids     = make([]driver.Value, len(nodes))
+		byID    = make(map[any]*UpdateSpec, len(nodes))
+	)
+	for i, n := range nodes {
+		ids[i] = n.Node.ID.Value
+		k, err := idKey(n.Node.ID.Value)
+		if err != nil {
+			return err
+		}
+		byID[k] = n
+	}
+	pos := -1
+	for i, c := range columns {
+		if c == idc {
+			pos = i
+		}
+	}
+	if pos == -1 {
+		return fmt.Errorf("sql/sqlgraph: missing id column %q in selected columns of table %q", idc, nodes[0].Node.Table)
+	}
+	selector := u.builder.Select(columns...).
+		From(u.builder.Table(nodes[0].Node.Table).Schema(nodes[0].Node.Schema)).
+		Where(matchID(idc, ids))
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := u.tx.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		// The values are allocated using the spec of the first node, as their
+		// types do not depend on the node, and it is not known before scanning.
+		values, err := nodes[0].ScanValues(columns)
+		if err != nil {
+			return err
+		}
+		if err := rows.Scan(values...); err != nil {
+			return fmt.Errorf("failed scanning rows: %w", err)
+		}
+		k, err := idKey(values[pos])
+		if err != nil {
+			return err
+		}
+		n, ok := byID[k]
+		if !ok {
+			return fmt.Errorf("sql/sqlgraph: unexpected node %v in table %q", k, nodes[0].Node.Table)
+		}
+		if err := n.Assign(columns, values); err != nil {
+			return err
+		}
+		delete(byID, k)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, n := range byID {
+		return &NotFoundError{table: n.Node.Table, id: n.Node.ID.Value}
+	}
+	return nil
+}
+
+// idKey returns a comparable representation of the given
+// id, or a value that was scanned from an id column.
+func idKey(v any) (any, error) {
+	if vr, ok := v.(driver.Valuer); ok {
+		dv, err := vr.Value()
+		if err != nil {
+			return nil, err
+		}
+		v = dv
+	}
+	dv, err := driver.DefaultParameterConverter.ConvertValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := dv.([]byte); ok {
+		return string(b), nil
+	}
+	return dv, nil
+}
+
+// matchNode returns a predicate for matching the given node by its id or composite id.
+func matchNode(n *NodeSpec) (*sql.Predicate, error) {
+	switch {
+	case n.ID != nil:
+		return sql.EQ(n.ID.Column, n.ID.Value), nil
+	case len(n.CompositeID) > 1:
+		ps := make([]*sql.Predicate, len(n.CompositeID))
+		for i, id := range n.CompositeID {
+			ps[i] = sql.EQ(id.Column, id.Value)
+		}
+		return sql.And(ps...), nil
+	case len(n.CompositeID) == 1:
+		return nil, errors.New("invalid composite id")
+	default:
+		return nil, errors.New("missing node id")
+	}
+}
+
+// nodeColumns returns the id columns of the given node.
+func nodeColumns(n *NodeSpec) ([]string, error) {
+	switch {
+	case n.ID != nil:
+		return []string{n.ID.Column}, nil
+	case len(n.CompositeID) > 1:
+		return idColumns(n.CompositeID), nil
+	case len(n.CompositeID) == 1:
+		return nil, errors.New("invalid composite id")
+	default:
+		return nil, errors.New("missing node id")
+	}
+}
+
+// idColumns returns the columns of the given identifier fields.
+func idColumns(fields []*FieldSpec) []string {
+	columns := make([]string, len(fields))
+	for i := range fields {
+		columns[i] = fields[i].Column
+	}
+	return columns
+}
+
+// scanNodeIDs scans the ids of the nodes from the given rows. The values of
+// composite ids, with the given number of columns, are scanned as []driver.Value.
+func scanNodeIDs(rows *sql.Rows, columns int) ([]driver.Value, error) {
+	var ids []driver.Value
+	if columns == 1 {
+		err := sql.ScanSlice(rows, &ids)
+		return ids, err
+	}
+	for rows.Next() {
+		id := make([]driver.Value, columns)
+		dest := make([]any, columns)
+		for i := range id {
+			dest[i] = &id[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// nodeID returns the id of the given node for reporting. The
+// values of composite ids are returned as []driver.Value.
+func nodeID(n *NodeSpec) driver.Value {
+	if n.ID != nil {
+		return n.ID.Value
+	}
+	id := make([]driver.Value, len(n.CompositeID))
+	for i := range n.CompositeID {
+		id[i] = n.CompositeID[i].Value
+	}
+	return id
+}
+
 type creator struct {
 	graph
 	*CreateSpec
@@ -1049,7 +2352,12 @@ func (c *creator) node(ctx context.Context, drv dialect.Driver) error {
 		edges  = EdgeSpecs(c.Edges).GroupRel()
 		insert = c.builder.Insert(c.Table).Schema(c.Schema).Default()
 	)
-	if err := c.setTableColumns(insert, edges); err != nil {
+	values := make(map[string]driver.Value)
+	err := setTableColumns(c.Fields, edges, func(column string, value driver.Value) {
+		insert.Set(column, value)
+		values[column] = value
+	})
+	if err != nil {
 		return err
 	}
 	tx, err := c.mayTx(ctx, drv, edges)
@@ -1057,23 +2365,32 @@ func (c *creator) node(ctx context.Context, drv dialect.Driver) error {
 		return err
 	}
 	if err := func() error {
-		// In case the spec does not contain an ID field, we assume
-		// we interact with an edge-schema with composite primary key.
+		var id driver.Value
+		// In case the spec does not contain an ID field, we assume we
+		// interact with a node (or an edge-schema) with composite primary key.
 		if c.ID == nil {
 			c.ensureConflict(insert)
 			query, args, err := insert.QueryErr()
 			if err != nil {
 				return err
 			}
-			return c.tx.Exec(ctx, query, args, nil)
-		}
-		if err := c.insert(ctx, insert); err != nil {
-			return err
+			if err := c.tx.Exec(ctx, query, args, nil); err != nil {
+				return err
+			}
+			if len(c.CompositeID) == 0 {
+				return nil
+			}
+			id = compositeID(c.CompositeID, values)
+		} else {
+			if err := c.insert(ctx, insert); err != nil {
+				return err
+			}
+			id = c.ID.Value
 		}
-		if err := c.graph.addM2MEdges(ctx, []driver.Value{c.ID.Value}, edges[M2M]); err != nil {
+		if err := c.graph.addM2MEdges(ctx, []driver.Value{id}, edges[M2M]); err != nil {
 			return err
 		}
-		return c.graph.addFKEdges(ctx, []driver.Value{c.ID.Value}, append(edges[O2M], edges[O2O]...))
+		return c.graph.addFKEdges(ctx, []driver.Value{id}, append(edges[O2M], edges[O2O]...))
 	}(); err != nil {
 		return rollback(tx, err)
 	}
@@ -1093,14 +2410,6 @@ func (c *creator) mayTx(ctx context.Context, drv dialect.Driver, edges map[Rel][
 	return tx, nil
 }
 
-// setTableColumns sets the table columns and foreign_keys used in insert.
-func (c *creator) setTableColumns(insert *sql.InsertBuilder, edges map[Rel][]*EdgeSpec) error {
-	err := setTableColumns(c.Fields, edges, func(column string, value driver.Value) {
-		insert.Set(column, value)
-	})
-	return err
-}
-
 // insert a node to its table and sets its ID if it was not provided by the user.
 func (c *creator) insert(ctx context.Context, insert *sql.InsertBuilder) error {
 	c.ensureConflict(insert)
@@ -1144,6 +2453,15 @@ func (c *creator) ensureLastInsertID(insert *sql.InsertBuilder) {
 	}))
 }
 
+// compositeID returns the values of the given composite id columns.
+func compositeID(columns []*FieldSpec, values map[string]driver.Value) driver.Value {
+	id := make([]driver.Value, len(columns))
+	for i, c := range columns {
+		id[i] = values[c.Column]
+	}
+	return id
+}
+
 type batchCreator struct {
 	graph
 	*BatchCreateSpec
@@ -1188,38 +2506,65 @@ func (c *batchCreator) nodes(ctx context.Context, drv dialect.Driver) error {
 		}
 	}
 	sorted := keys(columns)
-	insert := c.builder.Insert(c.Nodes[0].Table).Schema(c.Nodes[0].Schema).Default().Columns(sorted...)
+	rows := make([][]any, len(values))
 	for i := range values {
-		vs := make([]any, len(sorted))
+		rows[i] = make([]any, len(sorted))
 		for j, c := range sorted {
-			vs[j] = values[i][c]
+			rows[i][j] = values[i][c]
 		}
-		insert.Values(vs...)
 	}
-	tx, err := c.mayTx(ctx, drv)
+	// Split the rows into multiple statements, in case they exceed
+	// the maximum number of arguments supported by the database.
+	size := maxRows(drv.Dialect(), len(sorted))
+	tx, err := c.mayTx(ctx, drv, len(rows) > size)
 	if err != nil {
 		return err
 	}
 	c.tx = tx
 	if err := func() error {
-		// In case the spec does not contain an ID field, we assume
-		// we interact with an edge-schema with composite primary key.
-		if c.Nodes[0].ID == nil {
-			c.ensureConflict(insert)
-			query, args := insert.Query()
-			return tx.Exec(ctx, query, args, nil)
+		for i := 0; i < len(rows); i += size {
+			j := i + size
+			if j > len(rows) {
+				j = len(rows)
+			}
+			insert := c.builder.Insert(c.Nodes[0].Table).Schema(c.Nodes[0].Schema).Default().Columns(sorted...)
+			for _, vs := range rows[i:j] {
+				insert.Values(vs...)
+			}
+			// In case the spec does not contain an ID field, we assume
+			// we interact with an edge-schema with composite primary key.
+			if c.Nodes[0].ID == nil {
+				c.ensureConflict(insert)
+				query, args := insert.Query()
+				if err := tx.Exec(ctx, query, args, nil); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := c.batchInsert(ctx, tx, insert, c.Nodes[i:j]); err != nil {
+				return fmt.Errorf("insert nodes to table %q: %w", c.Nodes[0].Table, err)
+			}
 		}
-		if err := c.batchInsert(ctx, tx, insert); err != nil {
-			return fmt.Errorf("insert nodes to table %q: %w", c.Nodes[0].Table, err)
+		ids := make([]driver.Value, len(c.Nodes))
+		for i, node := range c.Nodes {
+			switch {
+			case node.ID != nil:
+				ids[i] = node.ID.Value
+			case len(node.CompositeID) > 0:
+				ids[i] = compositeID(node.CompositeID, values[i])
+			default:
+				// Edge-schemas are not linked to other nodes through external tables.
+				return nil
+			}
 		}
-		if err := c.batchAddM2M(ctx, c.BatchCreateSpec); err != nil {
+		if err := c.batchAddM2M(ctx, c.BatchCreateSpec, ids); err != nil {
 			return err
 		}
 		// FKs that exist in different tables can't be updated in batch (using the CASE
 		// statement), because we rely on RowsAffected to check if the FK column is NULL.
-		for _, node := range c.Nodes {
+		for i, node := range c.Nodes {
 			edges := EdgeSpecs(node.Edges).GroupRel()
-			if err := c.graph.addFKEdges(ctx, []driver.Value{node.ID.Value}, append(edges[O2M], edges[O2O]...)); err != nil {
+			if err := c.graph.addFKEdges(ctx, []driver.Value{ids[i]}, append(edges[O2M], edges[O2O]...)); err != nil {
 				return err
 			}
 		}
@@ -1231,7 +2576,10 @@ func (c *batchCreator) nodes(ctx context.Context, drv dialect.Driver) error {
 }
 
 // mayTx opens a new transaction if the create operation spans across multiple statements.
-func (c *batchCreator) mayTx(ctx context.Context, drv dialect.Driver) (dialect.Tx, error) {
+func (c *batchCreator) mayTx(ctx context.Context, drv dialect.Driver, batches bool) (dialect.Tx, error) {
+	if batches {
+		return drv.Tx(ctx)
+	}
 	for _, node := range c.Nodes {
 		for _, edge := range node.Edges {
 			if isExternalEdge(edge) {
@@ -1243,9 +2591,9 @@ func (c *batchCreator) mayTx(ctx context.Context, drv dialect.Driver) (dialect.T
 }
 
 // batchInsert inserts a batch of nodes to their table and sets their ID if it was not provided by the user.
-func (c *batchCreator) batchInsert(ctx context.Context, tx dialect.ExecQuerier, insert *sql.InsertBuilder) error {
+func (c *batchCreator) batchInsert(ctx context.Context, tx dialect.ExecQuerier, insert *sql.InsertBuilder, nodes []*CreateSpec) error {
 	c.ensureConflict(insert)
-	return c.insertLastIDs(ctx, tx, insert.Returning(c.Nodes[0].ID.Column))
+	return c.insertLastIDs(ctx, tx, insert.Returning(nodes[0].ID.Column), nodes)
 }
 
 // ensureConflict ensures the ON CONFLICT is added to the insert statement.
@@ -1304,22 +2652,19 @@ func (g *graph) clearM2MEdges(ctx context.Context, ids []driver.Value, edges Edg
 		edges := tables[table]
 		preds := make([]*sql.Predicate, 0, len(edges))
 		for _, edge := range edges {
-			fromC, toC := edge.Columns[0], edge.Columns[1]
-			if edge.Inverse {
-				fromC, toC = toC, fromC
-			}
+			fromC, toC := edge.m2mColumns(ids)
 			// If there are no specific edges (to target-nodes) to remove,
 			// clear all edges that go out (or come in) from the nodes.
 			if len(edge.Target.Nodes) == 0 {
-				preds = append(preds, matchID(fromC, ids))
+				preds = append(preds, matchColumns(fromC, ids))
 				if edge.Bidi {
-					preds = append(preds, matchID(toC, ids))
+					preds = append(preds, matchColumns(toC, ids))
 				}
 			} else {
 				pk1, pk2 := ids, edge.Target.Nodes
-				preds = append(preds, matchIDs(fromC, pk1, toC, pk2))
+				preds = append(preds, sql.And(matchColumns(fromC, pk1), matchColumns(toC, pk2)))
 				if edge.Bidi {
-					preds = append(preds, matchIDs(toC, pk1, fromC, pk2))
+					preds = append(preds, sql.And(matchColumns(toC, pk1), matchColumns(fromC, pk2)))
 				}
 			}
 		}
@@ -1365,9 +2710,9 @@ func (g *graph) addM2MEdges(ctx context.Context, ids []driver.Value, edges EdgeS
 				pk1, pk2 = pk2, pk1
 			}
 			for _, pair := range product(pk1, pk2) {
-				insert.Values(append([]any{pair[0], pair[1]}, values...)...)
+				insert.Values(append(flatten(pair[0], pair[1]), values...)...)
 				if edge.Bidi {
-					insert.Values(append([]any{pair[1], pair[0]}, values...)...)
+					insert.Values(append(flatten(pair[1], pair[0]), values...)...)
 				}
 			}
 		}
@@ -1384,51 +2729,72 @@ func (g *graph) addM2MEdges(ctx context.Context, ids []driver.Value, edges EdgeS
 	return nil
 }
 
-func (g *graph) batchAddM2M(ctx context.Context, spec *BatchCreateSpec) error {
-	tables := make(map[string]*sql.InsertBuilder)
-	for _, node := range spec.Nodes {
+// batchAddM2M adds the M2M edges of the nodes in the spec, where ids[i] holds the id of the i-th node.
+func (g *graph) batchAddM2M(ctx context.Context, spec *BatchCreateSpec, ids []driver.Value) error {
+	var (
+		tables = make(map[string]func() *sql.InsertBuilder)
+		rows   = make(map[string][][]any)
+	)
+	for i, node := range spec.Nodes {
 		edges := EdgeSpecs(node.Edges).FilterRel(M2M)
 		for name, edges := range edges.GroupTable() {
 			if len(edges) != 1 {
 				return fmt.Errorf("expect exactly 1 edge-spec per table, but got %d", len(edges))
 			}
 			edge := edges[0]
-			insert, ok := tables[name]
-			if !ok {
+			if _, ok := tables[name]; !ok {
 				columns := edge.Columns
 				// Additional fields, such as edge-schema fields.
 				for _, f := range edge.Target.Fields {
 					columns = append(columns, f.Column)
 				}
-				insert = g.builder.Insert(name).Columns(columns...)
-				if edge.Schema != "" {
-					// If the Schema field was provided to the EdgeSpec (by the
-					// generated code), it should be the same for all EdgeSpecs.
-					insert.Schema(edge.Schema)
-				}
-				// Ignore conflicts only if edges do not contain extra fields, because these fields
-				// can hold different values on different insertions (e.g. time.Now() or uuid.New()).
-				if len(edge.Target.Fields) == 0 {
-					insert.OnConflict(sql.DoNothing())
+				name, edge := name, edge
+				tables[name] = func() *sql.InsertBuilder {
+					insert := g.builder.Insert(name).Columns(columns...)
+					if edge.Schema != "" {
+						// If the Schema field was provided to the EdgeSpec (by the
+						// generated code), it should be the same for all EdgeSpecs.
+						insert.Schema(edge.Schema)
+					}
+					// Ignore conflicts only if edges do not contain extra fields, because these fields
+					// can hold different values on different insertions (e.g. time.Now() or uuid.New()).
+					if len(edge.Target.Fields) == 0 {
+						insert.OnConflict(sql.DoNothing())
+					}
+					return insert
 				}
 			}
-			tables[name] = insert
-			pk1, pk2 := []driver.Value{node.ID.Value}, edge.Target.Nodes
+			pk1, pk2 := []driver.Value{ids[i]}, edge.Target.Nodes
 			if edge.Inverse {
 				pk1, pk2 = pk2, pk1
 			}
 			for _, pair := range product(pk1, pk2) {
-				insert.Values(append([]any{pair[0], pair[1]}, edge.Target.FieldValues()...)...)
+				rows[name] = append(rows[name], append(flatten(pair[0], pair[1]), edge.Target.FieldValues()...))
 				if edge.Bidi {
-					insert.Values(append([]any{pair[1], pair[0]}, edge.Target.FieldValues()...)...)
+					rows[name] = append(rows[name], append(flatten(pair[1], pair[0]), edge.Target.FieldValues()...))
 				}
 			}
 		}
 	}
 	for _, table := range insertKeys(tables) {
-		query, args := tables[table].Query()
-		if err := g.tx.Exec(ctx, query, args, nil); err != nil {
-			return fmt.Errorf("add m2m edge for table %s: %w", table, err)
+		// Split the rows into multiple statements, in case they exceed
+		// the maximum number of arguments supported by the database.
+		for i, size := 0, 0; i < len(rows[table]); i += size {
+			insert := tables[table]()
+			if size == 0 {
+				size = maxRows(insert.Dialect(), len(rows[table][0]))
+			}
+			j := i + size
+			if j > len(rows[table]) {
+				j = len(rows[table])
+			}
+			for _, vs := range rows[table][i:j] {
+				insert.Values(vs...)
+			}
+			query, args := insert.Query()
+			if err := g.tx.Exec(ctx, query, args, nil); err != nil {
+				return fmt.Errorf("add m2m edge for table %s: %w", table, err)
+			}
 		}
 	}
 	return nil
@@ -1441,14 +2807,15 @@ func (g *graph) clearFKEdges(ctx context.Context, ids []driver.Value, edges []*E
 		}
 		// O2O relations can be cleared without
 		// passing the target ids.
-		pred := matchID(edge.Columns[0], ids)
+		pred := matchColumns(edge.Columns, ids)
 		if nodes := edge.Target.Nodes; len(nodes) > 0 {
-			pred = matchIDs(edge.Target.IDSpec.Column, edge.Target.Nodes, edge.Columns[0], ids)
+			pred = sql.And(matchColumns(edge.Target.columns(), edge.Target.Nodes), matchColumns(edge.Columns, ids))
 		}
-		query, args := g.builder.Update(edge.Table).
-			SetNull(edge.Columns[0]).
-			Where(pred).
-			Query()
+		update := g.builder.Update(edge.Table)
+		for _, c := range edge.Columns {
+			update.SetNull(c)
+		}
+		query, args := update.Where(pred).Query()
 		if err := g.tx.Exec(ctx, query, args, nil); err != nil {
 			return fmt.Errorf("add %s edge for table %s: %w", edge.Rel, edge.Table, err)
 		}
@@ -1467,16 +2834,16 @@ func (g *graph) addFKEdges(ctx context.Context, ids []driver.Value, edges []*Edg
 		if edge.Rel == O2O && edge.Inverse {
 			continue
 		}
-		p := sql.EQ(edge.Target.IDSpec.Column, edge.Target.Nodes[0])
-		// Use "IN" predicate instead of list of "OR"
-		// in case of more than on nodes to connect.
-		if len(edge.Target.Nodes) > 1 {
-			p = sql.InValues(edge.Target.IDSpec.Column, edge.Target.Nodes...)
+		values, err := fkValues(edge.Columns, id)
+		if err != nil {
+			return err
 		}
-		query, args := g.builder.Update(edge.Table).
-			Schema(edge.Schema).
-			Set(edge.Columns[0], id).
-			Where(sql.And(p, sql.IsNull(edge.Columns[0]))).
+		update := g.builder.Update(edge.Table).Schema(edge.Schema)
+		for i, c := range edge.Columns {
+			update.Set(c, values[i])
+		}
+		query, args := update.
+			Where(sql.And(matchColumns(edge.Target.columns(), edge.Target.Nodes), sql.IsNull(edge.Columns[0]))).
 			Query()
 		var res sql.Result
 		if err := g.tx.Exec(ctx, query, args, &res); err != nil {
@@ -1489,7 +2856,12 @@ func (g *graph) addFKEdges(ctx context.Context, ids []driver.Value, edges []*Edg
 		// Setting the FK value of the "other" table without clearing it before, is not allowed.
 		// Including no-op (same id), because we rely on "affected" to determine if the FK set.
 		if ids := edge.Target.Nodes; int(affected) < len(ids) {
-			return &ConstraintError{msg: fmt.Sprintf("one of %v is already connected to a different %s", ids, edge.Columns[0])}
+			return &ConstraintError{
+				msg:    fmt.Sprintf("one of %v is already connected to a different %s", ids, edge.Columns[0]),
+				Kind:   sql.KindUnique,
+				Table:  edge.Table,
+				Column: edge.Columns[0],
+			}
 		}
 	}
 	return nil
@@ -1534,16 +2906,44 @@ func setTableColumns(fields []*FieldSpec, edges map[Rel][]*EdgeSpec, set func(st
 		set(fi.Column, value)
 	}
 	for _, e := range edges[M2O] {
-		set(e.Columns[0], e.Target.Nodes[0])
+		if err := setFKColumns(e, set); err != nil {
+			return err
+		}
 	}
 	for _, e := range edges[O2O] {
 		if e.Inverse || e.Bidi {
-			set(e.Columns[0], e.Target.Nodes[0])
+			if err := setFKColumns(e, set); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+// setFKColumns sets the foreign-key columns of the given edge to the id of its target node.
+func setFKColumns(e *EdgeSpec, set func(string, driver.Value)) error {
+	values, err := fkValues(e.Columns, e.Target.Nodes[0])
+	if err != nil {
+		return err
+	}
+	for i, c := range e.Columns {
+		set(c, values[i])
+	}
+	return nil
+}
+
+// fkValues returns the values of the given foreign-key columns for the given node id.
+// Foreign-keys to nodes with composite ids span a column for each of their values.
+func fkValues(columns []string, id driver.Value) ([]driver.Value, error) {
+	if len(columns) == 1 {
+		return []driver.Value{id}, nil
+	}
+	if vs, ok := id.([]driver.Value); ok && len(vs) == len(columns) {
+		return vs, nil
+	}
+	return nil, fmt.Errorf("sql/sqlgraph: expect %d values for foreign-key columns %v, got: %v", len(columns), columns, id)
+}
+
 // insertLastID invokes the insert query on the transaction and returns the LastInsertID.
 func (c *creator) insertLastID(ctx context.Context, insert *sql.InsertBuilder) error {
 	query, args, err := insert.QueryErr()
@@ -1593,7 +2993,7 @@ func (c *creator) insertLastID(ctx context.Context, insert *sql.InsertBuilder) e
 }
 
 // insertLastIDs invokes the batch insert query on the transaction and returns the LastInsertID of all entities.
-func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier, insert *sql.InsertBuilder) error {
+func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier, insert *sql.InsertBuilder, nodes []*CreateSpec) error {
 	query, args, err := insert.QueryErr()
 	if err != nil {
 		return err
@@ -1606,7 +3006,7 @@ func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier
 		}
 		defer rows.Close()
 		for i := 0; rows.Next(); i++ {
-			node := c.Nodes[i]
+			node := nodes[i]
 			if node.ID.Type.Numeric() {
 				// Normalize the type to int64 to make it looks
 				// like LastInsertId.
@@ -1628,7 +3028,7 @@ func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier
 	}
 	// If the ID field is not numeric (e.g. string),
 	// there is no way to scan the LAST_INSERT_ID.
-	if len(c.Nodes) > 0 && c.Nodes[0].ID.Type.Numeric() {
+	if len(nodes) > 0 && nodes[0].ID.Type.Numeric() {
 		id, err := res.LastInsertId()
 		if err != nil {
 			return err
@@ -1639,8 +3039,8 @@ func (c *batchCreator) insertLastIDs(ctx context.Context, tx dialect.ExecQuerier
 		}
 		// Assume the ID field is AUTO_INCREMENT
 		// if its type is numeric.
-		for i := 0; int64(i) < affected && i < len(c.Nodes); i++ {
-			c.Nodes[i].ID.Value = id + int64(i)
+		for i := 0; int64(i) < affected && i < len(nodes); i++ {
+			nodes[i].ID.Value = id + int64(i)
 		}
 	}
 	return nil
@@ -1663,7 +3063,7 @@ func edgeKeys(m map[string][]*EdgeSpec) []string {
 	return keys
 }
 
-func insertKeys(m map[string]*sql.InsertBuilder) []string {
+func insertKeys(m map[string]func() *sql.InsertBuilder) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
 		keys = append(keys, k)
@@ -1672,6 +3072,24 @@ func insertKeys(m map[string]*sql.InsertBuilder) []string {
 	return keys
 }
 
+// maxArgs returns the maximum number of arguments in a single statement of the
+// given dialect. SQLite limits the number of arguments to 32766 (since 3.32.0).
+func maxArgs(d string) int {
+	if d == dialect.SQLite {
+		return 32766
+	}
+	return math.MaxUint16
+}
+
+// maxRows returns the maximum number of rows that can be passed
+// to a single INSERT statement with the given number of columns.
+func maxRows(d string, columns int) int {
+	if columns == 0 {
+		return math.MaxInt32
+	}
+	return maxArgs(d) / columns
+}
+
 func keys(m map[string]struct{}) []string {
 	keys := make([]string, 0, len(m))
 	for k := range m {
@@ -1681,6 +3099,38 @@ func keys(m map[string]struct{}) []string {
 	return keys
 }
 
+// matchColumns is like matchID, but supports composite ids. i.e. ids that span multiple
+// columns, and their values are held as []driver.Value.
+func matchColumns(columns []string, pk []driver.Value) *sql.Predicate {
+	if len(columns) == 1 {
+		return matchID(columns[0], pk)
+	}
+	ps := make([]*sql.Predicate, len(pk))
+	for i := range pk {
+		ps[i] = matchValue(columns, pk[i])
+	}
+	if len(ps) == 1 {
+		return ps[0]
+	}
+	return sql.Or(ps...)
+}
+
+// flatten returns the values of the given ids as a flat list,
+// where composite ids are expanded to the values of their columns.
+func flatten(ids ...driver.Value) []any {
+	vs := make([]any, 0, len(ids))
+	for _, id := range ids {
+		if cid, ok := id.([]driver.Value); ok {
+			for _, v := range cid {
+				vs = append(vs, v)
+			}
+		} else {
+			vs = append(vs, id)
+		}
+	}
+	return vs
+}
+
 func matchID(column string, pk []driver.Value) *sql.Predicate {
 	if len(pk) > 1 {
 		return sql.InValues(column, pk...)
@@ -100,6 +100,17 @@ func TestNeighbors(t *testing.T) {
 			wantQuery: "SELECT * FROM `users` JOIN (SELECT `owner_id` FROM `pets` WHERE `id` = ?) AS `t1` ON `users`.`id` = `t1`.`owner_id`",
 			wantArgs:  []any{2},
 		},
+		{
+			name: "M2O/polymorphic",
+			input: NewStep(
+				From("comments", "id", 2),
+				To("posts", "id"),
+				Edge(M2O, false, "comments", "target_id"),
+				Polymorphic("target_type", "Post"),
+			),
+			wantQuery: "SELECT * FROM `posts` JOIN (SELECT `target_id` FROM `comments` WHERE `id` = ? AND `target_type` = ?) AS `t1` ON `posts`.`id` = `t1`.`target_id`",
+			wantArgs:  []any{2, "Post"},
+		},
 		{
 			name: "M2O/1type/inverse",
 			input: NewStep(
@@ -278,6 +289,46 @@ func TestNeighbors(t *testing.T) {
 			wantQuery: "SELECT * FROM `s1`.`groups` JOIN (SELECT `s2`.`user_groups`.`group_id` FROM `s2`.`user_groups` WHERE `s2`.`user_groups`.`user_id` = ?) AS `t1` ON `s1`.`groups`.`id` = `t1`.`group_id`",
 			wantArgs:  []any{2},
 		},
+		{
+			name: "composite/O2M",
+			input: NewStep(
+				CompositeFrom("accounts", []string{"tenant_id", "external_id"}, []driver.Value{1, "x"}),
+				To("cards", "id"),
+				Edge(O2M, false, "cards", "account_tenant_id", "account_external_id"),
+			),
+			wantQuery: "SELECT * FROM `cards` WHERE `account_tenant_id` = ? AND `account_external_id` = ?",
+			wantArgs:  []any{1, "x"},
+		},
+		{
+			name: "composite/M2O",
+			input: NewStep(
+				From("cards", "id", 1),
+				CompositeTo("accounts", []string{"tenant_id", "external_id"}),
+				Edge(M2O, true, "cards", "account_tenant_id", "account_external_id"),
+			),
+			wantQuery: "SELECT * FROM `accounts` JOIN (SELECT `account_tenant_id`, `account_external_id` FROM `cards` WHERE `id` = ?) AS `t1` ON `accounts`.`tenant_id` = `t1`.`account_tenant_id` AND `accounts`.`external_id` = `t1`.`account_external_id`",
+			wantArgs:  []any{1},
+		},
+		{
+			name: "composite/M2M",
+			input: NewStep(
+				CompositeFrom("accounts", []string{"tenant_id", "external_id"}, []driver.Value{1, "x"}),
+				To("groups", "id"),
+				Edge(M2M, false, "account_groups", "account_tenant_id", "account_external_id", "group_id"),
+			),
+			wantQuery: "SELECT * FROM `groups` JOIN (SELECT `account_groups`.`group_id` FROM `account_groups` WHERE `account_groups`.`account_tenant_id` = ? AND `account_groups`.`account_external_id` = ?) AS `t1` ON `groups`.`id` = `t1`.`group_id`",
+			wantArgs:  []any{1, "x"},
+		},
+		{
+			name: "composite/M2M/inverse",
+			input: NewStep(
+				From("groups", "id", 2),
+				CompositeTo("accounts", []string{"tenant_id", "external_id"}),
+				Edge(M2M, true, "account_groups", "account_tenant_id", "account_external_id", "group_id"),
+			),
+			wantQuery: "SELECT * FROM `accounts` JOIN (SELECT `account_groups`.`account_tenant_id`, `account_groups`.`account_external_id` FROM `account_groups` WHERE `account_groups`.`group_id` = ?) AS `t1` ON `accounts`.`tenant_id` = `t1`.`account_tenant_id` AND `accounts`.`external_id` = `t1`.`account_external_id`",
+			wantArgs:  []any{2},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -316,6 +367,17 @@ func TestSetNeighbors(t *testing.T) {
 			wantQuery: `SELECT * FROM "users" JOIN (SELECT "pets"."owner_id" FROM "pets" WHERE "name" = $1) AS "t1" ON "users"."id" = "t1"."owner_id"`,
 			wantArgs:  []any{"pedro"},
 		},
+		{
+			name: "M2O/polymorphic",
+			input: NewStep(
+				From("comments", "id", sql.Select().From(sql.Table("comments")).Where(sql.EQ("text", "hello"))),
+				To("posts", "id"),
+				Edge(M2O, false, "comments", "target_id"),
+				Polymorphic("target_type", "Post"),
+			),
+			wantQuery: `SELECT * FROM "posts" JOIN (SELECT "comments"."target_id" FROM "comments" WHERE "text" = $1 AND "comments"."target_type" = $2) AS "t1" ON "posts"."id" = "t1"."target_id"`,
+			wantArgs:  []any{"hello", "Post"},
+		},
 		{
 			name: "M2M/2types",
 			input: NewStep(
@@ -442,12 +504,74 @@ JOIN
 	}
 }
 
+func TestRecursiveNeighbors(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     *Step
+		dialect   string
+		depth     int
+		order     func(*sql.Selector)
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name: "O2M/vertex",
+			input: NewStep(
+				From("nodes", "id", 1),
+				To("nodes", "id"),
+				Edge(O2M, false, "nodes", "parent_id"),
+			),
+			dialect:   dialect.SQLite,
+			order:     OrderByDepth,
+			wantQuery: "WITH RECURSIVE `recursive_neighbors`(`id`, `depth`, `path`) AS (SELECT `nodes`.`id`, 1, ',' || `nodes`.`parent_id` || ',' || `nodes`.`id` || ',' FROM `nodes` WHERE `nodes`.`id` IS NOT NULL AND `nodes`.`parent_id` = ? UNION ALL SELECT `nodes`.`id`, `t1`.`depth` + 1, `t1`.`path` || `nodes`.`id` || ',' FROM `nodes` JOIN `recursive_neighbors` AS `t1` ON `nodes`.`parent_id` = `t1`.`id` WHERE `nodes`.`id` IS NOT NULL AND (NOT (`t1`.`path` LIKE '%,' || `nodes`.`id` || ',%'))) SELECT * FROM `nodes` JOIN (SELECT `recursive_neighbors`.`id`, (MIN(`recursive_neighbors`.`depth`)) AS `depth` FROM `recursive_neighbors` GROUP BY `recursive_neighbors`.`id`) AS `recursive_depth` ON `nodes`.`id` = `recursive_depth`.`id` ORDER BY `recursive_depth`.`depth`",
+			wantArgs:  []any{1},
+		},
+		{
+			name: "M2M/inverse/set",
+			input: NewStep(
+				From("users", "id", sql.Select().From(sql.Table("users")).Where(sql.EQ("name", "a8m"))),
+				To("users", "id"),
+				Edge(M2M, true, "user_friends", "user_id", "friend_id"),
+			),
+			dialect:   dialect.Postgres,
+			depth:     3,
+			wantQuery: `WITH RECURSIVE "recursive_neighbors"("id", "depth", "path") AS (SELECT "user_friends"."user_id", 1, ',' || "user_friends"."friend_id" || ',' || "user_friends"."user_id" || ',' FROM "user_friends" WHERE "user_friends"."user_id" IS NOT NULL AND "user_friends"."friend_id" IN (SELECT "users"."id" FROM "users" WHERE "name" = $1) UNION ALL SELECT "user_friends"."user_id", "t1"."depth" + 1, "t1"."path" || "user_friends"."user_id" || ',' FROM "user_friends" JOIN "recursive_neighbors" AS "t1" ON "user_friends"."friend_id" = "t1"."id" WHERE ("user_friends"."user_id" IS NOT NULL AND (NOT ("t1"."path" LIKE '%,' || "user_friends"."user_id" || ',%'))) AND "t1"."depth" < $2) SELECT * FROM "users" JOIN (SELECT "recursive_neighbors"."id", (MIN("recursive_neighbors"."depth")) AS "depth" FROM "recursive_neighbors" GROUP BY "recursive_neighbors"."id") AS "recursive_depth" ON "users"."id" = "recursive_depth"."id"`,
+			wantArgs:  []any{"a8m", 3},
+		},
+		{
+			name: "M2O/inverse/depth",
+			input: NewStep(
+				From("nodes", "id", 1),
+				To("nodes", "id"),
+				Edge(M2O, true, "nodes", "parent_id"),
+			),
+			dialect:   dialect.MySQL,
+			depth:     2,
+			order:     OrderByDepthDesc,
+			wantQuery: "WITH RECURSIVE `recursive_neighbors`(`id`, `depth`, `path`) AS (SELECT `nodes`.`parent_id`, 1, CAST(CONCAT(',', `nodes`.`id`, ',', `nodes`.`parent_id`, ',') AS CHAR(4096)) FROM `nodes` WHERE `nodes`.`parent_id` IS NOT NULL AND `nodes`.`id` = ? UNION ALL SELECT `nodes`.`parent_id`, `t1`.`depth` + 1, CONCAT(`t1`.`path`, `nodes`.`parent_id`, ',') FROM `nodes` JOIN `recursive_neighbors` AS `t1` ON `nodes`.`id` = `t1`.`id` WHERE (`nodes`.`parent_id` IS NOT NULL AND (NOT (`t1`.`path` LIKE CONCAT('%,', `nodes`.`parent_id`, ',%')))) AND `t1`.`depth` < ?) SELECT * FROM `nodes` JOIN (SELECT `recursive_neighbors`.`id`, (MIN(`recursive_neighbors`.`depth`)) AS `depth` FROM `recursive_neighbors` GROUP BY `recursive_neighbors`.`id`) AS `recursive_depth` ON `nodes`.`id` = `recursive_depth`.`id` ORDER BY `recursive_depth`.`depth` DESC",
+			wantArgs:  []any{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector := RecursiveNeighbors(tt.dialect, tt.input, tt.depth)
+			if tt.order != nil {
+				tt.order(selector)
+			}
+			query, args := selector.Query()
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
 func TestHasNeighbors(t *testing.T) {
 	tests := []struct {
 		name      string
 		step      *Step
 		selector  *sql.Selector
 		wantQuery string
+		wantArgs  []any
 	}{
 		{
 			name: "O2O/1type",
@@ -494,6 +618,30 @@ func TestHasNeighbors(t *testing.T) {
 			selector:  sql.Select("*").From(sql.Table("pets")),
 			wantQuery: "SELECT * FROM `pets` WHERE `pets`.`owner_id` IS NOT NULL",
 		},
+		{
+			name: "M2O/polymorphic",
+			step: NewStep(
+				From("comments", "id"),
+				To("posts", "id"),
+				Edge(M2O, false, "comments", "target_id"),
+				Polymorphic("target_type", "Post"),
+			),
+			selector:  sql.Select("*").From(sql.Table("comments")),
+			wantQuery: "SELECT * FROM `comments` WHERE `comments`.`target_id` IS NOT NULL AND `comments`.`target_type` = ?",
+			wantArgs:  []any{"Post"},
+		},
+		{
+			name: "M2O/polymorphic/or",
+			step: NewStep(
+				From("comments", "id"),
+				To("posts", "id"),
+				Edge(M2O, false, "comments", "target_id"),
+				Polymorphic("target_type", "Post"),
+			),
+			selector:  sql.Select("*").From(sql.Table("comments")).Where(sql.EQ("text", "ent")).Or(),
+			wantQuery: "SELECT * FROM `comments` WHERE `text` = ? OR (`comments`.`target_id` IS NOT NULL AND `comments`.`target_type` = ?)",
+			wantArgs:  []any{"ent", "Post"},
+		},
 		{
 			name: "M2M/2types",
 			step: NewStep(
@@ -629,7 +777,7 @@ func TestHasNeighbors(t *testing.T) {
 				HasNeighbors(s, tt.step)
 				query, args := s.Query()
 				require.Equal(t, tt.wantQuery, query)
-				require.Empty(t, args)
+				require.Equal(t, tt.wantArgs, args)
 			}
 		})
 	}
@@ -703,6 +851,22 @@ func TestHasNeighborsWith(t *testing.T) {
 			wantQuery: `SELECT * FROM "pets" WHERE "name" = $1 AND "pets"."owner_id" IN (SELECT "users"."id" FROM "users" WHERE "last_name" = $2)`,
 			wantArgs:  []any{"pedro", "mashraki"},
 		},
+		{
+			name: "M2O/polymorphic",
+			step: NewStep(
+				From("comments", "id"),
+				To("posts", "id"),
+				Edge(M2O, false, "comments", "target_id"),
+				Polymorphic("target_type", "Post"),
+			),
+			selector: sql.Dialect("postgres").Select("*").
+				From(sql.Table("comments")),
+			predicate: func(s *sql.Selector) {
+				s.Where(sql.EQ("title", "ent"))
+			},
+			wantQuery: `SELECT * FROM "comments" WHERE "comments"."target_id" IN (SELECT "posts"."id" FROM "posts" WHERE "title" = $1) AND "comments"."target_type" = $2`,
+			wantArgs:  []any{"ent", "Post"},
+		},
 		{
 			name: "M2M",
 			step: NewStep(
@@ -1273,6 +1437,95 @@ func TestCreateNode(t *testing.T) {
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
+		{
+			name: "edges/composite/m2o",
+			spec: &CreateSpec{
+				Table: "cards",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt},
+				Fields: []*FieldSpec{
+					{Column: "number", Type: field.TypeString, Value: "1234"},
+				},
+				Edges: []*EdgeSpec{
+					{Rel: M2O, Inverse: true, Table: "cards", Columns: []string{"account_tenant_id", "account_external_id"}, Target: &EdgeTarget{Nodes: []driver.Value{[]driver.Value{1, "x"}}, CompositeID: []*FieldSpec{{Column: "tenant_id"}, {Column: "external_id"}}}},
+				},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectExec(escape("INSERT INTO `cards` (`number`, `account_tenant_id`, `account_external_id`) VALUES (?, ?, ?)")).
+					WithArgs("1234", 1, "x").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name: "edges/composite/o2m",
+			spec: &CreateSpec{
+				Table: "accounts",
+				Fields: []*FieldSpec{
+					{Column: "tenant_id", Type: field.TypeInt, Value: 1},
+					{Column: "external_id", Type: field.TypeString, Value: "x"},
+				},
+				CompositeID: []*FieldSpec{{Column: "tenant_id"}, {Column: "external_id"}},
+				Edges: []*EdgeSpec{
+					{Rel: O2M, Table: "cards", Columns: []string{"account_tenant_id", "account_external_id"}, Target: &EdgeTarget{Nodes: []driver.Value{2}, IDSpec: &FieldSpec{Column: "id"}}},
+				},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(escape("INSERT INTO `accounts` (`tenant_id`, `external_id`) VALUES (?, ?)")).
+					WithArgs(1, "x").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(escape("UPDATE `cards` SET `account_tenant_id` = ?, `account_external_id` = ? WHERE `id` = ? AND `account_tenant_id` IS NULL")).
+					WithArgs(1, "x", 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "edges/composite/m2m",
+			spec: &CreateSpec{
+				Table: "accounts",
+				Fields: []*FieldSpec{
+					{Column: "tenant_id", Type: field.TypeInt, Value: 1},
+					{Column: "external_id", Type: field.TypeString, Value: "x"},
+				},
+				CompositeID: []*FieldSpec{{Column: "tenant_id"}, {Column: "external_id"}},
+				Edges: []*EdgeSpec{
+					{Rel: M2M, Table: "account_groups", Columns: []string{"account_tenant_id", "account_external_id", "group_id"}, Target: &EdgeTarget{Nodes: []driver.Value{2, 3}, IDSpec: &FieldSpec{Column: "id"}}},
+				},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(escape("INSERT INTO `accounts` (`tenant_id`, `external_id`) VALUES (?, ?)")).
+					WithArgs(1, "x").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectExec(escape("INSERT INTO `account_groups` (`account_tenant_id`, `account_external_id`, `group_id`) VALUES (?, ?, ?), (?, ?, ?) ON DUPLICATE KEY UPDATE `account_tenant_id` = `account_groups`.`account_tenant_id`, `account_external_id` = `account_groups`.`account_external_id`, `group_id` = `account_groups`.`group_id`")).
+					WithArgs(1, "x", 2, 1, "x", 3).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
+		{
+			name: "edges/composite/m2m/inverse",
+			spec: &CreateSpec{
+				Table: "groups",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt},
+				Fields: []*FieldSpec{
+					{Column: "name", Type: field.TypeString, Value: "GitHub"},
+				},
+				Edges: []*EdgeSpec{
+					{Rel: M2M, Inverse: true, Table: "account_groups", Columns: []string{"account_tenant_id", "account_external_id", "group_id"}, Target: &EdgeTarget{Nodes: []driver.Value{[]driver.Value{1, "x"}}, CompositeID: []*FieldSpec{{Column: "tenant_id"}, {Column: "external_id"}}}},
+				},
+			},
+			expect: func(m sqlmock.Sqlmock) {
+				m.ExpectBegin()
+				m.ExpectExec(escape("INSERT INTO `groups` (`name`) VALUES (?)")).
+					WithArgs("GitHub").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				m.ExpectExec(escape("INSERT INTO `account_groups` (`account_tenant_id`, `account_external_id`, `group_id`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `account_tenant_id` = `account_groups`.`account_tenant_id`, `account_external_id` = `account_groups`.`account_external_id`, `group_id` = `account_groups`.`group_id`")).
+					WithArgs(1, "x", 2).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				m.ExpectCommit()
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1487,6 +1740,41 @@ func TestBatchCreate(t *testing.T) {
 	}
 }
 
+func TestBatchCreateChunks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// SQLite allows 32766 arguments in a statement. Hence, the
+	// nodes are inserted in batches of 16383 rows of 2 columns.
+	nodes := make([]*CreateSpec, 16384)
+	for i := range nodes {
+		nodes[i] = &CreateSpec{
+			Table: "users",
+			ID:    &FieldSpec{Column: "id", Type: field.TypeInt},
+			Fields: []*FieldSpec{
+				{Column: "age", Type: field.TypeInt, Value: i},
+				{Column: "name", Type: field.TypeString, Value: "a8m"},
+			},
+		}
+	}
+	ids := sqlmock.NewRows([]string{"id"})
+	for i := 0; i < 16383; i++ {
+		ids.AddRow(i + 1)
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("^" + regexp.QuoteMeta("INSERT INTO `users` (`age`, `name`) VALUES (?, ?), ")).
+		WillReturnRows(ids)
+	mock.ExpectQuery(escape("INSERT INTO `users` (`age`, `name`) VALUES (?, ?) RETURNING `id`")).
+		WithArgs(16383, "a8m").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(16384))
+	mock.ExpectCommit()
+	err = BatchCreate(context.Background(), sql.OpenDB(dialect.SQLite, db), &BatchCreateSpec{Nodes: nodes})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, int64(1), nodes[0].ID.Value)
+	require.Equal(t, int64(16383), nodes[16382].ID.Value)
+	require.Equal(t, int64(16384), nodes[16383].ID.Value)
+}
+
 type user struct {
 	id    int
 	age   int
@@ -1881,6 +2169,114 @@ func TestExecUpdateNode(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdateNodeVersion(t *testing.T) {
+	spec := func() *UpdateSpec {
+		return &UpdateSpec{
+			Node: &NodeSpec{
+				Table: "users",
+				ID:    &FieldSpec{Column: "id", Type: field.TypeInt, Value: 1},
+			},
+			Fields: FieldMut{
+				Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "Ariel"}},
+				Add: []*FieldSpec{{Column: "version", Type: field.TypeInt, Value: 1}},
+			},
+			Version: &FieldSpec{Column: "version", Type: field.TypeInt, Value: 2},
+		}
+	}
+	update := escape("UPDATE `users` SET `name` = ?, `version` = COALESCE(`users`.`version`, 0) + ? WHERE `id` = ? AND `version` = ?")
+	exists := escape("SELECT EXISTS (SELECT * FROM `users` WHERE `id` = ?)")
+
+	t.Run("Updated", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(update).
+			WithArgs("Ariel", 1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		require.NoError(t, UpdateNode(context.Background(), sql.OpenDB(dialect.MySQL, db), spec()))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(update).
+			WithArgs("Ariel", 1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(exists).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectRollback()
+		err = UpdateNode(context.Background(), sql.OpenDB(dialect.MySQL, db), spec())
+		require.True(t, IsConflictError(err))
+		require.EqualError(t, err, "record with id 1 in table users was modified concurrently")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(update).
+			WithArgs("Ariel", 1, 1, 2).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(exists).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+		err = UpdateNode(context.Background(), sql.OpenDB(dialect.MySQL, db), spec())
+		require.IsType(t, &NotFoundError{}, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestExecUpdateNodeCompositeID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectBegin()
+	mock.ExpectExec(escape("UPDATE `accounts` SET `name` = ? WHERE (`tenant_id` = ? AND `region` = ? AND `external_id` = ?) AND `version` = ?")).
+		WithArgs("a8m", 1, "us", "x", 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(escape("SELECT EXISTS (SELECT * FROM `accounts` WHERE (`tenant_id` = ? AND `region` = ? AND `external_id` = ?) AND `version` = ?)")).
+		WithArgs(1, "us", "x", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectRollback()
+	err = UpdateNode(context.Background(), sql.OpenDB("", db), &UpdateSpec{
+		Node: &NodeSpec{
+			Table: "accounts",
+			CompositeID: []*FieldSpec{
+				{Column: "tenant_id", Type: field.TypeInt, Value: 1},
+				{Column: "region", Type: field.TypeString, Value: "us"},
+				{Column: "external_id", Type: field.TypeString, Value: "x"},
+			},
+		},
+		Predicate: func(s *sql.Selector) {
+			s.Where(sql.EQ("version", 1))
+		},
+		Fields: FieldMut{
+			Set: []*FieldSpec{
+				{Column: "name", Type: field.TypeString, Value: "a8m"},
+			},
+		},
+	})
+	var nf *NotFoundError
+	require.ErrorAs(t, err, &nf)
+	require.EqualError(t, nf, "record with id [1 us x] not found in table accounts")
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	err = UpdateNode(context.Background(), sql.OpenDB("", db), &UpdateSpec{
+		Node: &NodeSpec{
+			Table:       "accounts",
+			CompositeID: []*FieldSpec{{Column: "tenant_id", Type: field.TypeInt, Value: 1}},
+		},
+	})
+	require.EqualError(t, err, `sql/sqlgraph: invalid composite id for update table "accounts"`)
+}
+
 func TestUpdateNodes(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -2145,6 +2541,38 @@ func TestUpdateNodes(t *testing.T) {
 			},
 			wantAffected: 4,
 		},
+		{
+			name: "composite_id/m2m",
+			spec: &UpdateSpec{
+				Node: &NodeSpec{
+					Table:       "accounts",
+					CompositeID: []*FieldSpec{{Column: "tenant_id", Type: field.TypeInt}, {Column: "external_id", Type: field.TypeString}},
+				},
+				Edges: EdgeMut{
+					Clear: []*EdgeSpec{
+						{Rel: M2M, Table: "account_groups", Columns: []string{"account_tenant_id", "account_external_id", "group_id"}, Target: &EdgeTarget{Nodes: []driver.Value{2}, IDSpec: &FieldSpec{Column: "id"}}},
+					},
+					Add: []*EdgeSpec{
+						{Rel: M2M, Table: "account_groups", Columns: []string{"account_tenant_id", "account_external_id", "group_id"}, Target: &EdgeTarget{Nodes: []driver.Value{3}, IDSpec: &FieldSpec{Column: "id"}}},
+					},
+				},
+			},
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(escape("SELECT `tenant_id`, `external_id` FROM `accounts`")).
+					WillReturnRows(sqlmock.NewRows([]string{"tenant_id", "external_id"}).
+						AddRow(1, "x").
+						AddRow(1, "y"))
+				mock.ExpectExec(escape("DELETE FROM `account_groups` WHERE ((`account_tenant_id` = ? AND `account_external_id` = ?) OR (`account_tenant_id` = ? AND `account_external_id` = ?)) AND `group_id` = ?")).
+					WithArgs(1, "x", 1, "y", 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(escape("INSERT INTO `account_groups` (`account_tenant_id`, `account_external_id`, `group_id`) VALUES (?, ?, ?), (?, ?, ?)")).
+					WithArgs(1, "x", 3, 1, "y", 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantAffected: 2,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2158,6 +2586,272 @@ func TestUpdateNodes(t *testing.T) {
 	}
 }
 
+func TestBatchUpdate(t *testing.T) {
+	newSpec := func(id int, fields FieldMut) *UpdateSpec {
+		return &UpdateSpec{
+			Node: &NodeSpec{
+				Table:   "users",
+				Columns: []string{"id", "age", "name"},
+				ID:      &FieldSpec{Column: "id", Type: field.TypeInt, Value: id},
+			},
+			Fields: fields,
+		}
+	}
+	specs := func() []*UpdateSpec {
+		return []*UpdateSpec{
+			newSpec(1, FieldMut{
+				Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "a8m"}},
+				Add: []*FieldSpec{{Column: "age", Type: field.TypeInt, Value: 1}},
+			}),
+			newSpec(2, FieldMut{
+				Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "nati"}},
+				Add: []*FieldSpec{{Column: "age", Type: field.TypeInt, Value: 2}},
+			}),
+			newSpec(3, FieldMut{
+				Clear: []*FieldSpec{{Column: "age", Type: field.TypeInt}},
+			}),
+		}
+	}
+	tests := []struct {
+		name    string
+		dialect string
+		prepare func(sqlmock.Sqlmock)
+	}{
+		{
+			name:    "sqlite",
+			dialect: dialect.SQLite,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(escape("UPDATE `users` SET `name` = CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `name` END, `age` = CASE `id` WHEN ? THEN `age` + ? WHEN ? THEN `age` + ? ELSE `age` END WHERE `id` IN (?, ?)")).
+					WithArgs(1, "a8m", 2, "nati", 1, 1, 2, 2, 1, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(escape("UPDATE `users` SET `age` = NULL WHERE `id` IN (?)")).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(escape("SELECT `id`, `age`, `name` FROM `users` WHERE `id` IN (?, ?, ?)")).
+					WithArgs(1, 2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "age", "name"}).
+						AddRow(2, 32, "nati").
+						AddRow(1, 31, "a8m").
+						AddRow(3, nil, "ariel"))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "postgres",
+			dialect: dialect.Postgres,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(escape(`UPDATE "users" SET "name" = "_values"."name", "age" = "users"."age" + "_values"."age" FROM (VALUES ((SELECT "id" FROM "users" LIMIT 0), (SELECT "name" FROM "users" LIMIT 0), (SELECT "age" FROM "users" LIMIT 0)), ($1, $2, $3), ($4, $5, $6)) AS "_values" ("id", "name", "age") WHERE "users"."id" = "_values"."id"`)).
+					WithArgs(1, "a8m", 1, 2, "nati", 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(escape(`UPDATE "users" SET "age" = NULL FROM (VALUES ((SELECT "id" FROM "users" LIMIT 0)), ($1)) AS "_values" ("id") WHERE "users"."id" = "_values"."id"`)).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(escape(`SELECT "id", "age", "name" FROM "users" WHERE "id" IN ($1, $2, $3)`)).
+					WithArgs(1, 2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "age", "name"}).
+						AddRow(1, 31, "a8m").
+						AddRow(2, 32, "nati").
+						AddRow(3, nil, "ariel"))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "mysql",
+			dialect: dialect.MySQL,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(escape("UPDATE `users` JOIN (SELECT ? AS `id`, ? AS `name`, ? AS `age` UNION ALL SELECT ?, ?, ?) AS `_values` ON `users`.`id` = `_values`.`id` SET `users`.`name` = `_values`.`name`, `users`.`age` = `users`.`age` + `_values`.`age`")).
+					WithArgs(1, "a8m", 1, 2, "nati", 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(escape("UPDATE `users` JOIN (SELECT ? AS `id`) AS `_values` ON `users`.`id` = `_values`.`id` SET `users`.`age` = NULL")).
+					WithArgs(3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(escape("SELECT `id`, `age`, `name` FROM `users` WHERE `id` IN (?, ?, ?)")).
+					WithArgs(1, 2, 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "age", "name"}).
+						AddRow(1, 31, "a8m").
+						AddRow(2, 32, "nati").
+						AddRow(3, nil, "ariel"))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tt.prepare(mock)
+			var (
+				nodes = specs()
+				users = make([]*user, len(nodes))
+			)
+			for i, n := range nodes {
+				users[i] = &user{}
+				n.ScanValues, n.Assign = users[i].values, users[i].assign
+			}
+			err = BatchUpdate(context.Background(), sql.OpenDB(tt.dialect, db), &BatchUpdateSpec{Nodes: nodes})
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+			require.Equal(t, &user{id: 1, age: 31, name: "a8m"}, users[0])
+			require.Equal(t, &user{id: 2, age: 32, name: "nati"}, users[1])
+			require.Equal(t, &user{id: 3, name: "ariel"}, users[2])
+		})
+	}
+
+	t.Run("batch size", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `users` SET `name` = CASE `id` WHEN ? THEN ? ELSE `name` END WHERE `id` IN (?)")).
+			WithArgs(1, "a8m", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(escape("UPDATE `users` SET `name` = CASE `id` WHEN ? THEN ? ELSE `name` END WHERE `id` IN (?)")).
+			WithArgs(2, "nati", 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		err = BatchUpdate(context.Background(), sql.OpenDB(dialect.SQLite, db), &BatchUpdateSpec{
+			Nodes: []*UpdateSpec{
+				newSpec(1, FieldMut{Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "a8m"}}}),
+				newSpec(2, FieldMut{Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "nati"}}}),
+			},
+			BatchSize: 1,
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("UPDATE `users` SET `age` = NULL WHERE `id` IN (?)")).
+			WithArgs(3).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(escape("SELECT `id`, `age`, `name` FROM `users` WHERE `id` = ?")).
+			WithArgs(3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "age", "name"}))
+		mock.ExpectRollback()
+		spec := newSpec(3, FieldMut{Clear: []*FieldSpec{{Column: "age", Type: field.TypeInt}}})
+		u := &user{}
+		spec.ScanValues, spec.Assign = u.values, u.assign
+		err = BatchUpdate(context.Background(), sql.OpenDB(dialect.SQLite, db), &BatchUpdateSpec{Nodes: []*UpdateSpec{spec}})
+		require.True(t, errors.As(err, new(*NotFoundError)), err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	versioned := func(id, version int, name string) *UpdateSpec {
+		spec := newSpec(id, FieldMut{
+			Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: name}},
+			Add: []*FieldSpec{{Column: "version", Type: field.TypeInt, Value: 1}},
+		})
+		spec.Version = &FieldSpec{Column: "version", Type: field.TypeInt, Value: version}
+		return spec
+	}
+	for _, tt := range []struct {
+		dialect string
+		prepare func(sqlmock.Sqlmock)
+	}{
+		{
+			dialect: dialect.SQLite,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(escape("SELECT `id` FROM `users` WHERE (`id` = ? AND `version` = ?) OR (`id` = ? AND `version` = ?)")).
+					WithArgs(1, 3, 2, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectExec(escape("UPDATE `users` SET `name` = CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `name` END, `version` = CASE `id` WHEN ? THEN `version` + ? WHEN ? THEN `version` + ? ELSE `version` END WHERE (`id` = ? AND `version` = ?) OR (`id` = ? AND `version` = ?)")).
+					WithArgs(1, "a8m", 2, "nati", 1, 1, 2, 1, 1, 3, 2, 5).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			dialect: dialect.Postgres,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(escape(`SELECT "id" FROM "users" WHERE ("id" = $1 AND "version" = $2) OR ("id" = $3 AND "version" = $4) FOR UPDATE`)).
+					WithArgs(1, 3, 2, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectExec(escape(`UPDATE "users" SET "name" = "_values"."name", "version" = "users"."version" + "_values"."version" FROM (VALUES ((SELECT "id" FROM "users" LIMIT 0), (SELECT "name" FROM "users" LIMIT 0), (SELECT "version" FROM "users" LIMIT 0), (SELECT "version" FROM "users" LIMIT 0)), ($1, $2, $3, $4), ($5, $6, $7, $8)) AS "_values" ("id", "name", "version", "_version") WHERE "users"."id" = "_values"."id" AND "users"."version" = "_values"."_version"`)).
+					WithArgs(1, "a8m", 1, 3, 2, "nati", 1, 5).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			dialect: dialect.MySQL,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(escape("SELECT `id` FROM `users` WHERE (`id` = ? AND `version` = ?) OR (`id` = ? AND `version` = ?) FOR UPDATE")).
+					WithArgs(1, 3, 2, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectExec(escape("UPDATE `users` JOIN (SELECT ? AS `id`, ? AS `name`, ? AS `version`, ? AS `_version` UNION ALL SELECT ?, ?, ?, ?) AS `_values` ON `users`.`id` = `_values`.`id` AND `users`.`version` = `_values`.`_version` SET `users`.`name` = `_values`.`name`, `users`.`version` = `users`.`version` + `_values`.`version`")).
+					WithArgs(1, "a8m", 1, 3, 2, "nati", 1, 5).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	} {
+		t.Run("versioned/"+tt.dialect, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			mock.ExpectBegin()
+			tt.prepare(mock)
+			mock.ExpectCommit()
+			err = BatchUpdate(context.Background(), sql.OpenDB(tt.dialect, db), &BatchUpdateSpec{
+				Nodes: []*UpdateSpec{versioned(1, 3, "a8m"), versioned(2, 5, "nati")},
+			})
+			require.NoError(t, err)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("versioned/conflict", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectQuery(escape("SELECT `id` FROM `users` WHERE (`id` = ? AND `version` = ?) OR (`id` = ? AND `version` = ?) OR (`id` = ? AND `version` = ?)")).
+			WithArgs(1, 3, 2, 5, 3, 7).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+		mock.ExpectQuery(escape("SELECT `id` FROM `users` WHERE `id` IN (?, ?)")).
+			WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(3))
+		mock.ExpectRollback()
+		nodes := []*UpdateSpec{versioned(1, 3, "a8m"), versioned(2, 5, "nati"), versioned(3, 7, "ariel")}
+		err = BatchUpdate(context.Background(), sql.OpenDB(dialect.SQLite, db), &BatchUpdateSpec{Nodes: nodes})
+		require.True(t, IsConflictError(err))
+		require.EqualError(t, err, "records with ids [1 3] in table users were modified concurrently")
+		var e *ConflictError
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, []*NodeSpec{nodes[0].Node, nodes[2].Node}, e.Nodes())
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("versioned/not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectQuery(escape("SELECT `id` FROM `users` WHERE `id` = ? AND `version` = ?")).
+			WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery(escape("SELECT `id` FROM `users` WHERE `id` = ?")).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectRollback()
+		err = BatchUpdate(context.Background(), sql.OpenDB(dialect.SQLite, db), &BatchUpdateSpec{
+			Nodes: []*UpdateSpec{versioned(1, 3, "a8m")},
+		})
+		require.True(t, errors.As(err, new(*NotFoundError)), err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("predicate", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		spec := newSpec(1, FieldMut{Set: []*FieldSpec{{Column: "name", Type: field.TypeString, Value: "a8m"}}})
+		spec.Predicate = func(s *sql.Selector) { s.Where(sql.EQ("name", "nati")) }
+		err = BatchUpdate(context.Background(), sql.OpenDB(dialect.SQLite, db), &BatchUpdateSpec{Nodes: []*UpdateSpec{spec}})
+		require.Error(t, err)
+	})
+}
+
 func TestDeleteNodes(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -2258,6 +2952,55 @@ func TestQueryNodes(t *testing.T) {
 	require.Equal(t, 3, n)
 }
 
+func TestIterateNodes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery(escape("SELECT `users`.`id`, `users`.`age`, `users`.`name` FROM `users` WHERE `age` < ? ORDER BY `id`")).
+		WithArgs(40).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "age", "name"}).
+			AddRow(1, 10, "a8m").
+			AddRow(2, 20, "nati").
+			AddRow(3, 30, "ariel")).
+		RowsWillBeClosed()
+
+	var (
+		users []*user
+		spec  = &QuerySpec{
+			Node: &NodeSpec{
+				Table:   "users",
+				Columns: []string{"id", "age", "name"},
+				ID:      &FieldSpec{Column: "id", Type: field.TypeInt},
+			},
+			Order: func(s *sql.Selector) {
+				s.OrderBy("id")
+			},
+			Predicate: func(s *sql.Selector) {
+				s.Where(sql.LT("age", 40))
+			},
+			ScanValues: func(columns []string) ([]any, error) {
+				u := &user{}
+				users = append(users, u)
+				return u.values(columns)
+			},
+			Assign: func(columns []string, values []any) error {
+				return users[len(users)-1].assign(columns, values)
+			},
+		}
+	)
+	iter, err := IterateNodes(context.Background(), sql.OpenDB("", db), spec)
+	require.NoError(t, err)
+	require.Empty(t, users, "rows are scanned lazily")
+	require.True(t, iter.Next())
+	require.Equal(t, []*user{{id: 1, age: 10, name: "a8m"}}, users)
+	require.True(t, iter.Next())
+	require.True(t, iter.Next())
+	require.Equal(t, &user{id: 3, age: 30, name: "ariel"}, users[2])
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+	require.NoError(t, iter.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestQueryNodesSchema(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -2305,6 +3048,71 @@ func TestQueryNodesSchema(t *testing.T) {
 	require.Equal(t, &user{id: 3, age: 30, name: "a8m", edges: struct{ fk1, fk2 int }{1, 1}}, users[2])
 }
 
+func TestLimitNeighbors(t *testing.T) {
+	tests := []struct {
+		name        string
+		selector    func() *sql.Selector
+		partitionBy string
+		limit       int
+		offset      int
+		wantQuery   string
+		wantArgs    []any
+	}{
+		{
+			name: "O2M/limit",
+			selector: func() *sql.Selector {
+				t1 := sql.Table("pets")
+				return sql.Select(t1.C("id"), t1.C("owner_id")).
+					From(t1).
+					Where(sql.InValues(t1.C("owner_id"), 1, 2)).
+					OrderBy(sql.Desc(t1.C("id")))
+			},
+			partitionBy: "owner_id",
+			limit:       2,
+			wantQuery:   "SELECT `id`, `owner_id` FROM (SELECT `pets`.`id`, `pets`.`owner_id`, (ROW_NUMBER() OVER (PARTITION BY `owner_id` ORDER BY `pets`.`id` DESC)) AS `row_number` FROM `pets` WHERE `pets`.`owner_id` IN (?, ?)) AS `pets` WHERE `pets`.`row_number` <= ? ORDER BY `pets`.`row_number`",
+			wantArgs:    []any{1, 2, 2},
+		},
+		{
+			name: "O2M/offset",
+			selector: func() *sql.Selector {
+				t1 := sql.Table("pets")
+				return sql.Select(t1.C("id"), t1.C("owner_id")).From(t1)
+			},
+			partitionBy: "owner_id",
+			offset:      3,
+			wantQuery:   "SELECT `id`, `owner_id` FROM (SELECT `pets`.`id`, `pets`.`owner_id`, (ROW_NUMBER() OVER (PARTITION BY `owner_id`)) AS `row_number` FROM `pets`) AS `pets` WHERE `pets`.`row_number` > ? ORDER BY `pets`.`row_number`",
+			wantArgs:    []any{3},
+		},
+		{
+			name: "M2M/limit-offset",
+			selector: func() *sql.Selector {
+				b := sql.Dialect(dialect.Postgres)
+				t1, t2 := b.Table("groups"), b.Table("user_groups").As("t1")
+				return b.Select(t2.C("user_id"), t1.C("id"), t1.C("name")).
+					From(t1).
+					Join(t2).
+					On(t1.C("id"), t2.C("group_id")).
+					Where(sql.InValues(t2.C("user_id"), 1, 2)).
+					OrderBy(t1.C("name"))
+			},
+			partitionBy: `"t1"."user_id"`,
+			limit:       2,
+			offset:      1,
+			wantQuery:   `SELECT "user_id", "id", "name" FROM (SELECT "t1"."user_id", "groups"."id", "groups"."name", (ROW_NUMBER() OVER (PARTITION BY "t1"."user_id" ORDER BY "groups"."name")) AS "row_number" FROM "groups" JOIN "user_groups" AS "t1" ON "groups"."id" = "t1"."group_id" WHERE "t1"."user_id" IN ($1, $2)) AS "groups" WHERE "groups"."row_number" > $3 AND "groups"."row_number" <= $4 ORDER BY "groups"."row_number"`,
+			wantArgs:    []any{1, 2, 1, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.selector()
+			LimitNeighbors(tt.partitionBy, tt.limit, tt.offset)(s)
+			query, args := s.Query()
+			require.Equal(t, tt.wantQuery, query)
+			require.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
 func TestQueryEdges(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -2342,6 +3150,50 @@ func TestQueryEdges(t *testing.T) {
 	require.Equal(t, [][]int64{{4, 5}, {4, 6}}, edges)
 }
 
+func TestAggregateNeighbors(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery(escape("SELECT `pets`.`owner_id`, COUNT(*), SUM(`pets`.`age`) FROM `pets` WHERE `pets`.`age` > ? AND `pets`.`owner_id` IN (?, ?) GROUP BY `pets`.`owner_id`")).
+		WithArgs(1, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"owner_id", "COUNT", "SUM"}).
+			AddRow(1, 2, 5).
+			AddRow(2, 1, nil))
+
+	var (
+		results = make(map[int64][]float64)
+		t1      = sql.Table("pets")
+		from    = sql.Select(t1.Columns("id", "age", "owner_id")...).
+			From(t1).
+			Where(sql.GT(t1.C("age"), 1)).
+			OrderBy(t1.C("id"))
+		spec = &AggregateSpec{
+			From:   from,
+			Column: t1.C("owner_id"),
+			Aggregates: []func(*sql.Selector) string{
+				func(*sql.Selector) string { return sql.Count("*") },
+				func(s *sql.Selector) string { return sql.Sum(s.C("age")) },
+			},
+			ScanValue: func() any {
+				return &sql.NullInt64{}
+			},
+			Assign: func(id any, values []sql.NullFloat64) error {
+				for _, v := range values {
+					results[id.(*sql.NullInt64).Int64] = append(results[id.(*sql.NullInt64).Int64], v.Float64)
+				}
+				return nil
+			},
+		}
+	)
+	from.Where(sql.InValues(spec.Column, 1, 2))
+	err = AggregateNeighbors(context.Background(), sql.OpenDB("", db), spec)
+	require.NoError(t, err)
+	require.Equal(t, map[int64][]float64{1: {2, 5}, 2: {1, 0}}, results)
+
+	spec.Aggregates = nil
+	err = AggregateNeighbors(context.Background(), sql.OpenDB("", db), spec)
+	require.Error(t, err)
+}
+
 func TestQueryEdgesSchema(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
@@ -2465,6 +3317,57 @@ func TestIsConstraintError(t *testing.T) {
 	}
 }
 
+func TestIsConstraintErrorKind(t *testing.T) {
+	for _, tt := range []struct {
+		err                        error
+		unique, fk, notNull, check bool
+	}{
+		{err: errors.New("NOT NULL constraint failed: users.name"), notNull: true},
+		{err: errors.New(`pq: null value in column "name" of relation "users" violates not-null constraint`), notNull: true},
+		{err: errors.New("Error 1048 (23000): Column 'name' cannot be null"), notNull: true},
+		{err: errors.New("CHECK constraint failed: age > 0"), check: true},
+		{err: errors.New(`pq: new row for relation "users" violates check constraint "users_age_check"`), check: true},
+		{err: &sql.Error{Kind: sql.KindUnique, Err: errors.New("unique")}, unique: true},
+		{err: &ConstraintError{msg: "edge", Kind: sql.KindUnique}, unique: true},
+		{err: &ConstraintError{msg: "edge"}},
+	} {
+		require.True(t, IsConstraintError(tt.err), tt.err)
+		require.Equal(t, tt.unique, IsUniqueConstraintError(tt.err), tt.err)
+		require.Equal(t, tt.fk, IsForeignKeyConstraintError(tt.err), tt.err)
+		require.Equal(t, tt.notNull, IsNotNullConstraintError(tt.err), tt.err)
+		require.Equal(t, tt.check, IsCheckConstraintError(tt.err), tt.err)
+	}
+	require.False(t, IsConstraintError(errors.New("syntax error")))
+}
+
+type sqlStateError string
+
+func (e sqlStateError) Error() string {
+	return "ERROR: canceled statement (SQLSTATE " + string(e) + ")"
+}
+
+func (e sqlStateError) SQLState() string { return string(e) }
+
+func TestIsRetryableError(t *testing.T) {
+	for _, err := range []error{
+		errors.New("Error 1213 (40001): Deadlock found when trying to get lock; try restarting transaction"),
+		errors.New("pq: could not serialize access due to concurrent update"),
+		errors.New("pq: deadlock detected"),
+		errors.New("database is locked"),
+		fmt.Errorf("update node: %w", sqlStateError("40001")),
+		sqlStateError("40P01"),
+	} {
+		require.True(t, IsRetryableError(err), err.Error())
+	}
+	for _, err := range []error{
+		errors.New("Error 1062: Duplicate entry 'a8m' for key 'name'"),
+		errors.New(`pq: duplicate key value violates unique constraint "users_name_key"`),
+		sqlStateError("23505"),
+	} {
+		require.False(t, IsRetryableError(err), err.Error())
+	}
+}
+
 func escape(query string) string {
 	rows := strings.Split(query, "\n")
 	for i := range rows {
@@ -2473,3 +3376,52 @@ func escape(query string) string {
 	query = strings.Join(rows, " ")
 	return strings.TrimSpace(regexp.QuoteMeta(query)) + "$"
 }
+
+func TestShortestPath(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	query := "SELECT `user_friends`.`user_id`, `user_friends`.`friend_id` FROM `user_friends` WHERE `user_friends`.`user_id` IN (%s) AND `user_friends`.`friend_id` IS NOT NULL"
+	// 1 -> 2, 1 -> 3, 2 -> 1, 2 -> 4, 3 -> 4.
+	level := func(args ...driver.Value) *sqlmock.ExpectedQuery {
+		return mock.ExpectQuery(escape(fmt.Sprintf(query, strings.Repeat(", ?", len(args))[2:]))).WithArgs(args...)
+	}
+	level(1).WillReturnRows(sqlmock.NewRows([]string{"user_id", "friend_id"}).AddRow(1, 2).AddRow(1, 3))
+	level(2, 3).WillReturnRows(sqlmock.NewRows([]string{"user_id", "friend_id"}).AddRow(2, 1).AddRow(2, 4).AddRow(3, 4))
+	// Depth is reached before visiting the neighbors of node 4.
+	level(1).WillReturnRows(sqlmock.NewRows([]string{"user_id", "friend_id"}).AddRow(1, 2).AddRow(1, 3))
+	level(2, 3).WillReturnRows(sqlmock.NewRows([]string{"user_id", "friend_id"}).AddRow(2, 1).AddRow(2, 4).AddRow(3, 4))
+	level(1).WillReturnRows(sqlmock.NewRows([]string{"user_id", "friend_id"}).AddRow(1, 2).AddRow(1, 3))
+	level(2, 3).WillReturnRows(sqlmock.NewRows([]string{"user_id", "friend_id"}).AddRow(2, 1).AddRow(2, 4).AddRow(3, 4))
+
+	drv := sql.OpenDB("", db)
+	spec := &PathSpec{
+		Step: NewStep(
+			From("users", "id", 1),
+			To("users", "id"),
+			Edge(M2M, false, "user_friends", "user_id", "friend_id"),
+		),
+		Target: 4,
+		Depth:  2,
+	}
+	path, err := ShortestPath(context.Background(), drv, spec)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "4"}, path)
+	spec.Target = 5
+	path, err = ShortestPath(context.Background(), drv, spec)
+	require.NoError(t, err)
+	require.Nil(t, path)
+	spec.Target = 4
+	ok, err := Reachable(context.Background(), drv, spec)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// A node is reachable from itself without executing any query.
+	spec.Target = 1
+	path, err = ShortestPath(context.Background(), drv, spec)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, path)
+	ok, err = Reachable(context.Background(), drv, spec)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,286 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+)
+
+// StreamCreateSpec holds the information for streaming
+// a large number of nodes into their table.
+type StreamCreateSpec struct {
+	// Next returns the next node to be inserted,
+	// or nil if there are no more nodes.
+	Next func() (*CreateSpec, error)
+
+	// BatchSize is the maximum number of nodes that are inserted by each
+	// INSERT statement, when the nodes are not copied. The default is 1000,
+	// and it is further limited by the maximum number of arguments of the
+	// database.
+	BatchSize int
+
+	// CopyFrom indicates if the nodes are copied into their table using the
+	// COPY FROM STDIN statement in PostgreSQL. It requires a database/sql
+	// driver that supports COPY using prepared statements (e.g. lib/pq),
+	// and it is ignored by other dialects.
+	CopyFrom bool
+}
+
+// StreamCreate inserts the nodes that are returned by spec.Next in a single transaction,
+// without holding all of them in memory, and returns the number of inserted nodes. The
+// nodes are inserted using multi-row INSERT statements of spec.BatchSize rows, or using
+// the COPY FROM STDIN statement if spec.CopyFrom is set.
+//
+// A new statement is started whenever a node sets different columns than the node before it.
+// Hence, columns that are not set by a node get their default value in the database. The IDs
+// of the nodes are not returned, and edges that are stored in other tables (e.g. M2M) are not
+// supported.
+func StreamCreate(ctx context.Context, drv dialect.Driver, spec *StreamCreateSpec) (int, error) {
+	first, err := spec.Next()
+	if err != nil || first == nil {
+		return 0, err
+	}
+	tx, err := drv.Tx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	s := &streamer{
+		StreamCreateSpec: spec,
+		dialect:          drv.Dialect(),
+		table:            first.Table,
+		schema:           first.Schema,
+		index:            make(map[string]int),
+	}
+	var n int
+	switch {
+	case !spec.CopyFrom || s.dialect != dialect.Postgres:
+		n, err = s.insert(ctx, tx, first)
+	default:
+		var p preparer
+		if p, err = copyIn(tx); err == nil {
+			n, err = s.copy(ctx, p, first)
+		}
+	}
+	if err != nil {
+		return 0, rollback(tx, err)
+	}
+	return n, tx.Commit()
+}
+
+type streamer struct {
+	*StreamCreateSpec
+	dialect       string
+	table, schema string
+	// The columns of the current statement, and their positions.
+	columns []string
+	index   map[string]int
+}
+
+// values calls fn for each of the column values of the given node.
+func (s *streamer) values(n *CreateSpec, fn func(string, driver.Value)) error {
+	if n.Table != s.table {
+		return fmt.Errorf("sqlgraph: more than 1 table for stream insert: %q != %q", n.Table, s.table)
+	}
+	for _, e := range n.Edges {
+		if isExternalEdge(e) {
+			return fmt.Errorf("sqlgraph: stream insert does not support edges that are stored in table %q", e.Table)
+		}
+	}
+	if n.ID != nil && n.ID.Value != nil {
+		fn(n.ID.Column, n.ID.Value)
+	}
+	return setTableColumns(n.Fields, EdgeSpecs(n.Edges).GroupRel(), fn)
+}
+
+// next returns the values of the given node, and reports if its
+// columns are different from the columns of the current statement.
+func (s *streamer) next(n *CreateSpec) (map[string]driver.Value, bool, error) {
+	values := make(map[string]driver.Value)
+	if err := s.values(n, func(column string, value driver.Value) {
+		values[column] = value
+	}); err != nil {
+		return nil, false, err
+	}
+	changed := len(values) != len(s.columns)
+	for c := range values {
+		if _, ok := s.index[c]; !ok {
+			changed = true
+		}
+	}
+	return values, changed, nil
+}
+
+// reset sets the columns of the current statement to the columns of the given values.
+func (s *streamer) reset(values map[string]driver.Value) {
+	s.columns = s.columns[:0]
+	for c := range values {
+		s.columns = append(s.columns, c)
+	}
+	sort.Strings(s.columns)
+	s.index = make(map[string]int, len(s.columns))
+	for i, c := range s.columns {
+		s.index[c] = i
+	}
+}
+
+// row returns the given values, ordered by the columns of the stream.
+func (s *streamer) row(values map[string]driver.Value) []any {
+	row := make([]any, len(s.columns))
+	for c, v := range values {
+		row[s.index[c]] = v
+	}
+	return row
+}
+
+// insert inserts the nodes using multi-row INSERT statements.
+func (s *streamer) insert(ctx context.Context, tx dialect.ExecQuerier, first *CreateSpec) (int, error) {
+	var (
+		n    int
+		rows [][]any
+	)
+	flush := func() error {
+		if len(rows) == 0 {
+			return nil
+		}
+		insert := sql.Dialect(s.dialect).Insert(s.table).Schema(s.schema).Default().Columns(s.columns...)
+		for _, vs := range rows {
+			insert.Values(vs...)
+		}
+		query, args, err := insert.QueryErr()
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(ctx, query, args, nil); err != nil {
+			return fmt.Errorf("insert nodes to table %q: %w", s.table, err)
+		}
+		n, rows = n+len(rows), rows[:0]
+		return nil
+	}
+	for node := first; node != nil; {
+		values, changed, err := s.next(node)
+		if err != nil {
+			return 0, err
+		}
+		if changed {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+			s.reset(values)
+		}
+		if rows = append(rows, s.row(values)); len(rows) >= s.batchSize() {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+		}
+		if node, err = s.Next(); err != nil {
+			return 0, err
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// batchSize returns the number of rows that are inserted by each INSERT statement.
+func (s *streamer) batchSize() int {
+	size := s.BatchSize
+	if size <= 0 {
+		size = 1000
+	}
+	if n := maxRows(s.dialect, len(s.columns)); n < size {
+		size = n
+	}
+	return size
+}
+
+// copy copies the nodes into their table using the COPY FROM STDIN statement. The
+// statement is prepared on the transaction, and each of its executions sends one row.
+func (s *streamer) copy(ctx context.Context, p preparer, first *CreateSpec) (int, error) {
+	var (
+		n    int
+		stmt *stdsql.Stmt
+	)
+	// An execution without arguments flushes the copied rows.
+	flush := func() error {
+		if stmt == nil {
+			return nil
+		}
+		_, err := stmt.ExecContext(ctx)
+		stmt.Close()
+		if stmt = nil; err != nil {
+			return fmt.Errorf("copy nodes to table %q: %w", s.table, err)
+		}
+		return nil
+	}
+	defer func() {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}()
+	for node := first; node != nil; n++ {
+		values, changed, err := s.next(node)
+		if err != nil {
+			return 0, err
+		}
+		if changed || stmt == nil {
+			if err := flush(); err != nil {
+				return 0, err
+			}
+			s.reset(values)
+			if stmt, err = p.PrepareContext(ctx, s.copyQuery()); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := stmt.ExecContext(ctx, s.row(values)...); err != nil {
+			return 0, fmt.Errorf("copy nodes to table %q: %w", s.table, err)
+		}
+		if node, err = s.Next(); err != nil {
+			return 0, err
+		}
+	}
+	if err := flush(); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// copyQuery returns the COPY FROM STDIN statement for the columns of the stream.
+func (s *streamer) copyQuery() string {
+	b := sql.Dialect(s.dialect).Insert(s.table)
+	table := b.Quote(s.table)
+	if s.schema != "" {
+		table = b.Quote(s.schema) + "." + table
+	}
+	columns := make([]string, len(s.columns))
+	for i, c := range s.columns {
+		columns[i] = b.Quote(c)
+	}
+	return fmt.Sprintf("COPY %s (%s) FROM STDIN", table, strings.Join(columns, ", "))
+}
+
+// preparer is implemented by database/sql transactions.
+type preparer interface {
+	PrepareContext(context.Context, string) (*stdsql.Stmt, error)
+}
+
+// copyIn returns the preparer of the given transaction, that is
+// used for executing the COPY FROM STDIN statements.
+func copyIn(tx dialect.Tx) (preparer, error) {
+	if t, ok := tx.(*sql.Tx); ok {
+		if p, ok := t.ExecQuerier.(preparer); ok {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("sqlgraph: transaction %T does not support COPY FROM STDIN", tx)
+}
@@ -0,0 +1,151 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlgraph
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/schema/field"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamCreate(t *testing.T) {
+	ctx := context.Background()
+	newNode := func(name string, fields ...*FieldSpec) *CreateSpec {
+		return &CreateSpec{
+			Table:  "users",
+			ID:     &FieldSpec{Column: "id", Type: field.TypeInt},
+			Fields: append([]*FieldSpec{{Column: "name", Type: field.TypeString, Value: name}}, fields...),
+		}
+	}
+	iter := func(nodes ...*CreateSpec) func() (*CreateSpec, error) {
+		return func() (*CreateSpec, error) {
+			if len(nodes) == 0 {
+				return nil, nil
+			}
+			n := nodes[0]
+			nodes = nodes[1:]
+			return n, nil
+		}
+	}
+
+	t.Run("MySQL", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("INSERT INTO `users` (`age`, `name`) VALUES (?, ?), (?, ?)")).
+			WithArgs(30, "a8m", 1, "nati").
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		mock.ExpectExec(escape("INSERT INTO `users` (`age`, `name`) VALUES (?, ?)")).
+			WithArgs(2, "ariel").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+		n, err := StreamCreate(ctx, sql.OpenDB(dialect.MySQL, db), &StreamCreateSpec{
+			Next: iter(
+				newNode("a8m", &FieldSpec{Column: "age", Type: field.TypeInt, Value: 30}),
+				newNode("nati", &FieldSpec{Column: "age", Type: field.TypeInt, Value: 1}),
+				newNode("ariel", &FieldSpec{Column: "age", Type: field.TypeInt, Value: 2}),
+			),
+			BatchSize: 2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Postgres", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		// Without the CopyFrom option, the nodes are inserted in batches.
+		mock.ExpectBegin()
+		mock.ExpectExec(escape(`INSERT INTO "users" ("name") VALUES ($1), ($2)`)).
+			WithArgs("a8m", "nati").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+		n, err := StreamCreate(ctx, sql.OpenDB(dialect.Postgres, db), &StreamCreateSpec{
+			Next: iter(newNode("a8m"), newNode("nati")),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CopyFrom", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		copied := mock.ExpectPrepare(escape(`COPY "users" ("name") FROM STDIN`))
+		copied.ExpectExec().WithArgs("a8m").WillReturnResult(sqlmock.NewResult(0, 0))
+		copied.ExpectExec().WithArgs("nati").WillReturnResult(sqlmock.NewResult(0, 0))
+		copied.ExpectExec().WithArgs().WillReturnResult(sqlmock.NewResult(0, 0))
+		copied.WillBeClosed()
+		mock.ExpectCommit()
+		// COPY is used also when the statements of the driver are cached.
+		drv := sql.OpenDB(dialect.Postgres, db, sql.StatementCache(8))
+		n, err := StreamCreate(ctx, drv, &StreamCreateSpec{
+			Next:     iter(newNode("a8m"), newNode("nati")),
+			CopyFrom: true,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		n, err := StreamCreate(ctx, sql.OpenDB(dialect.SQLite, db), &StreamCreateSpec{Next: iter()})
+		require.NoError(t, err)
+		require.Zero(t, n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NewColumn", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		mock.ExpectExec(escape("INSERT INTO `users` (`name`) VALUES (?)")).
+			WithArgs("a8m").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		// Columns that are not set are omitted, and get their default values.
+		mock.ExpectExec(escape("INSERT INTO `users` (`age`, `name`) VALUES (?, ?)")).
+			WithArgs(30, "nati").
+			WillReturnResult(sqlmock.NewResult(2, 1))
+		mock.ExpectExec(escape("INSERT INTO `users` (`name`) VALUES (?)")).
+			WithArgs("ariel").
+			WillReturnResult(sqlmock.NewResult(3, 1))
+		mock.ExpectCommit()
+		n, err := StreamCreate(ctx, sql.OpenDB(dialect.SQLite, db), &StreamCreateSpec{
+			Next: iter(
+				newNode("a8m"),
+				newNode("nati", &FieldSpec{Column: "age", Type: field.TypeInt, Value: 30}),
+				newNode("ariel"),
+			),
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ExternalEdge", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		node := newNode("a8m")
+		node.Edges = []*EdgeSpec{
+			{Rel: M2M, Table: "user_groups", Columns: []string{"user_id", "group_id"}, Target: &EdgeTarget{Nodes: []driver.Value{1}}},
+		}
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+		_, err = StreamCreate(ctx, sql.OpenDB(dialect.SQLite, db), &StreamCreateSpec{Next: iter(node)})
+		require.EqualError(t, err, `sqlgraph: stream insert does not support edges that are stored in table "user_groups"`)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,452 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package sqlshard provides a dialect.Driver that routes the statements of a client to one of several
+// databases with identical schemas (shards), by a shard key that is attached to the context of the
+// statement, or that is read from a designated field of the mutations.
+//
+//	drv, err := sqlshard.NewDriver(map[string]dialect.Driver{
+//		"eu": euDrv,
+//		"us": usDrv,
+//	}, sqlshard.WithRouter(func(key any) (string, error) {
+//		return regionOf(key.(int))
+//	}))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := ent.NewClient(ent.Driver(drv))
+//
+//	// In an HTTP middleware.
+//	ctx := sqlshard.WithKey(r.Context(), tenantID)
+//
+// Transactions are pinned to the shard they were started on, queries that need every shard are executed
+// using Gather or Each, and migrations are run on all shards using Each. For example:
+//
+//	err := drv.Each(ctx, func(ctx context.Context) error {
+//		return client.Schema.Create(ctx)
+//	})
+package sqlshard
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect"
+	"golang.org/x/sync/errgroup"
+)
+
+// ErrNoShard is returned when a statement is executed without a shard
+// key, and the driver has no default shard.
+var ErrNoShard = errors.New("sqlshard: missing shard key")
+
+// A Router returns the name of the shard of the given key.
+type Router func(key any) (string, error)
+
+// Driver is a dialect.Driver that routes statements to the driver of their shard.
+type Driver struct {
+	shards  map[string]dialect.Driver
+	names   []string
+	dialect string
+	route   Router
+	def     string
+}
+
+// Option allows configuring the sharded Driver.
+type Option func(*Driver)
+
+// WithRouter sets the function that maps shard keys to shard names. By default,
+// keys are the names of their shards, formatted using fmt.Sprint.
+func WithRouter(r Router) Option {
+	return func(d *Driver) {
+		d.route = r
+	}
+}
+
+// DefaultShard sets the shard of the statements that are executed without a shard key, such as
+// the statements of global tables. By default, such statements fail with ErrNoShard.
+func DefaultShard(name string) Option {
+	return func(d *Driver) {
+		d.def = name
+	}
+}
+
+// NewDriver returns a new Driver that routes statements to the given shards. All shards must use the same dialect.
+func NewDriver(shards map[string]dialect.Driver, opts ...Option) (*Driver, error) {
+	if len(shards) == 0 {
+		return nil, errors.New("sqlshard: no shards were provided")
+	}
+	d := &Driver{shards: shards, names: make([]string, 0, len(shards))}
+	for name := range shards {
+		d.names = append(d.names, name)
+	}
+	sort.Strings(d.names)
+	for _, name := range d.names {
+		switch dia := shards[name].Dialect(); {
+		case d.dialect == "":
+			d.dialect = dia
+		case d.dialect != dia:
+			return nil, fmt.Errorf("sqlshard: shard %q uses dialect %q, but other shards use %q", name, dia, d.dialect)
+		}
+	}
+	d.route = func(key any) (string, error) {
+		return fmt.Sprint(key), nil
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if _, ok := d.shards[d.def]; d.def != "" && !ok {
+		return nil, fmt.Errorf("sqlshard: default shard %q does not exist", d.def)
+	}
+	return d, nil
+}
+
+// Shards returns the names of the shards of the driver, sorted in ascending order.
+func (d *Driver) Shards() []string {
+	return append([]string(nil), d.names...)
+}
+
+// Shard returns the name of the shard of the given context. That is, the shard that was set using
+// WithShard, or the shard of the key that was set using WithKey, or the default shard of the driver.
+func (d *Driver) Shard(ctx context.Context) (string, error) {
+	name, err := d.shard(ctx)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		return "", ErrNoShard
+	}
+	return name, nil
+}
+
+// shard is like Shard, but returns an empty string if the context has no shard.
+func (d *Driver) shard(ctx context.Context) (string, error) {
+	if name, ok := ctx.Value(shardKey{}).(string); ok {
+		if _, ok := d.shards[name]; !ok {
+			return "", fmt.Errorf("sqlshard: shard %q does not exist", name)
+		}
+		return name, nil
+	}
+	key, ok := KeyFromContext(ctx)
+	if !ok {
+		return d.def, nil
+	}
+	name, err := d.route(key)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := d.shards[name]; !ok {
+		return "", fmt.Errorf("sqlshard: shard %q of key %v does not exist", name, key)
+	}
+	return name, nil
+}
+
+// driver returns the driver of the shard of the given context.
+func (d *Driver) driver(ctx context.Context) (dialect.Driver, error) {
+	name, err := d.Shard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return d.shards[name], nil
+}
+
+// Exec executes the statement on the shard of the context.
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	drv, err := d.driver(ctx)
+	if err != nil {
+		return err
+	}
+	return drv.Exec(ctx, query, args, v)
+}
+
+// Query executes the query on the shard of the context.
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	drv, err := d.driver(ctx)
+	if err != nil {
+		return err
+	}
+	return drv.Query(ctx, query, args, v)
+}
+
+// ExecContext calls the ExecContext method of the driver of the shard of the context if it is supported.
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	drv, err := d.driver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ex, ok := drv.(interface {
+		ExecContext(context.Context, string, ...any) (sql.Result, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.ExecContext is not supported")
+	}
+	return ex.ExecContext(ctx, query, args...)
+}
+
+// QueryContext calls the QueryContext method of the driver of the shard of the context if it is supported.
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	drv, err := d.driver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q, ok := drv.(interface {
+		QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.QueryContext is not supported")
+	}
+	return q.QueryContext(ctx, query, args...)
+}
+
+// Tx starts a transaction on the shard of the context. The transaction is pinned to its shard, and
+// fails to execute statements with contexts of other shards.
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	name, err := d.Shard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := d.shards[name].Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d, name: name}, nil
+}
+
+// BeginTx calls the BeginTx method of the driver of the shard of the context if it
+// is supported, and returns a transaction that is pinned to its shard.
+func (d *Driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	name, err := d.Shard(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drv, ok := d.shards[name].(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Driver.BeginTx is not supported")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, drv: d, name: name}, nil
+}
+
+// Close closes the drivers of all shards, and returns the first error that occurred.
+func (d *Driver) Close() error {
+	var err error
+	for _, name := range d.names {
+		if cerr := d.shards[name].Close(); cerr != nil && err == nil {
+			err = fmt.Errorf("sqlshard: closing shard %q: %w", name, cerr)
+		}
+	}
+	return err
+}
+
+// Dialect returns the dialect of the shards.
+func (d *Driver) Dialect() string {
+	return d.dialect
+}
+
+// Each calls fn with a context that is pinned to each of the shards, one after the other, and stops
+// on the first error. It is used to run statements that should be executed on all shards, such as
+// migrations:
+//
+//	err := drv.Each(ctx, func(ctx context.Context) error {
+//		return client.Schema.Create(ctx)
+//	})
+func (d *Driver) Each(ctx context.Context, fn func(context.Context) error) error {
+	for _, name := range d.names {
+		if err := fn(WithShard(ctx, name)); err != nil {
+			return fmt.Errorf("sqlshard: shard %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Gather calls fn concurrently with a context that is pinned to each of the shards of the driver, and
+// merges their results using the given less function. If the result of each shard is ordered by less,
+// the merged result is ordered as well. For example:
+//
+//	users, err := sqlshard.Gather(ctx, drv, func(ctx context.Context) ([]*ent.User, error) {
+//		return client.User.Query().Order(ent.Desc(user.FieldCreatedAt)).Limit(10).All(ctx)
+//	}, func(a, b *ent.User) bool {
+//		return a.CreatedAt.After(b.CreatedAt)
+//	})
+//	// Limits apply per shard, and should be applied again to the merged result.
+//	if len(users) > 10 {
+//		users = users[:10]
+//	}
+//
+// A nil less function concatenates the results in the order of the shards.
+func Gather[T any](ctx context.Context, d *Driver, fn func(context.Context) ([]T, error), less func(a, b T) bool) ([]T, error) {
+	results := make([][]T, len(d.names))
+	g, ctx := errgroup.WithContext(ctx)
+	for i, name := range d.names {
+		i, name := i, name
+		g.Go(func() error {
+			r, err := fn(WithShard(ctx, name))
+			if err != nil {
+				return fmt.Errorf("sqlshard: shard %q: %w", name, err)
+			}
+			results[i] = r
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return merge(results, less), nil
+}
+
+// merge merges the given ordered lists into a single ordered list.
+func merge[T any](lists [][]T, less func(a, b T) bool) []T {
+	var n int
+	for _, l := range lists {
+		n += len(l)
+	}
+	merged := make([]T, 0, n)
+	if less == nil {
+		for _, l := range lists {
+			merged = append(merged, l...)
+		}
+		return merged
+	}
+	// The number of shards is expected to be small, and therefore, the head
+	// of each list is scanned on each step, instead of maintaining a heap.
+	for len(merged) < n {
+		min := -1
+		for i, l := range lists {
+			// Prefer earlier shards on ties, in order to keep the merge stable.
+			if len(l) > 0 && (min == -1 || less(l[0], lists[min][0])) {
+				min = i
+			}
+		}
+		merged = append(merged, lists[min][0])
+		lists[min] = lists[min][1:]
+	}
+	return merged
+}
+
+// Tx is a dialect.Tx that is pinned to a single shard.
+type Tx struct {
+	dialect.Tx
+	drv  *Driver
+	name string
+}
+
+// Exec executes the statement in the transaction, if its context does not belong to another shard.
+func (t *Tx) Exec(ctx context.Context, query string, args, v any) error {
+	if err := t.check(ctx); err != nil {
+		return err
+	}
+	return t.Tx.Exec(ctx, query, args, v)
+}
+
+// Query executes the query in the transaction, if its context does not belong to another shard.
+func (t *Tx) Query(ctx context.Context, query string, args, v any) error {
+	if err := t.check(ctx); err != nil {
+		return err
+	}
+	return t.Tx.Query(ctx, query, args, v)
+}
+
+// Savepoint calls the Savepoint method of the underlying transaction if it is
+// supported, and returns a nested transaction that is pinned to the same shard.
+func (t *Tx) Savepoint(ctx context.Context, name string) (dialect.Tx, error) {
+	sp, ok := t.Tx.(interface {
+		Savepoint(context.Context, string) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("Tx.Savepoint is not supported")
+	}
+	nested, err := sp.Savepoint(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: nested, drv: t.drv, name: t.name}, nil
+}
+
+// Shard returns the name of the shard of the transaction.
+func (t *Tx) Shard() string {
+	return t.name
+}
+
+// check returns an error if the given context belongs to a shard other than the shard of the transaction.
+// Statements without a shard key are executed on the shard of the transaction.
+func (t *Tx) check(ctx context.Context) error {
+	if !hasShard(ctx) {
+		return nil
+	}
+	name, err := t.drv.shard(ctx)
+	if err != nil {
+		return err
+	}
+	if name != t.name {
+		return fmt.Errorf("sqlshard: transaction of shard %q cannot execute statements of shard %q", t.name, name)
+	}
+	return nil
+}
+
+type (
+	// keyKey is the context key for the shard key.
+	keyKey struct{}
+	// shardKey is the context key for the shard name.
+	shardKey struct{}
+)
+
+// WithKey returns a new context that routes the statements that are executed with it
+// to the shard of the given key, as returned by the Router of the driver.
+func WithKey(ctx context.Context, key any) context.Context {
+	return context.WithValue(ctx, keyKey{}, key)
+}
+
+// KeyFromContext returns the shard key that was attached to the context using WithKey, if any.
+func KeyFromContext(ctx context.Context) (any, bool) {
+	key := ctx.Value(keyKey{})
+	return key, key != nil
+}
+
+// WithShard returns a new context that routes the statements that are executed with it to the
+// given shard. Shards that are set using WithShard take precedence over shard keys.
+func WithShard(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, shardKey{}, name)
+}
+
+// hasShard reports if the context holds a shard key or a shard name.
+func hasShard(ctx context.Context) bool {
+	_, ok := ctx.Value(shardKey{}).(string)
+	return ok || ctx.Value(keyKey{}) != nil
+}
+
+// KeyHook returns a hook that routes the mutations of a schema to the shard of the given field, when it is set
+// in the mutation. Mutations with a field value that differs from the shard key of their context fail, in order
+// to prevent writes across shards. Keys are compared by their string representation. For example:
+//
+//	func (Pet) Hooks() []ent.Hook {
+//		return []ent.Hook{
+//			sqlshard.KeyHook(pet.FieldTenantID),
+//		}
+//	}
+//
+// Mutations that do not set the field, such as updates of other fields, are routed by their context.
+func KeyHook(field string) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			v, ok := m.Field(field)
+			if !ok {
+				return next.Mutate(ctx, m)
+			}
+			if key, ok := KeyFromContext(ctx); ok && fmt.Sprint(key) != fmt.Sprint(v) {
+				return nil, fmt.Errorf("sqlshard: %s mutation with %s %v cannot run with shard key %v", m.Type(), field, v, key)
+			}
+			return next.Mutate(WithKey(ctx, v), m)
+		})
+	}
+}
+
+var _ dialect.Driver = (*Driver)(nil)
@@ -0,0 +1,166 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sqlshard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDriver(t *testing.T) {
+	_, err := NewDriver(nil)
+	require.Error(t, err)
+
+	db1, _, err := sqlmock.New()
+	require.NoError(t, err)
+	db2, _, err := sqlmock.New()
+	require.NoError(t, err)
+	_, err = NewDriver(map[string]dialect.Driver{
+		"a": sql.OpenDB(dialect.MySQL, db1),
+		"b": sql.OpenDB(dialect.Postgres, db2),
+	})
+	require.EqualError(t, err, `sqlshard: shard "b" uses dialect "postgres", but other shards use "mysql"`)
+	_, err = NewDriver(map[string]dialect.Driver{"a": sql.OpenDB(dialect.MySQL, db1)}, DefaultShard("b"))
+	require.EqualError(t, err, `sqlshard: default shard "b" does not exist`)
+
+	drv, err := NewDriver(map[string]dialect.Driver{
+		"b": sql.OpenDB(dialect.MySQL, db2),
+		"a": sql.OpenDB(dialect.MySQL, db1),
+	})
+	require.NoError(t, err)
+	require.Equal(t, dialect.MySQL, drv.Dialect())
+	require.Equal(t, []string{"a", "b"}, drv.Shards())
+}
+
+func TestDriver(t *testing.T) {
+	db1, mock1, err := sqlmock.New()
+	require.NoError(t, err)
+	db2, mock2, err := sqlmock.New()
+	require.NoError(t, err)
+	drv, err := NewDriver(map[string]dialect.Driver{
+		"even": sql.OpenDB(dialect.SQLite, db1),
+		"odd":  sql.OpenDB(dialect.SQLite, db2),
+	}, WithRouter(func(key any) (string, error) {
+		id, ok := key.(int)
+		if !ok {
+			return "", fmt.Errorf("unexpected key %v", key)
+		}
+		if id%2 == 0 {
+			return "even", nil
+		}
+		return "odd", nil
+	}))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.ErrorIs(t, drv.Exec(ctx, "DELETE FROM users", []any{}, nil), ErrNoShard)
+	require.EqualError(t, drv.Exec(WithKey(ctx, "1"), "DELETE FROM users", []any{}, nil), "unexpected key 1")
+	require.EqualError(t, drv.Exec(WithShard(ctx, "none"), "DELETE FROM users", []any{}, nil), `sqlshard: shard "none" does not exist`)
+
+	mock1.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(WithKey(ctx, 2), "DELETE FROM users", []any{}, nil))
+	mock2.ExpectQuery("SELECT name FROM users").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+	rows := &sql.Rows{}
+	require.NoError(t, drv.Query(WithKey(ctx, 1), "SELECT name FROM users", []any{}, rows))
+	require.NoError(t, rows.Close())
+	mock1.ExpectExec("DELETE FROM pets").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(WithShard(WithKey(ctx, 1), "even"), "DELETE FROM pets", []any{}, nil), "shards take precedence over keys")
+
+	// Transactions are pinned to their shard.
+	mock2.ExpectBegin()
+	mock2.ExpectExec("UPDATE users").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock2.ExpectExec("UPDATE pets").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock2.ExpectCommit()
+	tx, err := drv.Tx(WithKey(ctx, 3))
+	require.NoError(t, err)
+	require.Equal(t, "odd", tx.(*Tx).Shard())
+	require.NoError(t, tx.Exec(WithKey(ctx, 5), "UPDATE users SET name = ?", []any{"a8m"}, nil))
+	require.NoError(t, tx.Exec(ctx, "UPDATE pets SET name = ?", []any{"pedro"}, nil))
+	err = tx.Exec(WithKey(ctx, 4), "UPDATE users SET name = ?", []any{"a8m"}, nil)
+	require.EqualError(t, err, `sqlshard: transaction of shard "odd" cannot execute statements of shard "even"`)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock1.ExpectationsWereMet())
+	require.NoError(t, mock2.ExpectationsWereMet())
+
+	// Statements without a key are routed to the default shard.
+	drv.def = "odd"
+	mock2.ExpectExec("DELETE FROM users").WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(ctx, "DELETE FROM users", []any{}, nil))
+	require.NoError(t, mock2.ExpectationsWereMet())
+}
+
+func TestEachGather(t *testing.T) {
+	drv := &Driver{names: []string{"a", "b", "c"}}
+	var visited []string
+	err := drv.Each(context.Background(), func(ctx context.Context) error {
+		name := ctx.Value(shardKey{}).(string)
+		visited = append(visited, name)
+		if name == "b" {
+			return errors.New("oops")
+		}
+		return nil
+	})
+	require.EqualError(t, err, `sqlshard: shard "b": oops`)
+	require.Equal(t, []string{"a", "b"}, visited)
+
+	results := map[string][]int{"a": {1, 4, 9}, "b": {2, 3}, "c": {0, 10}}
+	gather := func(ctx context.Context) ([]int, error) {
+		return results[ctx.Value(shardKey{}).(string)], nil
+	}
+	merged, err := Gather(context.Background(), drv, gather, func(a, b int) bool { return a < b })
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3, 4, 9, 10}, merged)
+	merged, err = Gather(context.Background(), drv, gather, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 4, 9, 2, 3, 0, 10}, merged)
+	_, err = Gather(context.Background(), drv, func(ctx context.Context) ([]int, error) {
+		if ctx.Value(shardKey{}).(string) == "c" {
+			return nil, errors.New("oops")
+		}
+		return nil, nil
+	}, nil)
+	require.EqualError(t, err, `sqlshard: shard "c": oops`)
+}
+
+type mutation struct {
+	ent.Mutation
+	fields map[string]ent.Value
+}
+
+func (m *mutation) Type() string { return "User" }
+
+func (m *mutation) Field(name string) (ent.Value, bool) {
+	v, ok := m.fields[name]
+	return v, ok
+}
+
+func TestKeyHook(t *testing.T) {
+	var key any
+	mutator := KeyHook("tenant_id")(ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+		key, _ = KeyFromContext(ctx)
+		return nil, nil
+	}))
+	ctx := context.Background()
+	_, err := mutator.Mutate(ctx, &mutation{fields: map[string]ent.Value{"tenant_id": 1}})
+	require.NoError(t, err)
+	require.Equal(t, 1, key)
+
+	_, err = mutator.Mutate(WithKey(ctx, 2), &mutation{})
+	require.NoError(t, err)
+	require.Equal(t, 2, key, "mutations without the field are routed by their context")
+
+	_, err = mutator.Mutate(WithKey(ctx, "1"), &mutation{fields: map[string]ent.Value{"tenant_id": 1}})
+	require.NoError(t, err)
+	_, err = mutator.Mutate(WithKey(ctx, 2), &mutation{fields: map[string]ent.Value{"tenant_id": 1}})
+	require.EqualError(t, err, "sqlshard: User mutation with tenant_id 1 cannot run with shard key 2")
+}
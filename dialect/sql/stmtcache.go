@@ -0,0 +1,390 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// DriverOption allows configuring the Driver when it is opened.
+type DriverOption func(*Driver)
+
+// StatementCache enables caching of prepared statements in the driver and its transactions.
+// Statements are keyed by their query text, and the least recently used statements are closed
+// when the cache holds more than size statements. Only DML statements (SELECT, INSERT, UPDATE,
+// DELETE and WITH queries) are cached, and other statements are executed as is.
+//
+// Cached statements are safe for use across connections, as the database/sql package prepares
+// them on each connection they are executed on, and prepares them again on connections that were
+// reset. Statements that are rejected by the database as unknown or stale (e.g. after a schema
+// change) are evicted from the cache and executed again without a prepared statement.
+//
+//	drv, err := sql.Open(dialect.Postgres, dsn, sql.StatementCache(512))
+//
+// Since a prepared statement is parsed once, and executed many times, it cannot carry a comment
+// that changes on every execution. Hence, statements with a trailing comment, such as the per-request
+// tags that are appended by the sqlcomment package, are executed without a prepared statement, unless
+// the IgnoreTrailingComments option is set. Leading comments, such as optimizer hints, are part of the key.
+func StatementCache(size int, opts ...StatementCacheOption) DriverOption {
+	return func(d *Driver) {
+		if db, ok := d.ExecQuerier.(*sql.DB); ok && size > 0 {
+			c := newStmtCache(db, size)
+			for _, opt := range opts {
+				opt(c)
+			}
+			d.ExecQuerier = c
+		}
+	}
+}
+
+// StatementCacheOption allows configuring the statement cache of the driver.
+type StatementCacheOption func(*stmtCache)
+
+// IgnoreTrailingComments caches the statements that end with a comment by their query text without
+// the comment. Note that the trailing comments of cached statements are not sent to the database.
+// Hence, using this option with the sqlcomment package keeps the hot paths of the generated code
+// prepared, at the cost of dropping the tags of these statements.
+//
+//	drv, err := sql.Open(dialect.Postgres, dsn, sql.StatementCache(512, sql.IgnoreTrailingComments()))
+func IgnoreTrailingComments() StatementCacheOption {
+	return func(c *stmtCache) {
+		c.ignoreComments = true
+	}
+}
+
+// stmtCache is an ExecQuerier that executes the statements of
+// the database using an LRU cache of prepared statements.
+type stmtCache struct {
+	db             *sql.DB
+	size           int
+	ignoreComments bool
+	mu             sync.Mutex
+	ll             *list.List
+	items          map[string]*list.Element
+}
+
+// cachedStmt is a prepared statement in the cache. Statements that are evicted while
+// they are in use by other goroutines are closed after they were released by all of them.
+type cachedStmt struct {
+	*sql.Stmt
+	query   string
+	refs    int
+	evicted bool
+}
+
+func newStmtCache(db *sql.DB, size int) *stmtCache {
+	return &stmtCache{db: db, size: size, ll: list.New(), items: make(map[string]*list.Element)}
+}
+
+// DB returns the underlying *sql.DB of the cache.
+func (c *stmtCache) DB() *sql.DB {
+	return c.db
+}
+
+// ExecContext executes the statement using a cached prepared statement, if it is cacheable.
+func (c *stmtCache) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if !c.cacheable(query) {
+		return c.db.ExecContext(ctx, query, args...)
+	}
+	s, err := c.get(ctx, stmtKey(query))
+	if err != nil {
+		return c.retryExec(ctx, query, args, err)
+	}
+	res, err := s.ExecContext(ctx, args...)
+	c.release(s)
+	if err != nil {
+		return c.retryExec(ctx, query, args, err)
+	}
+	return res, nil
+}
+
+// QueryContext executes the query using a cached prepared statement, if it is cacheable.
+func (c *stmtCache) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if !c.cacheable(query) {
+		return c.db.QueryContext(ctx, query, args...)
+	}
+	s, err := c.get(ctx, stmtKey(query))
+	if err != nil {
+		return c.retryQuery(ctx, query, args, err)
+	}
+	// The statement is closed by the database/sql package only
+	// after the rows were closed, even if it was evicted before.
+	rows, err := s.QueryContext(ctx, args...)
+	c.release(s)
+	if err != nil {
+		return c.retryQuery(ctx, query, args, err)
+	}
+	return rows, nil
+}
+
+// retryExec evicts the statement of the query from the cache if the given error indicates
+// that it is stale, and executes the query again without a prepared statement.
+func (c *stmtCache) retryExec(ctx context.Context, query string, args []any, err error) (sql.Result, error) {
+	if !c.invalidate(query, err) {
+		return nil, err
+	}
+	return c.db.ExecContext(ctx, query, args...)
+}
+
+// retryQuery is the query version of retryExec.
+func (c *stmtCache) retryQuery(ctx context.Context, query string, args []any, err error) (*sql.Rows, error) {
+	if !c.invalidate(query, err) {
+		return nil, err
+	}
+	return c.db.QueryContext(ctx, query, args...)
+}
+
+// invalidate evicts the statement of the query from the cache if the given error indicates
+// that the statement is stale, or that its connection is broken. It reports if the query
+// should be executed again.
+func (c *stmtCache) invalidate(query string, err error) bool {
+	stale := staleStmt(err)
+	if stale || errors.Is(err, driver.ErrBadConn) {
+		c.evict(stmtKey(query))
+	}
+	return stale
+}
+
+// get returns the prepared statement of the query, and prepares it if it is not in
+// the cache. The given query is expected to be the key of the statement (see stmtKey).
+func (c *stmtCache) get(ctx context.Context, query string) (*cachedStmt, error) {
+	c.mu.Lock()
+	if e, ok := c.items[query]; ok {
+		c.ll.MoveToFront(e)
+		s := e.Value.(*cachedStmt)
+		s.refs++
+		c.mu.Unlock()
+		return s, nil
+	}
+	c.mu.Unlock()
+	// Statements are prepared outside of the lock, and therefore, concurrent calls
+	// may prepare the same query. In this case, the first statement is cached.
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[query]; ok {
+		stmt.Close()
+		c.ll.MoveToFront(e)
+		s := e.Value.(*cachedStmt)
+		s.refs++
+		return s, nil
+	}
+	s := &cachedStmt{Stmt: stmt, query: query, refs: 1}
+	c.items[query] = c.ll.PushFront(s)
+	for c.ll.Len() > c.size {
+		c.removeLocked(c.ll.Back())
+	}
+	return s, nil
+}
+
+// release releases a statement that was returned by get.
+func (c *stmtCache) release(s *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s.refs--; s.refs == 0 && s.evicted {
+		s.Close()
+	}
+}
+
+// evict removes the statement of the query from the cache.
+func (c *stmtCache) evict(query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.items[query]; ok {
+		c.removeLocked(e)
+	}
+}
+
+// removeLocked removes the given element from the cache, and
+// closes its statement if it is not in use. c.mu must be held.
+func (c *stmtCache) removeLocked(e *list.Element) {
+	s := c.ll.Remove(e).(*cachedStmt)
+	delete(c.items, s.query)
+	s.evicted = true
+	if s.refs == 0 {
+		s.Close()
+	}
+}
+
+// close closes all statements in the cache.
+func (c *stmtCache) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.ll.Len() > 0 {
+		c.removeLocked(c.ll.Back())
+	}
+}
+
+// tx returns an ExecQuerier that executes the statements
+// of the given transaction using the cached statements.
+func (c *stmtCache) tx(tx *sql.Tx) *txStmts {
+	return &txStmts{Tx: tx, cache: c, stmts: make(map[string]*sql.Stmt)}
+}
+
+// txStmts is an ExecQuerier that executes the statements of a transaction using
+// the prepared statements of the cache. The transaction-specific statements are
+// closed by the database/sql package when the transaction ends.
+type txStmts struct {
+	*sql.Tx
+	cache *stmtCache
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// ExecContext executes the statement in the transaction, using a cached prepared statement if it is cacheable.
+func (t *txStmts) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if !t.cache.cacheable(query) {
+		return t.Tx.ExecContext(ctx, query, args...)
+	}
+	s, err := t.stmt(ctx, stmtKey(query))
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.ExecContext(ctx, args...)
+	if err != nil {
+		t.invalidate(query, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// QueryContext executes the query in the transaction, using a cached prepared statement if it is cacheable.
+func (t *txStmts) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if !t.cache.cacheable(query) {
+		return t.Tx.QueryContext(ctx, query, args...)
+	}
+	s, err := t.stmt(ctx, stmtKey(query))
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.QueryContext(ctx, args...)
+	if err != nil {
+		t.invalidate(query, err)
+		return nil, err
+	}
+	return rows, nil
+}
+
+// stmt returns the transaction-specific statement of the query.
+func (t *txStmts) stmt(ctx context.Context, query string) (*sql.Stmt, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if s, ok := t.stmts[query]; ok {
+		return s, nil
+	}
+	cs, err := t.cache.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// The transaction-specific statement keeps the cached
+	// statement open until the transaction ends.
+	s := t.Tx.StmtContext(ctx, cs.Stmt)
+	t.cache.release(cs)
+	t.stmts[query] = s
+	return s, nil
+}
+
+// invalidate removes the statement of the query from the transaction and from the cache, if the
+// given error indicates that it is stale. Unlike statements that are executed outside of transactions,
+// stale statements are not executed again, because the failure may have aborted the transaction.
+func (t *txStmts) invalidate(query string, err error) {
+	if t.cache.invalidate(query, err) {
+		t.mu.Lock()
+		delete(t.stmts, stmtKey(query))
+		t.mu.Unlock()
+	}
+}
+
+// cacheable reports if the statement of the query should be cached. Statements
+// with a trailing comment are cached only if the comments are ignored.
+func (c *stmtCache) cacheable(query string) bool {
+	return cacheable(query) && (c.ignoreComments || stmtKey(query) == query)
+}
+
+// cacheable reports if the statement of the query is a DML statement.
+func cacheable(query string) bool {
+	query = strings.TrimSpace(query)
+	// Skip leading comments, such as optimizer hints.
+	for strings.HasPrefix(query, "/*") {
+		i := strings.Index(query, "*/")
+		if i == -1 {
+			return false
+		}
+		query = strings.TrimSpace(query[i+2:])
+	}
+	i := strings.IndexAny(query, " \t\n(")
+	if i == -1 {
+		return false
+	}
+	switch strings.ToUpper(query[:i]) {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "WITH":
+		return true
+	default:
+		return false
+	}
+}
+
+// stmtKey returns the key of the statement of the given query in the cache, which is the query
+// without its trailing comment (e.g. sqlcommenter tags), if it exists. The terminating semicolon
+// of the query is preserved.
+func stmtKey(query string) string {
+	stmt := strings.TrimRight(query, " \t\n")
+	semicolon := strings.HasSuffix(stmt, ";")
+	stmt = strings.TrimRight(strings.TrimSuffix(stmt, ";"), " \t\n")
+	if !strings.HasSuffix(stmt, "*/") {
+		return query
+	}
+	i := strings.LastIndex(stmt, "/*")
+	// Skip queries that consist of a comment only, and optimizer hints.
+	if i <= 0 || strings.HasPrefix(stmt[i:], "/*+") {
+		return query
+	}
+	stmt = strings.TrimRight(stmt[:i], " \t\n")
+	if semicolon {
+		stmt += ";"
+	}
+	return stmt
+}
+
+// staleStmt reports if the error indicates that the prepared statement is unknown to the
+// database, or that it must be prepared again. For example, after a connection was reset by
+// a connection pooler, or after a schema change that modified the result type of the query.
+func staleStmt(err error) bool {
+	for err := err; err != nil; err = errors.Unwrap(err) {
+		var code string
+		if s, ok := err.(interface{ SQLState() string }); ok {
+			code = s.SQLState()
+		} else if v, ok := errorField(err, "Code"); ok && v.Kind() == reflect.String {
+			code = v.String()
+		}
+		switch code {
+		case "26000": // invalid_sql_statement_name.
+			return true
+		case "0A000": // feature_not_supported.
+			if strings.Contains(err.Error(), "cached plan must not change result type") {
+				return true
+			}
+		}
+		if v, ok := errorField(err, "Number"); ok && v.CanUint() {
+			switch v.Uint() {
+			case 1243, 1615: // ER_UNKNOWN_STMT_HANDLER, ER_NEED_REPREPARE.
+				return true
+			}
+		}
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "prepared statement") && strings.Contains(msg, "does not exist") ||
+		strings.Contains(msg, "cached plan must not change result type")
+}
@@ -0,0 +1,198 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementCache(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv := OpenDB(dialect.Postgres, db, StatementCache(1))
+	require.Equal(t, db, drv.DB())
+
+	users := mock.ExpectPrepare(`SELECT "name" FROM "users" WHERE "id" = $1`)
+	users.ExpectQuery().WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+	users.ExpectQuery().WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("nati"))
+	for i, name := range []string{"a8m", "nati"} {
+		rows := &Rows{}
+		require.NoError(t, drv.Query(ctx, `SELECT "name" FROM "users" WHERE "id" = $1`, []any{i + 1}, rows))
+		got, err := ScanString(rows)
+		require.NoError(t, err)
+		require.Equal(t, name, got)
+		require.NoError(t, rows.Close())
+	}
+	// Statements that are not DML are not prepared.
+	mock.ExpectExec(`SET LOCAL statement_timeout = 1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.NoError(t, drv.Exec(ctx, `SET LOCAL statement_timeout = 1`, []any{}, nil))
+	// The least recently used statement is closed on eviction.
+	users.WillBeClosed()
+	pets := mock.ExpectPrepare(`DELETE FROM "pets" WHERE "id" = $1`)
+	pets.ExpectExec().WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(ctx, `DELETE FROM "pets" WHERE "id" = $1`, []any{1}, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Transactions use the cached statements.
+	mock.ExpectBegin()
+	pets.ExpectExec().WithArgs(2).WillReturnResult(sqlmock.NewResult(0, 1))
+	pets.ExpectExec().WithArgs(3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("SAVEPOINT sp").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	tx, err := drv.Tx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Exec(ctx, `DELETE FROM "pets" WHERE "id" = $1`, []any{2}, nil))
+	require.NoError(t, tx.Exec(ctx, `DELETE FROM "pets" WHERE "id" = $1`, []any{3}, nil))
+	_, err = tx.(*Tx).Savepoint(ctx, "sp")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Stale statements are evicted, and executed again without a prepared statement.
+	pets.ExpectExec().WithArgs(4).WillReturnError(&pq.Error{Code: "26000", Message: `prepared statement "1" does not exist`})
+	pets.WillBeClosed()
+	mock.ExpectExec(`DELETE FROM "pets" WHERE "id" = $1`).WithArgs(4).WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, drv.Exec(ctx, `DELETE FROM "pets" WHERE "id" = $1`, []any{4}, nil))
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// The statement is prepared again, and closed when the driver is closed.
+	pets = mock.ExpectPrepare(`DELETE FROM "pets" WHERE "id" = $1`)
+	pets.ExpectExec().WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 1))
+	pets.WillBeClosed()
+	mock.ExpectClose()
+	require.NoError(t, drv.Exec(ctx, `DELETE FROM "pets" WHERE "id" = $1`, []any{5}, nil))
+	require.NoError(t, drv.Close())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStatementCache_Comments(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv := OpenDB(dialect.Postgres, db, StatementCache(1))
+	// Statements with trailing comments are executed as is.
+	for i := 0; i < 2; i++ {
+		query := fmt.Sprintf(`SELECT "name" FROM "users" /*traceparent='00-%d-01'*/`, i)
+		mock.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+		rows := &Rows{}
+		require.NoError(t, drv.Query(ctx, query, []any{}, rows))
+		require.NoError(t, rows.Close())
+	}
+	require.Zero(t, drv.ExecQuerier.(*stmtCache).ll.Len())
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	db, mock, err = sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	require.NoError(t, err)
+	drv = OpenDB(dialect.Postgres, db, StatementCache(1, IgnoreTrailingComments()))
+	// Statements that differ only by their trailing comments share the same prepared statement.
+	users := mock.ExpectPrepare(`SELECT "name" FROM "users"`)
+	for i := 0; i < 3; i++ {
+		users.ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("a8m"))
+		rows := &Rows{}
+		query := fmt.Sprintf(`SELECT "name" FROM "users" /*traceparent='00-%d-01'*/`, i)
+		require.NoError(t, drv.Query(ctx, query, []any{}, rows))
+		require.NoError(t, rows.Close())
+	}
+	require.Equal(t, 1, drv.ExecQuerier.(*stmtCache).ll.Len())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStmtKey(t *testing.T) {
+	for query, want := range map[string]string{
+		"SELECT * FROM users":                                 "SELECT * FROM users",
+		"SELECT * FROM users /*route='%2Fusers'*/":            "SELECT * FROM users",
+		"SELECT * FROM users /*route='%2Fusers'*/;":           "SELECT * FROM users;",
+		"/*+ SeqScan(users) */ SELECT * FROM users":           "/*+ SeqScan(users) */ SELECT * FROM users",
+		"/*+ SeqScan(users) */ SELECT * FROM users /*a='b'*/": "/*+ SeqScan(users) */ SELECT * FROM users",
+		"SELECT * FROM users /*+ MAX_EXECUTION_TIME(1000) */": "SELECT * FROM users /*+ MAX_EXECUTION_TIME(1000) */",
+		"/* comment only */":                                  "/* comment only */",
+	} {
+		require.Equal(t, want, stmtKey(query), query)
+	}
+}
+
+func TestStatementCache_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	drv, err := Open(dialect.SQLite, "file:stmtcache?mode=memory&cache=shared&_fk=1", StatementCache(2))
+	require.NoError(t, err)
+	defer drv.Close()
+	require.NoError(t, drv.Exec(ctx, "CREATE TABLE `users` (`id` integer PRIMARY KEY, `name` text)", []any{}, nil))
+	require.NoError(t, drv.Exec(ctx, "INSERT INTO `users` (`id`, `name`) VALUES (?, ?), (?, ?)", []any{1, "a8m", 2, "nati"}, nil))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				// Use more queries than the cache size, in order to evict statements that are in use.
+				query := fmt.Sprintf("SELECT `name` FROM `users` WHERE `id` = ? AND %d = %d", (i+j)%4, (i+j)%4)
+				rows := &Rows{}
+				if !assertNoError(t, drv.Query(ctx, query, []any{1}, rows)) {
+					return
+				}
+				name, err := ScanString(rows)
+				assertNoError(t, err)
+				assertNoError(t, rows.Close())
+				if name != "a8m" {
+					t.Errorf("unexpected name: %q", name)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	c := drv.ExecQuerier.(*stmtCache)
+	require.Equal(t, 2, c.ll.Len())
+	require.Len(t, c.items, 2)
+}
+
+func assertNoError(t *testing.T, err error) bool {
+	if err != nil {
+		t.Error(err)
+		return false
+	}
+	return true
+}
+
+func TestStaleStmt(t *testing.T) {
+	require.True(t, staleStmt(&pq.Error{Code: "26000"}))
+	require.True(t, staleStmt(&pq.Error{Code: "0A000", Message: "cached plan must not change result type"}))
+	require.False(t, staleStmt(&pq.Error{Code: "0A000", Message: "not supported"}))
+	require.True(t, staleStmt(&Error{Err: &mysql.MySQLError{Number: 1615, Message: "Prepared statement needs to be re-prepared"}}))
+	require.True(t, staleStmt(fmt.Errorf("pgx: %w", fmt.Errorf(`ERROR: prepared statement "stmtcache_1" does not exist (SQLSTATE 26000)`))))
+	require.False(t, staleStmt(sql.ErrNoRows))
+}
+
+func TestCacheable(t *testing.T) {
+	for query, want := range map[string]bool{
+		"SELECT * FROM `users`":                     true,
+		" select 1":                                 true,
+		"WITH t AS (SELECT 1) SELECT * FROM t":      true,
+		"/*+ SeqScan(users) */ SELECT * FROM users": true,
+		"INSERT INTO users DEFAULT VALUES":          true,
+		"UPDATE users SET name = $1":                true,
+		"DELETE FROM users":                         true,
+		"SAVEPOINT ent_savepoint_1":                 false,
+		"CREATE TABLE users (id int)":               false,
+		"SET LOCAL statement_timeout = 1000":        false,
+		"/* unterminated SELECT":                    false,
+		"SELECT":                                    false,
+	} {
+		require.Equal(t, want, cacheable(query), query)
+	}
+}
@@ -119,6 +119,168 @@ var (
 		Description: "Allows users to configure the `ON CONFLICT`/`ON DUPLICATE KEY` clause for `INSERT` statements",
 	}
 
+	// FeatureJSONPath provides a feature-flag for generating typed predicates and orders for the
+	// nested values of JSON fields with struct types.
+	FeatureJSONPath = Feature{
+		Name:        "sql/jsonpath",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates typed predicates and order functions for the nested values of JSON fields with struct types",
+	}
+
+	// FeatureEncrypt provides a feature-flag for encrypting fields at rest. It is
+	// enabled automatically when one of the schema fields is configured as encrypted.
+	FeatureEncrypt = Feature{
+		Name:        "sql/encrypt",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Encrypts string and bytes fields at rest using AES-GCM envelope encryption, and decrypts them on query",
+	}
+
+	// FeaturePolymorphic provides a feature-flag for polymorphic edges. It is
+	// enabled automatically when one of the schemas defines a polymorphic edge.
+	FeaturePolymorphic = Feature{
+		Name:        "sql/polymorphic",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Generates the query, eager-loading and mutation API for polymorphic edges",
+	}
+
+	// FeatureEdgeAggregate provides a feature-flag for eager-loading the number of nodes
+	// connected to an edge, or the results of aggregation functions applied on them.
+	FeatureEdgeAggregate = Feature{
+		Name:        "sql/edgeaggregate",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to eager-load counts and aggregations of edges without loading the edges",
+	}
+
+	// FeatureRecursive provides a feature-flag for traversing self-referencing edges recursively.
+	FeatureRecursive = Feature{
+		Name:        "sql/recursive",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to traverse self-referencing edges recursively using recursive common table expressions",
+	}
+
+	// FeatureShortestPath provides a feature-flag for finding paths between nodes through self-referencing edges.
+	FeatureShortestPath = Feature{
+		Name:        "shortestpath",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to check reachability and find the shortest path between nodes through self-referencing edges",
+	}
+
+	// FeatureIter provides a feature-flag for iterating over large query results without loading them into memory.
+	FeatureIter = Feature{
+		Name:        "sql/iter",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to stream the results of queries one row at a time, or to process them in batches",
+	}
+
+	// FeatureLoader provides a feature-flag for batching node and edge lookups into a single query.
+	FeatureLoader = Feature{
+		Name:        "sql/loader",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to batch node and edge lookups that are made concurrently into a single query, in order to avoid N+1 queries",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "dialect/sql/loader",
+				Format: "loader.go",
+				Skip:   func(g *Graph) bool { return g.Storage.Name != "sql" },
+			},
+		},
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "loader.go"))
+		},
+	}
+
+	// FeatureCache provides a feature-flag for invalidating the entries of a query cache on mutations.
+	FeatureCache = Feature{
+		Name:        "sql/cache",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to invalidate the entries of a query cache (see dialect/sql/sqlcache) when nodes are mutated",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "dialect/sql/cache",
+				Format: "cache.go",
+				Skip:   func(g *Graph) bool { return g.Storage.Name != "sql" },
+			},
+		},
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "cache.go"))
+		},
+	}
+
+	// FeatureExplain provides a feature-flag for reporting the execution plans of queries.
+	FeatureExplain = Feature{
+		Name:        "sql/explain",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to get the execution plans of queries using the EXPLAIN statement of the database",
+	}
+
+	// FeatureUpdateBulk provides a feature-flag for updating many entities, each with its own values, in bulk.
+	FeatureUpdateBulk = Feature{
+		Name:        "sql/updatebulk",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to update many entities, each with its own values, using a single statement for each batch",
+	}
+
+	// FeatureStream provides a feature-flag for streaming a large number of entities into the database.
+	FeatureStream = Feature{
+		Name:        "sql/stream",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to stream a large number of entities into the database using COPY or multi-row INSERT statements",
+	}
+
+	// FeatureSavepoint provides a feature-flag for nesting transactions using savepoints.
+	FeatureSavepoint = Feature{
+		Name:        "sql/savepoint",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to start nested transactions that are backed by savepoints, and roll them back partially",
+	}
+
+	// FeatureWithTx provides a feature-flag for executing functions in transactions that are retried on conflicts.
+	FeatureWithTx = Feature{
+		Name:        "sql/withtx",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to execute functions in transactions that are retried on serialization failures and deadlocks",
+	}
+
+	// FeatureQueryHints provides a feature-flag for setting statement timeouts and optimizer hints on queries.
+	FeatureQueryHints = Feature{
+		Name:        "sql/hints",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to limit the execution time of queries, and to pass optimizer and index hints to the database",
+	}
+
+	// FeatureBudget provides a feature-flag for enforcing row limits and refusing unbounded mutations.
+	FeatureBudget = Feature{
+		Name:        "sql/budget",
+		Stage:       Experimental,
+		Default:     false,
+		Description: "Allows users to limit the rows returned by queries, and to refuse Update and Delete mutations without predicates (see dialect/sql/sqlbudget)",
+		GraphTemplates: []GraphTemplate{
+			{
+				Name:   "dialect/sql/budget",
+				Format: "budget.go",
+				Skip:   func(g *Graph) bool { return g.Storage.Name != "sql" },
+			},
+		},
+		cleanup: func(c *Config) error {
+			return os.RemoveAll(filepath.Join(c.Target, "budget.go"))
+		},
+	}
+
 	FeatureVersionedMigration = Feature{
 		Name:        "sql/versioned-migration",
 		Stage:       Experimental,
@@ -139,6 +301,22 @@ var (
 		FeatureExecQuery,
 		FeatureUpsert,
 		FeatureVersionedMigration,
+		FeatureJSONPath,
+		FeatureEncrypt,
+		FeaturePolymorphic,
+		FeatureEdgeAggregate,
+		FeatureRecursive,
+		FeatureShortestPath,
+		FeatureIter,
+		FeatureLoader,
+		FeatureCache,
+		FeatureExplain,
+		FeatureUpdateBulk,
+		FeatureStream,
+		FeatureSavepoint,
+		FeatureWithTx,
+		FeatureQueryHints,
+		FeatureBudget,
 	}
 )
 
@@ -107,6 +107,9 @@ func quote(v any) any {
 // fieldOps returns all predicate operations for a given field.
 func fieldOps(f *Field) (ops []Op) {
 	switch t := f.Type.Type; {
+	case f.IsDeterministicEncrypted():
+		ops = enumOps
+	case f.IsEncrypted():
 	case f.HasGoType() && !f.ConvertedToBasic() && !f.Type.Valuer():
 	case t == field.TypeJSON:
 	case t == field.TypeBool:
@@ -14,10 +14,12 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime/debug"
 	"strings"
 	"text/template/parse"
 
+	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/sql/schema"
 	"github.com/jogly/ent/entc/load"
 	"github.com/jogly/ent/schema/field"
@@ -173,11 +175,67 @@ func NewGraph(c *Config, schemas ...*load.Schema) (g *Graph, err error) {
 		g.addIndexes(schemas[i])
 	}
 	check(g.edgeSchemas(), "resolving edges")
+	check(g.compositeIDs(), "resolving composite identifiers")
+	check(g.versions(), "resolving version fields")
+	check(g.encryption(), "resolving encrypted fields")
+	check(g.polymorphic(), "resolving polymorphic edges")
 	aliases(g)
 	g.defaults()
 	return
 }
 
+// encryption enables the encryption feature-flag in case
+// one of the fields in the graph is configured as encrypted.
+func (g *Graph) encryption() error {
+	for _, n := range g.Nodes {
+		for _, f := range n.Fields {
+			if !f.IsEncrypted() {
+				continue
+			}
+			if g.Storage != nil && g.Storage.Name != "sql" {
+				return fmt.Errorf("encrypted field %s.%s is not supported by storage driver %q", n.Name, f.Name, g.Storage)
+			}
+			if !g.featureEnabled(FeatureEncrypt) {
+				g.Features = append(g.Features, FeatureEncrypt)
+			}
+		}
+	}
+	return nil
+}
+
+// polymorphic validates the polymorphic edges in the graph, and enables
+// the polymorphic feature-flag in case one of the types defines them.
+func (g *Graph) polymorphic() error {
+	for _, n := range g.Nodes {
+		for _, e := range n.PolymorphicEdges {
+			if g.Storage != nil && g.Storage.Name != "sql" {
+				return fmt.Errorf("polymorphic edge %s.%s is not supported by storage driver %q", n.Name, e.Name, g.Storage)
+			}
+			if !n.HasOneFieldID() {
+				return fmt.Errorf("polymorphic edge %s.%s is not supported on type %[1]s with composite identifier", n.Name, e.Name)
+			}
+			for _, t := range e.Targets {
+				switch {
+				case !t.Type.HasOneFieldID():
+					return fmt.Errorf("polymorphic edge %s.%s cannot reference type %s with composite identifier", n.Name, e.Name, t.Type.Name)
+				case t.Type.ID.Type.String() != e.IDField.Type.String():
+					return fmt.Errorf("polymorphic edge %s.%s requires all its types to share the same id type, but %s.id is %s and %s.id is %s",
+						n.Name, e.Name, e.Targets[0].Type.Name, e.IDField.Type, t.Type.Name, t.Type.ID.Type)
+				}
+				for _, e2 := range n.Edges {
+					if e2.Name == t.Name() {
+						return fmt.Errorf("edge %s.%s conflicts with type %s of polymorphic edge %q", n.Name, e2.Name, t.Type.Name, e.Name)
+					}
+				}
+			}
+			if !g.featureEnabled(FeaturePolymorphic) {
+				g.Features = append(g.Features, FeaturePolymorphic)
+			}
+		}
+	}
+	return nil
+}
+
 // defaultIDType holds the default value for IDType.
 var defaultIDType = &field.TypeInfo{Type: field.TypeInt}
 
@@ -293,6 +351,13 @@ func (g *Graph) addEdges(schema *load.Schema) {
 	t, _ := g.typ(schema.Name)
 	seen := make(map[string]struct{}, len(schema.Edges))
 	for _, e := range schema.Edges {
+		if len(e.Types) > 0 {
+			_, ok := seen[e.Name]
+			expect(!ok, "%s schema contains multiple %q edges", schema.Name, e.Name)
+			seen[e.Name] = struct{}{}
+			g.addPolymorphicEdge(t, e)
+			continue
+		}
 		typ, ok := g.typ(e.Type)
 		expect(ok, "type %q does not exist for edge", e.Type)
 		_, ok = t.fields[e.Name]
@@ -366,6 +431,59 @@ func (g *Graph) addEdges(schema *load.Schema) {
 	}
 }
 
+// addPolymorphicEdge adds a polymorphic edge to the given type. The edge is
+// stored in two generated fields: "<edge>_type" that holds the type of the
+// neighbor, and "<edge>_id" that holds its identifier.
+func (g *Graph) addPolymorphicEdge(t *Type, e *load.Edge) {
+	_, ok := t.fields[e.Name]
+	expect(!ok, "%s schema cannot contain field and edge with the same name %q", t.Name, e.Name)
+	pe := &PolymorphicEdge{
+		def:         e,
+		Name:        e.Name,
+		Owner:       t,
+		Optional:    !e.Required,
+		Immutable:   e.Immutable,
+		Annotations: e.Annotations,
+	}
+	enums := make([]struct{ N, V string }, 0, len(e.Types))
+	for i, name := range e.Types {
+		typ, ok := g.typ(name)
+		expect(ok, "type %q does not exist for polymorphic edge %s.%s", name, t.Name, e.Name)
+		for _, pt := range pe.Targets {
+			expect(pt.Type != typ, "polymorphic edge %s.%s contains type %q more than once", t.Name, e.Name, name)
+		}
+		pe.Targets = append(pe.Targets, &PolymorphicTarget{Edge: pe, Type: typ, Value: typ.Name, Index: i})
+		enums = append(enums, struct{ N, V string }{N: typ.Name, V: typ.Name})
+	}
+	// The type of the identifier field is set to the ID type of the targets,
+	// and it is verified after the graph is fully resolved (see polymorphic).
+	idType := *pe.Targets[0].Type.ID.Type
+	for _, f := range []*load.Field{
+		{Name: e.Name + "_type", Info: &field.TypeInfo{Type: field.TypeEnum}, Enums: enums},
+		{Name: e.Name + "_id", Info: &idType},
+	} {
+		f.Optional, f.Nillable, f.Immutable = pe.Optional, pe.Optional, pe.Immutable
+		tf := &Field{
+			cfg:         g.Config,
+			def:         f,
+			typ:         t,
+			Name:        f.Name,
+			Type:        f.Info,
+			Nillable:    f.Nillable,
+			Optional:    f.Optional,
+			Immutable:   f.Immutable,
+			StructTag:   structTag(f.Name, ""),
+			UserDefined: true,
+		}
+		check(t.checkField(tf, f), "polymorphic edge %s.%s", t.Name, e.Name)
+		t.Fields = append(t.Fields, tf)
+		t.fields[f.Name] = tf
+	}
+	pe.TypeField, pe.IDField = t.fields[e.Name+"_type"], t.fields[e.Name+"_id"]
+	check(t.AddIndex(&load.Index{Fields: []string{pe.TypeField.Name, pe.IDField.Name}}), "invalid index for polymorphic edge %s.%s", t.Name, e.Name)
+	t.PolymorphicEdges = append(t.PolymorphicEdges, pe)
+}
+
 // resolve the type references and relations of its edges.
 // It fails if one of the references is missing or invalid.
 //
@@ -458,7 +576,7 @@ func (g *Graph) resolve(t *Type) error {
 				e.Rel.Table = e.Type.Table()
 			}
 			if !e.M2M() {
-				e.Rel.Columns = []string{fmt.Sprintf("%s_id", snake(e.Name))}
+				e.Rel.Columns = []string{fmt.Sprintf("%s_%s", t.Label(), snake(e.Name))}
 			}
 		}
 	}
@@ -581,6 +699,7 @@ func (g *Graph) edgeSchemas() error {
 				for _, f := range ant.ID {
 					edgeT.EdgeSchema.ID = append(edgeT.EdgeSchema.ID, edgeT.fields[f])
 				}
+				edgeT.CompositeID = edgeT.EdgeSchema.ID
 			}
 			if edgeT.HasCompositeID() {
 				continue
@@ -608,6 +727,221 @@ func (g *Graph) edgeSchemas() error {
 	return nil
 }
 
+// compositeIDs resolves the composite identifiers of regular (non-edge) schemas that
+// were defined using the field.ID annotation. The identifier fields must be required,
+// comparable fields. Relations that reference these types (foreign keys and join tables)
+// are expanded to hold a column for each of the identifier fields.
+func (g *Graph) compositeIDs() error {
+	for _, n := range g.Nodes {
+		ant := fieldAnnotate(n.Annotations)
+		if ant == nil || len(ant.ID) == 0 || n.IsEdgeSchema() {
+			continue
+		}
+		if g.Storage != nil && g.Storage.Name != "sql" {
+			return fmt.Errorf("composite identifier of type %s is not supported by storage driver %q", n.Name, g.Storage)
+		}
+		if len(ant.ID) < 2 {
+			return fmt.Errorf("composite identifier of type %s must contain at least 2 fields, got: %q", n.Name, ant.ID)
+		}
+		var (
+			ids  = make([]*Field, 0, len(ant.ID))
+			seen = make(map[string]struct{}, len(ant.ID))
+		)
+		for _, name := range ant.ID {
+			f, ok := n.fields[name]
+			_, dup := seen[name]
+			switch {
+			case !ok:
+				return fmt.Errorf("field %q defined in the composite identifier of type %s was not found", name, n.Name)
+			case dup:
+				return fmt.Errorf("field %q is defined more than once in the composite identifier of type %s", name, n.Name)
+			case f.Optional || f.Nillable:
+				return fmt.Errorf("field %s.%s defined in the composite identifier cannot be optional or nillable", n.Name, name)
+			case f.IsJSON() || f.IsBytes() || f.IsEncrypted():
+				return fmt.Errorf("field %s.%s of type %s cannot be used in a composite identifier", n.Name, name, f.Type)
+			}
+			seen[name] = struct{}{}
+			ids = append(ids, f)
+		}
+		n.ID = nil
+		n.CompositeID = ids
+	}
+	for _, n := range g.Nodes {
+		for _, e := range n.Edges {
+			// Relations are shared between the assoc and inverse edges,
+			// and edges to edge schemas are resolved by their schema.
+			if e.IsInverse() || e.Type.IsEdgeSchema() {
+				continue
+			}
+			if err := e.compositeColumns(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compositeColumns expands the relation columns of an assoc edge that references a type with a
+// composite identifier to a column for each of the identifier fields. For example, the "owner_id"
+// column that references an "accounts" table with an ID of ("tenant_id", "external_id"), is
+// expanded to the "owner_tenant_id" and "owner_external_id" columns.
+func (e *Edge) compositeColumns() error {
+	switch owner, ref := e.Owner, e.Type; {
+	case !owner.compositeRef() && !ref.compositeRef():
+		return nil
+	case e.Through != nil || e.Ref != nil && e.Ref.Through != nil:
+		return fmt.Errorf("edge %s.%s cannot be defined through an edge schema, as it references a type with composite identifier", owner.Name, e.Name)
+	case e.M2M():
+		c1, c2 := []string{e.Rel.Columns[0]}, []string{e.Rel.Columns[1]}
+		if owner.compositeRef() {
+			c1 = compositeColumns(c1[0], owner)
+		}
+		if ref.compositeRef() {
+			c2 = compositeColumns(c2[0], ref)
+		}
+		e.setColumns(append(c1, c2...))
+		return nil
+	}
+	holder, ref := e.Type, e.Owner
+	if e.OwnFK() {
+		holder, ref = e.Owner, e.Type
+	}
+	if !ref.compositeRef() {
+		return nil
+	}
+	for _, ed := range []*Edge{e, e.Ref} {
+		if ed != nil && ed.def.Field != "" {
+			return fmt.Errorf("edge %s.%s cannot be defined with an edge field, as it references type %s with composite identifier", ed.Owner.Name, ed.Name, ref.Name)
+		}
+	}
+	fk, err := e.ForeignKey()
+	if err != nil {
+		return err
+	}
+	columns := compositeColumns(e.Rel.Column(), ref)
+	fks := make([]*ForeignKey, len(columns))
+	for i, c := range columns {
+		fks[i] = &ForeignKey{
+			Edge: e,
+			Field: &Field{
+				typ:         holder,
+				Name:        builderField(c),
+				Type:        ref.CompositeID[i].Type,
+				Nillable:    true,
+				Optional:    true,
+				Unique:      e.Unique,
+				UserDefined: true,
+			},
+			column: c,
+		}
+	}
+	// Replace the foreign-key that was created for the edge
+	// (single column) with a foreign-key for each of its columns.
+	for i := range holder.ForeignKeys {
+		if holder.ForeignKeys[i] != fk {
+			continue
+		}
+		delete(holder.foreignKeys, fk.Field.Name)
+		holder.ForeignKeys = append(holder.ForeignKeys[:i], holder.ForeignKeys[i+1:]...)
+		break
+	}
+	for _, fk := range fks {
+		holder.addFK(fk)
+	}
+	e.setColumns(columns)
+	e.Rel.fk, e.Rel.fks = fks[0], fks
+	if e.Ref != nil {
+		e.Ref.Rel.fk, e.Ref.Rel.fks = fks[0], fks
+	}
+	return nil
+}
+
+// setColumns sets the relation columns of the edge and its inverse edge.
+func (e *Edge) setColumns(columns []string) {
+	e.Rel.Columns = columns
+	if e.Ref != nil {
+		e.Ref.Rel.Columns = columns
+	}
+}
+
+// compositeRef reports if the type has a composite identifier that is referenced by
+// multi-column relations. Edge schemas are referenced by the relations they define.
+func (t Type) compositeRef() bool {
+	return t.HasCompositeID() && !t.IsEdgeSchema()
+}
+
+// compositeColumns returns the relation columns that reference the composite identifier of the
+// given type. The "_id" suffix of the relation column is replaced with the identifier columns.
+func compositeColumns(column string, t *Type) []string {
+	prefix := strings.TrimSuffix(column, "_id")
+	columns := make([]string, len(t.CompositeID))
+	for i, f := range t.CompositeID {
+		columns[i] = prefix + "_" + f.StorageKey()
+	}
+	return columns
+}
+
+// versions resolves the version fields of the types that were defined using the
+// field.Version annotation. A version field must be a required integer or time
+// field that can be updated, as it is bumped by the update builders.
+func (g *Graph) versions() error {
+	for _, n := range g.Nodes {
+		ant := fieldAnnotate(n.Annotations)
+		if ant == nil || ant.Version == "" {
+			continue
+		}
+		f, ok := n.fields[ant.Version]
+		switch {
+		case !ok:
+			return fmt.Errorf("version field %q of type %s was not found", ant.Version, n.Name)
+		case n.IsEdgeSchema():
+			return fmt.Errorf("version field %s.%s is not supported on edge schemas", n.Name, f.Name)
+		case f.Optional || f.Nillable:
+			return fmt.Errorf("version field %s.%s cannot be optional or nillable", n.Name, f.Name)
+		case f.Immutable || f.IsEdgeField() || f.IsEncrypted():
+			return fmt.Errorf("version field %s.%s cannot be immutable, an edge field or encrypted", n.Name, f.Name)
+		case f.IsTime() && f.HasGoType(), !f.IsTime() && (!f.Type.Type.Integer() || !f.ConvertedToBasic()):
+			return fmt.Errorf("version field %s.%s must be an integer or a time field, got: %s", n.Name, f.Name, f.Type)
+		}
+		if f.IsTime() && f.def != nil {
+			// Time versions are truncated to microseconds. Columns with a lower precision
+			// round them, and two updates in the same period would write the same version.
+			for d, t := range f.def.SchemaType {
+				if p := timePrecision.FindStringSubmatch(t); p == nil && d == dialect.MySQL || p != nil && p[1] != "6" {
+					return fmt.Errorf("time version field %s.%s must be stored with microsecond precision, got %s type: %q", n.Name, f.Name, d, t)
+				}
+			}
+		}
+		n.VersionField = f
+	}
+	return nil
+}
+
+// timePrecision matches the fractional seconds precision of time types. e.g. "timestamp(6)".
+var timePrecision = regexp.MustCompile(`\((\d+)\)`)
+
+// HasVersionFields reports if one of the types in the graph defines a version field.
+func (g *Graph) HasVersionFields() bool {
+	for _, n := range g.Nodes {
+		if n.VersionField != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDecimalFields reports if any of the nodes in the graph has a decimal field.
+func (g *Graph) HasDecimalFields() bool {
+	for _, n := range g.Nodes {
+		for _, f := range n.Fields {
+			if f.IsDecimal() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Tables returns the schema definitions of SQL tables for the graph.
 func (g *Graph) Tables() (all []*schema.Table, err error) {
 	tables := make(map[string]*schema.Table)
@@ -625,94 +959,33 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 		tables[table.Name] = table
 		all = append(all, table)
 	}
+	// Relations that reference types with composite identifiers are added after
+	// the composite primary keys are set, as their columns reference them.
+	var composite []*Edge
 	for _, n := range g.Nodes {
 		// Foreign key and its reference, or a join table.
 		for _, e := range n.Edges {
-			if e.IsInverse() {
-				continue
-			}
-			switch e.Rel.Type {
-			case O2O, O2M:
-				// The "owner" is the table that owns the relation (we set
-				// the foreign-key on) and "ref" is the referenced table.
-				owner, ref := tables[e.Rel.Table], tables[n.Table()]
-				column := fkColumn(e, owner, ref.PrimaryKey[0])
-				// If it's not a circular reference (self-referencing table),
-				// and the inverse edge is required, make it non-nullable.
-				if n != e.Type && e.Ref != nil && !e.Ref.Optional {
-					column.Nullable = false
-				}
-				mayAddColumn(owner, column)
-				owner.AddForeignKey(&schema.ForeignKey{
-					RefTable:   ref,
-					OnDelete:   deleteAction(e, column),
-					Columns:    []*schema.Column{column},
-					RefColumns: []*schema.Column{ref.PrimaryKey[0]},
-					Symbol:     fkSymbol(e, owner, ref),
-				})
-			case M2O:
-				ref, owner := tables[e.Type.Table()], tables[e.Rel.Table]
-				column := fkColumn(e, owner, ref.PrimaryKey[0])
-				// If it's not a circular reference (self-referencing table),
-				// and the edge is non-optional (required), make it non-nullable.
-				if n != e.Type && !e.Optional {
-					column.Nullable = false
-				}
-				mayAddColumn(owner, column)
-				owner.AddForeignKey(&schema.ForeignKey{
-					RefTable:   ref,
-					OnDelete:   deleteAction(e, column),
-					Columns:    []*schema.Column{column},
-					RefColumns: []*schema.Column{ref.PrimaryKey[0]},
-					Symbol:     fkSymbol(e, owner, ref),
-				})
-			case M2M:
-				// If there is an edge schema for the association (i.e. edge.Through).
-				if e.Through != nil || e.Ref != nil && e.Ref.Through != nil {
-					continue
-				}
-				t1, t2 := tables[n.Table()], tables[e.Type.Table()]
-				c1 := &schema.Column{Name: e.Rel.Columns[0], Type: field.TypeInt, SchemaType: n.ID.def.SchemaType}
-				if ref := n.ID; ref.UserDefined {
-					c1.Type = ref.Type.Type
-					c1.Size = ref.size()
-				}
-				c2 := &schema.Column{Name: e.Rel.Columns[1], Type: field.TypeInt, SchemaType: e.Type.ID.def.SchemaType}
-				if ref := e.Type.ID; ref.UserDefined {
-					c2.Type = ref.Type.Type
-					c2.Size = ref.size()
-				}
-				s1, s2 := fkSymbols(e, c1, c2)
-				all = append(all, &schema.Table{
-					Name:       e.Rel.Table,
-					Columns:    []*schema.Column{c1, c2},
-					PrimaryKey: []*schema.Column{c1, c2},
-					ForeignKeys: []*schema.ForeignKey{
-						{
-							RefTable:   t1,
-							OnDelete:   schema.Cascade,
-							Columns:    []*schema.Column{c1},
-							RefColumns: []*schema.Column{t1.PrimaryKey[0]},
-							Symbol:     s1,
-						},
-						{
-							RefTable:   t2,
-							OnDelete:   schema.Cascade,
-							Columns:    []*schema.Column{c2},
-							RefColumns: []*schema.Column{t2.PrimaryKey[0]},
-							Symbol:     s2,
-						},
-					},
-				})
+			switch {
+			case e.IsInverse():
+			case e.HasCompositeColumns():
+				composite = append(composite, e)
+			default:
+				all = addRelation(tables, all, e)
 			}
 		}
+	}
+	// Append composite primary keys to tables after all
+	// columns were added (including relation columns).
+	for _, n := range g.Nodes {
 		if n.HasCompositeID() {
 			if err := addCompositePK(tables[n.Table()], n); err != nil {
 				return nil, err
 			}
 		}
 	}
-	// Append indexes to tables after all columns were added (including relation columns).
+	for _, e := range composite {
+		all = addRelation(tables, all, e)
+	}
 	for _, n := range g.Nodes {
 		table := tables[n.Table()]
 		for _, idx := range n.Indexes {
@@ -728,6 +1001,110 @@ func (g *Graph) Tables() (all []*schema.Table, err error) {
 	return
 }
 
+// addRelation adds the foreign keys or the join table of the given assoc edge to
+// the tables. The join tables of M2M edges are appended to the returned tables.
+func addRelation(tables map[string]*schema.Table, all []*schema.Table, e *Edge) []*schema.Table {
+	n := e.Owner
+	switch e.Rel.Type {
+	case O2O, O2M:
+		// The "owner" is the table that owns the relation (we set
+		// the foreign-key on) and "ref" is the referenced table.
+		owner, ref := tables[e.Rel.Table], tables[n.Table()]
+		columns := fkColumns(e, owner, ref.PrimaryKey)
+		for _, c := range columns {
+			// If it's not a circular reference (self-referencing table),
+			// and the inverse edge is required, make it non-nullable.
+			if n != e.Type && e.Ref != nil && !e.Ref.Optional {
+				c.Nullable = false
+			}
+			mayAddColumn(owner, c)
+		}
+		addFK(owner, ref, e, columns)
+	case M2O:
+		ref, owner := tables[e.Type.Table()], tables[e.Rel.Table]
+		columns := fkColumns(e, owner, ref.PrimaryKey)
+		for _, c := range columns {
+			// If it's not a circular reference (self-referencing table),
+			// and the edge is non-optional (required), make it non-nullable.
+			if n != e.Type && !e.Optional {
+				c.Nullable = false
+			}
+			mayAddColumn(owner, c)
+		}
+		addFK(owner, ref, e, columns)
+	case M2M:
+		// If there is an edge schema for the association (i.e. edge.Through).
+		if e.Through != nil || e.Ref != nil && e.Ref.Through != nil {
+			return all
+		}
+		t1, t2 := tables[n.Table()], tables[e.Type.Table()]
+		c1, c2 := m2mColumns(e, t1, t2)
+		s1, s2 := fkSymbols(e, c1[0], c2[0])
+		all = append(all, &schema.Table{
+			Name:       e.Rel.Table,
+			Columns:    append(c1, c2...),
+			PrimaryKey: append(c1[:len(c1):len(c1)], c2...),
+			ForeignKeys: []*schema.ForeignKey{
+				{
+					RefTable:   t1,
+					OnDelete:   schema.Cascade,
+					Columns:    c1,
+					RefColumns: t1.PrimaryKey,
+					Symbol:     s1,
+				},
+				{
+					RefTable:   t2,
+					OnDelete:   schema.Cascade,
+					Columns:    c2,
+					RefColumns: t2.PrimaryKey,
+					Symbol:     s2,
+				},
+			},
+		})
+	}
+	return all
+}
+
+// addFK adds the foreign key of the given edge to the owner table. Foreign keys that span
+// multiple columns of O2O relations are enforced using a unique index on their columns.
+func addFK(owner, ref *schema.Table, e *Edge, columns []*schema.Column) {
+	owner.AddForeignKey(&schema.ForeignKey{
+		RefTable:   ref,
+		OnDelete:   deleteAction(e, columns[0]),
+		Columns:    columns,
+		RefColumns: ref.PrimaryKey,
+		Symbol:     fkSymbol(e, owner, ref),
+	})
+	if len(columns) > 1 && e.Rel.Type == O2O {
+		owner.AddIndex(strings.Join(e.Rel.Columns, "_"), true, e.Rel.Columns)
+	}
+}
+
+// m2mColumns returns the columns of the join table of the given M2M edge. The first
+// columns reference the owner of the edge, and the second reference the other side.
+func m2mColumns(e *Edge, t1, t2 *schema.Table) (c1, c2 []*schema.Column) {
+	if !e.HasCompositeColumns() {
+		n := e.Owner
+		c1 := &schema.Column{Name: e.Rel.Columns[0], Type: field.TypeInt, SchemaType: n.ID.def.SchemaType}
+		if ref := n.ID; ref.UserDefined {
+			c1.Type = ref.Type.Type
+			c1.Size = ref.size()
+		}
+		c2 := &schema.Column{Name: e.Rel.Columns[1], Type: field.TypeInt, SchemaType: e.Type.ID.def.SchemaType}
+		if ref := e.Type.ID; ref.UserDefined {
+			c2.Type = ref.Type.Type
+			c2.Size = ref.size()
+		}
+		return []*schema.Column{c1}, []*schema.Column{c2}
+	}
+	columns := make([]*schema.Column, len(e.Rel.Columns))
+	for i, pk := range append(t1.PrimaryKey[:len(t1.PrimaryKey):len(t1.PrimaryKey)], t2.PrimaryKey...) {
+		columns[i] = &schema.Column{Name: e.Rel.Columns[i], Type: pk.Type, Size: pk.Size, SchemaType: pk.SchemaType}
+	}
+	n := len(t1.PrimaryKey)
+	return columns[:n:n], columns[n:]
+}
+
 // mayAddColumn adds the given column if it does not already exist in the table.
 func mayAddColumn(t *schema.Table, c *schema.Column) {
 	if !t.HasColumn(c.Name) {
@@ -735,13 +1112,24 @@ func mayAddColumn(t *schema.Table, c *schema.Column) {
 	}
 }
 
-// fkColumn returns the foreign key column for the given edge.
-func fkColumn(e *Edge, owner *schema.Table, refPK *schema.Column) *schema.Column {
+// fkColumns returns the foreign key columns for the given edge. Edges that reference
+// types with composite identifiers hold a column for each of the primary key columns.
+func fkColumns(e *Edge, owner *schema.Table, refPK []*schema.Column) []*schema.Column {
+	columns := make([]*schema.Column, len(e.Rel.Columns))
+	for i := range e.Rel.Columns {
+		columns[i] = fkColumn(e, e.Rel.Columns[i], owner, refPK[i])
+	}
+	return columns
+}
+
+// fkColumn returns the foreign key column with the given name for the given edge.
+func fkColumn(e *Edge, name string, owner *schema.Table, refPK *schema.Column) *schema.Column {
 	// If the foreign-key also functions as a primary key, it cannot be nullable.
-	ispk := len(owner.PrimaryKey) == 1 && owner.PrimaryKey[0].Name == e.Rel.Column()
-	column := &schema.Column{Name: e.Rel.Column(), Size: refPK.Size, Type: refPK.Type, SchemaType: refPK.SchemaType, Nullable: !ispk}
-	// O2O relations are enforced using a unique index.
-	column.Unique = e.Rel.Type == O2O
+	ispk := len(owner.PrimaryKey) == 1 && owner.PrimaryKey[0].Name == name
+	column := &schema.Column{Name: name, Size: refPK.Size, Type: refPK.Type, SchemaType: refPK.SchemaType, Nullable: !ispk}
+	// O2O relations are enforced using a unique index (or a
+	// unique index on all columns in case of composite keys).
+	column.Unique = e.Rel.Type == O2O && len(e.Rel.Columns) == 1
 	// Foreign key was defined as an edge field.
 	if e.Rel.fk != nil && e.Rel.fk.Field != nil {
 		fc := e.Rel.fk.Field.Column()
@@ -751,18 +1139,13 @@ func fkColumn(e *Edge, owner *schema.Table, refPK *schema.Column) *schema.Column
 }
 
 func addCompositePK(t *schema.Table, n *Type) error {
-	columns := make([]*schema.Column, 0, len(n.EdgeSchema.ID))
-	for _, id := range n.EdgeSchema.ID {
-		for _, f := range n.Fields {
-			if !f.IsEdgeField() || id != f {
-				continue
-			}
-			c, ok := t.Column(f.StorageKey())
-			if !ok {
-				return fmt.Errorf("missing column %q for edge field %q.%q", f.StorageKey(), n.Name, f.Name)
-			}
-			columns = append(columns, c)
+	columns := make([]*schema.Column, 0, len(n.CompositeID))
+	for _, f := range n.CompositeID {
+		c, ok := t.Column(f.StorageKey())
+		if !ok {
+			return fmt.Errorf("missing column %q for field %q.%q", f.StorageKey(), n.Name, f.Name)
 		}
+		columns = append(columns, c)
 	}
 	t.PrimaryKey = columns
 	return nil
@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/entc/load"
 	"github.com/jogly/ent/schema/edge"
 	"github.com/jogly/ent/schema/field"
@@ -258,6 +259,251 @@ func TestNewGraphThroughDuplicates(t *testing.T) {
 	require.EqualError(t, err, `entc/gen: resolving edges: edge User.groups defined with Through("group_edges", T1.Type), but schema User already has an edge named group_edges`)
 }
 
+func TestNewGraphEncryptedFields(t *testing.T) {
+	schema := &load.Schema{
+		Name: "User",
+		Fields: []*load.Field{
+			{Name: "name", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "ssn", Info: &field.TypeInfo{Type: field.TypeString}, Encryption: &field.Encryption{}},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, schema)
+	require.NoError(t, err)
+	require.True(t, graph.featureEnabled(FeatureEncrypt))
+	require.Equal(t, []*Field{graph.Nodes[0].Fields[1]}, graph.Nodes[0].EncryptedFields())
+
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, &load.Schema{Name: "User"})
+	require.NoError(t, err)
+	require.False(t, graph.featureEnabled(FeatureEncrypt))
+
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, schema)
+	require.Error(t, err, "encrypted fields are supported only by SQL")
+}
+
+func TestNewGraphCompositeID(t *testing.T) {
+	var (
+		tenant = &load.Schema{
+			Name: "Tenant",
+			Edges: []*load.Edge{
+				{Name: "accounts", Type: "Account"},
+			},
+		}
+		account = &load.Schema{
+			Name: "Account",
+			Fields: []*load.Field{
+				{Name: "tenant_id", Info: &field.TypeInfo{Type: field.TypeInt}},
+				{Name: "external_id", Info: &field.TypeInfo{Type: field.TypeString}},
+				{Name: "balance", Info: &field.TypeInfo{Type: field.TypeInt}, Optional: true},
+			},
+			Edges: []*load.Edge{
+				{Name: "tenant", Type: "Tenant", RefName: "accounts", Field: "tenant_id", Inverse: true, Unique: true, Required: true},
+			},
+			Annotations: map[string]any{
+				"Fields": field.ID("tenant_id", "external_id"),
+			},
+		}
+	)
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, tenant, account)
+	require.NoError(t, err)
+	typ := graph.Nodes[1]
+	require.True(t, typ.HasCompositeID())
+	require.False(t, typ.IsEdgeSchema())
+	require.Nil(t, typ.ID)
+	require.Equal(t, []*Field{typ.Fields[0], typ.Fields[1]}, typ.CompositeID)
+	tables, err := graph.Tables()
+	require.NoError(t, err)
+	require.Len(t, tables[1].PrimaryKey, 2)
+	require.Equal(t, "tenant_id", tables[1].PrimaryKey[0].Name)
+	require.Equal(t, "external_id", tables[1].PrimaryKey[1].Name)
+
+	account.Annotations["Fields"] = field.ID("tenant_id", "balance")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, tenant, account)
+	require.EqualError(t, err, "entc/gen: resolving composite identifiers: field Account.balance defined in the composite identifier cannot be optional or nillable")
+
+	account.Annotations["Fields"] = field.ID("tenant_id", "unknown")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, tenant, account)
+	require.EqualError(t, err, `entc/gen: resolving composite identifiers: field "unknown" defined in the composite identifier of type Account was not found`)
+
+	account.Annotations["Fields"] = field.ID("tenant_id", "external_id")
+	account.Edges = append(account.Edges, &load.Edge{Name: "groups", Type: "Group"})
+	tenant.Edges = append(tenant.Edges, &load.Edge{Name: "owner", Type: "Account", Unique: true})
+	group := &load.Schema{
+		Name: "Group",
+		Edges: []*load.Edge{
+			{Name: "accounts", Type: "Account", RefName: "groups", Inverse: true},
+		},
+	}
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, tenant, account, group)
+	require.NoError(t, err)
+	owner := graph.Nodes[0].Edges[1]
+	require.True(t, owner.HasCompositeColumns())
+	require.Equal(t, []string{"tenant_owner_tenant_id", "tenant_owner_external_id"}, owner.Rel.Columns)
+	fks, err := owner.ForeignKeys()
+	require.NoError(t, err)
+	require.Len(t, fks, 2)
+	require.Equal(t, fks, graph.Nodes[0].ForeignKeys)
+	require.Equal(t, "tenant_owner_external_id", fks[1].Column())
+	require.Equal(t, field.TypeString, fks[1].Field.Type.Type)
+	groups := graph.Nodes[1].Edges[1]
+	require.True(t, groups.HasCompositeColumns())
+	require.Equal(t, []string{"account_tenant_id", "account_external_id", "group_id"}, groups.Rel.Columns)
+	require.Equal(t, groups.Rel.Columns, groups.Ref.Rel.Columns)
+	tables, err = graph.Tables()
+	require.NoError(t, err)
+	require.Len(t, tables, 4)
+	require.Len(t, tables[0].ForeignKeys, 1)
+	require.Equal(t, tables[1].PrimaryKey, tables[0].ForeignKeys[0].RefColumns)
+	require.Len(t, tables[0].ForeignKeys[0].Columns, 2)
+	require.Equal(t, "tenant_owner_tenant_id", tables[0].ForeignKeys[0].Columns[0].Name)
+	require.Equal(t, field.TypeString, tables[0].ForeignKeys[0].Columns[1].Type)
+	join := tables[3]
+	require.Equal(t, "account_groups", join.Name)
+	require.Len(t, join.PrimaryKey, 3)
+	require.Len(t, join.ForeignKeys, 2)
+	require.Len(t, join.ForeignKeys[0].Columns, 2)
+	require.Equal(t, tables[1].PrimaryKey, join.ForeignKeys[0].RefColumns)
+
+	account.Edges[0].Field = ""
+	tenant.Edges[1].Field = "tenant_id"
+	tenant.Fields = []*load.Field{{Name: "tenant_id", Info: &field.TypeInfo{Type: field.TypeInt}, Optional: true}}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, tenant, account, group)
+	require.EqualError(t, err, "entc/gen: resolving composite identifiers: edge Tenant.owner cannot be defined with an edge field, as it references type Account with composite identifier")
+}
+
+func TestNewGraphVersion(t *testing.T) {
+	doc := &load.Schema{
+		Name: "Document",
+		Fields: []*load.Field{
+			{Name: "version", Info: &field.TypeInfo{Type: field.TypeInt64}},
+			{Name: "updated_at", Info: &field.TypeInfo{Type: field.TypeTime}},
+			{Name: "title", Info: &field.TypeInfo{Type: field.TypeString}},
+			{Name: "revision", Info: &field.TypeInfo{Type: field.TypeInt}, Optional: true},
+		},
+		Annotations: map[string]any{
+			"Fields": field.Version("version"),
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.NoError(t, err)
+	require.True(t, graph.HasVersionFields())
+	require.Equal(t, graph.Nodes[0].Fields[0], graph.Nodes[0].VersionField)
+
+	doc.Annotations["Fields"] = field.Version("updated_at")
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.NoError(t, err)
+	require.Equal(t, graph.Nodes[0].Fields[1], graph.Nodes[0].VersionField)
+	require.True(t, graph.Nodes[0].Fields[1].IsVersion())
+	require.False(t, graph.Nodes[0].Fields[0].IsVersion())
+	require.Equal(t, map[string]string{dialect.MySQL: "timestamp(6)"}, graph.Nodes[0].Fields[1].Column().SchemaType)
+
+	doc.Fields[1].SchemaType = map[string]string{dialect.MySQL: "datetime(6)", dialect.Postgres: "timestamp"}
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.NoError(t, err)
+	require.Equal(t, doc.Fields[1].SchemaType, graph.Nodes[0].Fields[1].Column().SchemaType)
+	doc.Fields[1].SchemaType = map[string]string{dialect.MySQL: "datetime"}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.EqualError(t, err, `entc/gen: resolving version fields: time version field Document.updated_at must be stored with microsecond precision, got mysql type: "datetime"`)
+	doc.Fields[1].SchemaType = map[string]string{dialect.Postgres: "timestamp(3)"}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.EqualError(t, err, `entc/gen: resolving version fields: time version field Document.updated_at must be stored with microsecond precision, got postgres type: "timestamp(3)"`)
+	doc.Fields[1].SchemaType = nil
+
+	doc.Annotations["Fields"] = field.Version("unknown")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.EqualError(t, err, `entc/gen: resolving version fields: version field "unknown" of type Document was not found`)
+
+	doc.Annotations["Fields"] = field.Version("title")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.EqualError(t, err, "entc/gen: resolving version fields: version field Document.title must be an integer or a time field, got: string")
+
+	doc.Annotations["Fields"] = field.Version("revision")
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.EqualError(t, err, "entc/gen: resolving version fields: version field Document.revision cannot be optional or nillable")
+
+	delete(doc.Annotations, "Fields")
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, doc)
+	require.NoError(t, err)
+	require.False(t, graph.HasVersionFields())
+}
+
+func TestGraph_HasDecimalFields(t *testing.T) {
+	order := &load.Schema{
+		Name: "Order",
+		Fields: []*load.Field{
+			{Name: "price", Info: &field.TypeInfo{Type: field.TypeFloat64}},
+		},
+	}
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order)
+	require.NoError(t, err)
+	require.False(t, graph.HasDecimalFields())
+
+	order.Fields = append(order.Fields, &load.Field{Name: "total", Info: &field.TypeInfo{Type: field.TypeDecimal}})
+	graph, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, order)
+	require.NoError(t, err)
+	require.True(t, graph.HasDecimalFields())
+}
+
+func TestNewGraphPolymorphic(t *testing.T) {
+	var (
+		post    = &load.Schema{Name: "Post"}
+		photo   = &load.Schema{Name: "Photo"}
+		comment = &load.Schema{
+			Name: "Comment",
+			Fields: []*load.Field{
+				{Name: "text", Info: &field.TypeInfo{Type: field.TypeString}},
+			},
+			Edges: []*load.Edge{
+				{Name: "target", Types: []string{"Post", "Photo"}, Unique: true},
+			},
+		}
+	)
+	graph, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, photo, comment)
+	require.NoError(t, err)
+	require.True(t, graph.featureEnabled(FeaturePolymorphic))
+	typ := graph.Nodes[2]
+	require.Empty(t, typ.Edges)
+	require.Len(t, typ.PolymorphicEdges, 1)
+	e := typ.PolymorphicEdges[0]
+	require.True(t, e.Optional)
+	require.Equal(t, []*Field{typ.Fields[1], typ.Fields[2]}, []*Field{e.TypeField, e.IDField})
+	require.Equal(t, "target_type", e.TypeField.Name)
+	require.Equal(t, []string{"Post", "Photo"}, e.TypeField.EnumValues())
+	require.True(t, e.TypeField.Optional && e.TypeField.Nillable)
+	require.Equal(t, "target_id", e.IDField.Name)
+	require.Equal(t, field.TypeInt, e.IDField.Type.Type)
+	require.Len(t, e.Targets, 2)
+	require.Equal(t, "target_photo", e.Targets[1].Name())
+	require.Equal(t, "TargetPhoto", e.Targets[1].StructField())
+	require.Equal(t, "TargetTypePhoto", e.Targets[1].EnumConstant())
+	tables, err := graph.Tables()
+	require.NoError(t, err)
+	require.Empty(t, tables[2].ForeignKeys)
+	require.Len(t, tables[2].Indexes, 1)
+	require.Equal(t, []string{"target_type", "target_id"}, []string{tables[2].Indexes[0].Columns[0].Name, tables[2].Indexes[0].Columns[1].Name})
+
+	photo.Fields = []*load.Field{{Name: "id", Info: &field.TypeInfo{Type: field.TypeString}}}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, photo, comment)
+	require.EqualError(t, err, "entc/gen: resolving polymorphic edges: polymorphic edge Comment.target requires all its types to share the same id type, but Post.id is int and Photo.id is string")
+
+	photo.Fields = nil
+	comment.Edges[0].Types = []string{"Post", "Post"}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, photo, comment)
+	require.EqualError(t, err, `entc/gen: polymorphic edge Comment.target contains type "Post" more than once`)
+
+	comment.Edges[0].Types = []string{"Post", "Unknown"}
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, photo, comment)
+	require.EqualError(t, err, `entc/gen: type "Unknown" does not exist for polymorphic edge Comment.target`)
+
+	comment.Edges[0].Types = []string{"Post", "Photo"}
+	comment.Fields = append(comment.Fields, &load.Field{Name: "target_id", Info: &field.TypeInfo{Type: field.TypeInt}})
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, post, photo, comment)
+	require.EqualError(t, err, `entc/gen: polymorphic edge Comment.target: field "target_id" redeclared for type "Comment"`)
+
+	comment.Fields = comment.Fields[:1]
+	_, err = NewGraph(&Config{Package: "entc/gen", Storage: drivers[1]}, post, photo, comment)
+	require.EqualError(t, err, `entc/gen: resolving polymorphic edges: polymorphic edge Comment.target is not supported by storage driver "gremlin"`)
+}
+
 func TestRelation(t *testing.T) {
 	require := require.New(t)
 	_, err := NewGraph(&Config{Package: "entc/gen", Storage: drivers[0]}, T1)
@@ -101,6 +101,8 @@ var (
 			ExtendPatterns: []string{
 				"client/fields/additional/*",
 				"dialect/*/query/fields/init/*",
+				"dialect/*/client/get/*",
+				"dialect/*/client/additional/*",
 			},
 		},
 		{
@@ -178,7 +180,9 @@ var (
 		"dialect/*/import/additional/*",
 		"dialect/*/query/selector/*",
 		"dialect/sql/create/additional/*",
+		"dialect/sql/create/save/*",
 		"dialect/sql/create_bulk/additional/*",
+		"dialect/sql/group/additional/*",
 		"dialect/sql/model/additional/*",
 		"dialect/sql/model/edges/*",
 		"dialect/sql/model/edges/fields/additional/*",
@@ -190,6 +194,7 @@ var (
 		"dialect/sql/query/from/*",
 		"dialect/sql/query/path/*",
 		"dialect/sql/query/*/*/*",
+		"dialect/sql/update/node/*",
 		"import/additional/*",
 		"model/additional/*",
 		"model/comment/additional/*",
@@ -22,6 +22,7 @@ import (
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/entsql"
 	"github.com/jogly/ent/dialect/sql/schema"
+	"github.com/jogly/ent/dialect/sql/sqlencrypt"
 	"github.com/jogly/ent/entc/load"
 	"github.com/jogly/ent/schema/edge"
 	"github.com/jogly/ent/schema/field"
@@ -47,6 +48,8 @@ type (
 		fields map[string]*Field
 		// Edge holds all the edges of this type.
 		Edges []*Edge
+		// PolymorphicEdges holds the polymorphic edges of this type.
+		PolymorphicEdges []*PolymorphicEdge
 		// Indexes are the configured indexes for this type.
 		Indexes []*Index
 		// ForeignKeys are the foreign-keys that resides in the type table.
@@ -55,6 +58,14 @@ type (
 		// Annotations that were defined for the field in the schema.
 		// The mapping is from the Annotation.Name() to a JSON decoded object.
 		Annotations Annotations
+		// CompositeID holds the fields of the type identifier in case it is
+		// defined by multiple fields (composite primary key). In this case,
+		// the ID field is nil.
+		CompositeID []*Field
+		// VersionField holds the field that is used as the concurrency token of
+		// the type (optimistic locking), in case it was defined using the
+		// field.Version annotation.
+		VersionField *Field
 		// EdgeSchema indicates that this type (schema) is being used as an "edge schema".
 		// The To and From fields holds references to the edges that go "through" this type.
 		EdgeSchema struct {
@@ -63,6 +74,43 @@ type (
 		}
 	}
 
+	// PolymorphicEdge represents a unique edge that can point to a node of one of
+	// multiple types. The edge is stored in two fields of its owner type: the type
+	// of the neighbor (discriminator) and its identifier.
+	PolymorphicEdge struct {
+		def *load.Edge
+		// Name holds the name of the edge.
+		Name string
+		// Owner holds the type of the edge owner.
+		Owner *Type
+		// Optional indicates is this edge is optional on create.
+		Optional bool
+		// Immutable indicates is this edge cannot be updated.
+		Immutable bool
+		// TypeField holds the enum field that stores the type of the neighbor.
+		TypeField *Field
+		// IDField holds the field that stores the identifier of the neighbor.
+		IDField *Field
+		// Targets holds the types this edge can point to.
+		Targets []*PolymorphicTarget
+		// Annotations that were defined for the edge in the schema.
+		// The mapping is from the Annotation.Name() to a JSON decoded object.
+		Annotations Annotations
+	}
+
+	// PolymorphicTarget represents one of the types a polymorphic edge can point to.
+	PolymorphicTarget struct {
+		// Edge holds the polymorphic edge of this target.
+		Edge *PolymorphicEdge
+		// Type holds the type of the target.
+		Type *Type
+		// Value holds the value that is stored in the
+		// discriminator field for this target.
+		Value string
+		// Index of the target in the edge targets.
+		Index int
+	}
+
 	// Field holds the information of a type field used for the templates.
 	Field struct {
 		cfg *Config
@@ -159,6 +207,9 @@ type (
 		Columns []string
 		// foreign-key information for non-M2M edges.
 		fk *ForeignKey
+		// foreign-keys of relations that reference types with composite
+		// identifiers, a foreign-key for each of the relation columns.
+		fks []*ForeignKey
 	}
 
 	// Index represents a database index used for either increasing speed
@@ -195,6 +246,9 @@ type (
 		//		Field("owner_id")
 		//
 		UserDefined bool
+		// column holds the name of the foreign-key column in case it is
+		// one of the columns of a multi-column (composite) foreign-key.
+		column string
 	}
 	// Enum holds the enum information for schema enums in codegen.
 	Enum struct {
@@ -203,6 +257,19 @@ type (
 		// Value in the schema.
 		Value string
 	}
+	// JSONPath represents a path to a value that is nested in a JSON field
+	// whose Go type is a struct. Used to generate typed predicates for JSON
+	// fields. For example, "MetaLimitsMaxGT" for the "limits.max" path.
+	JSONPath struct {
+		// Name is the Go name of the path. e.g. LimitsMax.
+		Name string
+		// Keys holds the JSON keys of the path. e.g. ["limits", "max"].
+		Keys []string
+		// Type holds the Go type information of the value.
+		Type *field.RType
+		// The JSON field this path belongs to.
+		field *Field
+	}
 )
 
 // NewType creates a new type and its fields from the given schema.
@@ -277,7 +344,7 @@ func (t Type) IsEdgeSchema() bool {
 
 // HasCompositeID indicates if the type has a composite ID field.
 func (t Type) HasCompositeID() bool {
-	return t.IsEdgeSchema() && len(t.EdgeSchema.ID) > 1
+	return len(t.CompositeID) > 1
 }
 
 // HasOneFieldID indicates if the type has an ID with one field (not composite).
@@ -285,6 +352,15 @@ func (t Type) HasOneFieldID() bool {
 	return !t.HasCompositeID()
 }
 
+// IDType returns the type of the identifier of the type, as used by the edges that point to it.
+// For types with a composite identifier, it is the ID struct that is defined in their package.
+func (t Type) IDType() *field.TypeInfo {
+	if t.HasOneFieldID() {
+		return t.ID.Type
+	}
+	return &field.TypeInfo{Type: field.TypeOther, Ident: t.Package() + ".ID"}
+}
+
 // Label returns Gremlin label name of the node/type.
 func (t Type) Label() string {
 	return snake(t.Name)
@@ -433,11 +509,45 @@ func (t Type) FKEdges() (edges []*Edge) {
 	return
 }
 
-// EdgesWithID returns all edges that point to entities with non-composite identifiers.
-// These types of edges can be created, updated and deleted by their identifiers.
+// RecursiveEdges returns all edges that point back to the type itself, such as "children"
+// or "friends", and can be traversed recursively. Types with composite identifiers are skipped.
+func (t Type) RecursiveEdges() (edges []*Edge) {
+	if !t.HasOneFieldID() {
+		return nil
+	}
+	for _, e := range t.Edges {
+		if e.Type.Name == t.Name {
+			edges = append(edges, e)
+		}
+	}
+	return
+}
+
+// TreeEdge returns the recursive O2M edge of a type that forms a tree, such as "children",
+// in case the type has exactly one such edge and it has an inverse edge (e.g. "parent").
+// It returns nil if the type defines an edge named "ancestors" or "descendants".
+func (t Type) TreeEdge() *Edge {
+	var tree []*Edge
+	for _, e := range t.RecursiveEdges() {
+		switch {
+		case e.Name == "ancestors" || e.Name == "descendants":
+			return nil
+		case e.Rel.Type == O2M && !e.IsInverse() && e.Ref != nil:
+			tree = append(tree, e)
+		}
+	}
+	if len(tree) != 1 {
+		return nil
+	}
+	return tree[0]
+}
+
+// EdgesWithID returns all edges that point to entities with identifiers, i.e. all edges
+// except the ones that point to edge schemas with composite identifiers. These types
+// of edges can be created, updated and deleted by their identifiers.
 func (t Type) EdgesWithID() (edges []*Edge) {
 	for _, e := range t.Edges {
-		if !e.Type.HasCompositeID() {
+		if !e.Type.IsEdgeSchema() || !e.Type.HasCompositeID() {
 			edges = append(edges, e)
 		}
 	}
@@ -560,6 +670,17 @@ func (t Type) ImmutableFields() []*Field {
 	return fields
 }
 
+// EncryptedFields returns all the fields that are encrypted at rest.
+func (t Type) EncryptedFields() []*Field {
+	var fields []*Field
+	for _, f := range t.Fields {
+		if f.IsEncrypted() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
 // MutationFields returns all the fields that are available on the typed-mutation.
 func (t Type) MutationFields() []*Field {
 	fields := make([]*Field, 0, len(t.Fields))
@@ -857,11 +978,20 @@ func (t Type) SiblingImports() []struct{ Alias, Path string } {
 		imports = []struct{ Alias, Path string }{{Alias: t.PackageAlias(), Path: path.Join(t.Config.Package, t.PackageDir())}}
 		seen    = map[string]bool{imports[0].Path: true}
 	)
+	types := make([]*Type, 0, len(t.Edges))
 	for _, e := range t.Edges {
-		p := path.Join(t.Config.Package, e.Type.PackageDir())
+		types = append(types, e.Type)
+	}
+	for _, e := range t.PolymorphicEdges {
+		for _, pt := range e.Targets {
+			types = append(types, pt.Type)
+		}
+	}
+	for _, typ := range types {
+		p := path.Join(t.Config.Package, typ.PackageDir())
 		if !seen[p] {
 			seen[p] = true
-			imports = append(imports, struct{ Alias, Path string }{Alias: e.Type.PackageAlias(), Path: p})
+			imports = append(imports, struct{ Alias, Path string }{Alias: typ.PackageAlias(), Path: p})
 		}
 	}
 	return imports
@@ -1237,6 +1367,9 @@ func (f Field) IsJSON() bool { return f.Type != nil && f.Type.Type == field.Type
 // IsOther returns true if the field is an Other field.
 func (f Field) IsOther() bool { return f.Type != nil && f.Type.Type == field.TypeOther }
 
+// IsDecimal returns true if the field is a decimal field.
+func (f Field) IsDecimal() bool { return f.Type != nil && f.Type.Type == field.TypeDecimal }
+
 // IsString returns true if the field is a string field.
 func (f Field) IsString() bool { return f.Type != nil && f.Type.Type == field.TypeString }
 
@@ -1270,6 +1403,19 @@ func (f Field) Edge() (*Edge, error) {
 // Sensitive returns true if the field is a sensitive field.
 func (f Field) Sensitive() bool { return f.def != nil && f.def.Sensitive }
 
+// IsEncrypted returns true if the field is encrypted at rest.
+func (f Field) IsEncrypted() bool { return f.def != nil && f.def.Encryption != nil }
+
+// IsDeterministicEncrypted returns true if the field is encrypted in deterministic mode.
+func (f Field) IsDeterministicEncrypted() bool {
+	return f.IsEncrypted() && f.def.Encryption.Deterministic
+}
+
+// IsVersion reports if the field is the version field (concurrency token) of its type.
+func (f Field) IsVersion() bool {
+	return f.typ != nil && f.typ.VersionField != nil && f.typ.VersionField.Name == f.Name
+}
+
 // Comment returns the comment of the field,
 func (f Field) Comment() string {
 	if f.def != nil {
@@ -1435,6 +1581,29 @@ func (f Field) Column() *schema.Column {
 	if f.def != nil {
 		c.SchemaType = f.def.SchemaType
 	}
+	// Time versions are stored with microsecond precision, as they are truncated to it
+	// by the builders. In MySQL, TIMESTAMP columns have no fractional seconds by default.
+	if f.IsVersion() && f.IsTime() && c.SchemaType[dialect.MySQL] == "" {
+		types := make(map[string]string, len(c.SchemaType)+1)
+		for k, v := range c.SchemaType {
+			types[k] = v
+		}
+		types[dialect.MySQL] = "timestamp(6)"
+		c.SchemaType = types
+	}
+	// Encrypted columns store the ciphertexts of the values. Hence, their size is
+	// the size of the ciphertext of the largest value (255 bytes by default for
+	// strings). Unsized bytes fields are stored in BLOB columns anyway.
+	if f.IsEncrypted() && (c.Size > 0 || f.IsString()) {
+		size, mode := c.Size, sqlencrypt.Randomized
+		if size == 0 {
+			size = schema.DefaultStringLen
+		}
+		if f.IsDeterministicEncrypted() {
+			mode = sqlencrypt.Deterministic
+		}
+		c.Size = sqlencrypt.CiphertextSize(size, mode, f.IsString())
+	}
 	return c
 }
 
@@ -1707,12 +1876,107 @@ func (f Field) enums(lf *load.Field) ([]Enum, error) {
 // Ops returns all predicate operations of the field.
 func (f *Field) Ops() []Op {
 	ops := fieldOps(f)
-	if f.Name != "id" && f.cfg != nil && f.cfg.Storage.Ops != nil {
+	// Storage specific operations cannot be applied on encrypted values.
+	if f.Name != "id" && !f.IsEncrypted() && f.cfg != nil && f.cfg.Storage.Ops != nil {
 		ops = append(ops, f.cfg.Storage.Ops(f)...)
 	}
 	return ops
 }
 
+// JSONPaths returns the typed paths of a JSON field whose Go type is a struct.
+// Paths to values that cannot be compared in the database are omitted.
+func (f Field) JSONPaths() []*JSONPath {
+	if !f.IsJSON() || f.Type.RType == nil {
+		return nil
+	}
+	return f.jsonPaths(f.Type.RType.Fields, "", nil)
+}
+
+func (f *Field) jsonPaths(fields []*field.RTypeField, prefix string, keys []string) (paths []*JSONPath) {
+	for _, sf := range fields {
+		p := &JSONPath{
+			Name:  prefix + sf.Name,
+			Keys:  append(append(make([]string, 0, len(keys)+1), keys...), sf.Key),
+			Type:  sf.Type,
+			field: f,
+		}
+		switch {
+		case len(sf.Type.Fields) > 0:
+			paths = append(paths, f.jsonPaths(sf.Type.Fields, p.Name, p.Keys)...)
+		case len(p.Ops()) > 0:
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// StructField returns the Go name of the path, prefixed with the field name.
+func (p JSONPath) StructField() string { return p.field.StructField() + p.Name }
+
+// Field returns the JSON field this path belongs to.
+func (p JSONPath) Field() *Field { return p.field }
+
+// DotPath returns the path in dot format. e.g. "limits.max".
+func (p JSONPath) DotPath() string { return strings.Join(p.Keys, ".") }
+
+// IsList reports if the path points to a JSON array.
+func (p JSONPath) IsList() bool {
+	return p.Type.Kind == reflect.Slice || p.Type.Kind == reflect.Array
+}
+
+// IsString reports if the path points to a JSON string value.
+func (p JSONPath) IsString() bool { return p.value().Kind == reflect.String }
+
+// Ops returns the predicate operations supported by the path.
+func (p JSONPath) Ops() []Op {
+	switch k := p.value().Kind; {
+	// Byte slices are encoded as base64 strings.
+	case p.IsList() && k == reflect.Uint8:
+		return nil
+	case p.IsList() && (k == reflect.Bool || k == reflect.String || k >= reflect.Int && k <= reflect.Float64):
+		return []Op{Contains}
+	case p.IsList():
+		return nil
+	case k == reflect.Bool:
+		return boolOps
+	case k == reflect.String:
+		return stringOps
+	case k >= reflect.Int && k <= reflect.Float64:
+		return numericOps
+	default:
+		return nil
+	}
+}
+
+// ArgType returns the Go type of the predicates' argument. Named types
+// that are defined outside the package of the JSON field are replaced
+// with their basic type to avoid importing additional packages.
+func (p JSONPath) ArgType() string {
+	switch t := p.value(); {
+	case t.PkgPath == "" && t.Name != "", t.PkgPath == p.field.Type.PkgPath:
+		return t.Ident
+	default:
+		return t.Kind.String()
+	}
+}
+
+// BasicType returns a Go expression for converting the given identifier
+// to its basic type. Named types are not recognized by the sqljson package.
+func (p JSONPath) BasicType(ident string) string {
+	if k := p.value().Kind.String(); p.ArgType() != k {
+		return fmt.Sprintf("%s(%s)", k, ident)
+	}
+	return ident
+}
+
+// value returns the type of the value the predicates are applied on.
+func (p JSONPath) value() *field.RType {
+	if p.IsList() && p.Type.Elem != nil {
+		return p.Type.Elem
+	}
+	return p.Type
+}
+
 // Label returns the Gremlin label name of the edge.
 // If the edge is inverse
 func (e Edge) Label() string {
@@ -1768,6 +2032,10 @@ func (e Edge) ColumnConstant() string { return pascal(e.Name) + "Column" }
 // PKConstant returns the constant name of the primary key. Used for M2M edges.
 func (e Edge) PKConstant() string { return pascal(e.Name) + "PrimaryKey" }
 
+// ColumnsConstant returns the variable name of the relation columns.
+// Used for edges that are stored in multi-column foreign-keys.
+func (e Edge) ColumnsConstant() string { return pascal(e.Name) + "Columns" }
+
 // HasConstraint indicates if this edge has a unique constraint check.
 // We check uniqueness when both-directions are unique or one of them.
 // Used by the Gremlin storage-layer.
@@ -1809,6 +2077,29 @@ func (e Edge) OwnFK() bool {
 	return false
 }
 
+// ForeignKeys returns the foreign-keys of the edge. Edges that reference a type with
+// a composite identifier are stored in a foreign-key column for each identifier field.
+func (e *Edge) ForeignKeys() ([]*ForeignKey, error) {
+	if len(e.Rel.fks) > 0 {
+		return e.Rel.fks, nil
+	}
+	fk, err := e.ForeignKey()
+	if err != nil {
+		return nil, err
+	}
+	return []*ForeignKey{fk}, nil
+}
+
+// HasCompositeColumns reports if the relation of the edge spans multiple columns on one of its sides,
+// because it references a type with a composite identifier. i.e. a multi-column foreign-key, or join
+// table columns that reference the composite identifier.
+func (e Edge) HasCompositeColumns() bool {
+	if e.M2M() {
+		return len(e.Rel.Columns) > 2
+	}
+	return len(e.Rel.Columns) > 1
+}
+
 // ForeignKey returns the foreign-key of the inverse-field.
 func (e *Edge) ForeignKey() (*ForeignKey, error) {
 	if e.Rel.fk != nil {
@@ -1952,6 +2243,64 @@ func (e Edge) EntSQL() *entsql.Annotation {
 	return sqlAnnotate(e.Annotations)
 }
 
+// StructField returns the struct member of the polymorphic edge in the model.
+func (e PolymorphicEdge) StructField() string {
+	return pascal(e.Name)
+}
+
+// LoadedField returns the struct member (of the model edges) that tracks
+// which targets of the polymorphic edge were eager-loaded.
+func (e PolymorphicEdge) LoadedField() string {
+	return "loaded" + e.StructField()
+}
+
+// Comment returns the comment of the edge.
+func (e PolymorphicEdge) Comment() string {
+	return e.def.Comment
+}
+
+// Name returns the name of the edge that connects the owner to this target.
+// For example, "target_post" for the "Post" target of the "target" edge.
+func (t PolymorphicTarget) Name() string {
+	return t.Edge.Name + "_" + snake(t.Type.Name)
+}
+
+// StructField returns the struct member of the target in the model edges.
+func (t PolymorphicTarget) StructField() string {
+	return pascal(t.Name())
+}
+
+// Constant returns the constant name of the target edge.
+func (t PolymorphicTarget) Constant() string {
+	return "Edge" + t.StructField()
+}
+
+// InverseTableConstant returns the constant name of the target table.
+func (t PolymorphicTarget) InverseTableConstant() string {
+	return t.StructField() + "InverseTable"
+}
+
+// FieldIDConstant returns the constant name of the target id column.
+func (t PolymorphicTarget) FieldIDConstant() string {
+	return t.StructField() + "FieldID"
+}
+
+// EagerLoadField returns the struct field (of query builder)
+// for storing the eager-loading info of the target.
+func (t PolymorphicTarget) EagerLoadField() string {
+	return "with" + t.StructField()
+}
+
+// EnumConstant returns the constant name of the discriminator value.
+func (t PolymorphicTarget) EnumConstant() string {
+	return t.Edge.TypeField.EnumName(t.Value)
+}
+
+// StructTag returns the struct tag of the target in the model edges.
+func (t PolymorphicTarget) StructTag() string {
+	return structTag(t.Name(), "")
+}
+
 // Column returns the first element from the columns slice.
 func (r Relation) Column() string {
 	if len(r.Columns) == 0 {
@@ -1960,6 +2309,20 @@ func (r Relation) Column() string {
 	return r.Columns[0]
 }
 
+// Column returns the name of the foreign-key column.
+func (f ForeignKey) Column() string {
+	if f.column != "" {
+		return f.column
+	}
+	return f.Edge.Rel.Column()
+}
+
+// Composite reports if the foreign-key column is one of the
+// columns of a multi-column (composite) foreign-key.
+func (f ForeignKey) Composite() bool {
+	return f.column != ""
+}
+
 // StructField returns the struct member of the foreign-key in the generated model.
 func (f ForeignKey) StructField() string {
 	if f.UserDefined {
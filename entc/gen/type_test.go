@@ -5,8 +5,10 @@
 package gen
 
 import (
+	"net/url"
 	"testing"
 
+	"github.com/jogly/ent/dialect/sql/sqlencrypt"
 	"github.com/jogly/ent/entc/load"
 	"github.com/jogly/ent/schema/field"
 
@@ -296,6 +298,79 @@ func TestField_incremental(t *testing.T) {
 	}
 }
 
+func TestField_Decimal(t *testing.T) {
+	f := &Field{Name: "price", Type: field.Decimal("price").Descriptor().Info}
+	require.True(t, f.IsDecimal())
+	require.True(t, f.HasGoType())
+	require.True(t, f.SupportsMutationAdd())
+	require.Equal(t, numericOps, fieldOps(f))
+	expr, err := f.MutationAddAssignExpr("a", "b")
+	require.NoError(t, err)
+	require.Equal(t, "*a = a.Add(b)", expr)
+}
+
+func TestField_Encrypted(t *testing.T) {
+	f := &Field{typ: &Type{}, Name: "ssn", Type: &field.TypeInfo{Type: field.TypeString}, Optional: true, def: &load.Field{Encryption: &field.Encryption{}}}
+	require.True(t, f.IsEncrypted())
+	require.False(t, f.IsDeterministicEncrypted())
+	require.Equal(t, nillableOps, fieldOps(f))
+	require.Equal(t, sqlencrypt.CiphertextSize(255, sqlencrypt.Randomized, true), f.Column().Size)
+
+	f = &Field{typ: &Type{}, Name: "email", Type: &field.TypeInfo{Type: field.TypeString}, def: &load.Field{Encryption: &field.Encryption{Deterministic: true}}}
+	require.True(t, f.IsEncrypted())
+	require.True(t, f.IsDeterministicEncrypted())
+	require.Equal(t, enumOps, fieldOps(f))
+	require.Equal(t, int64(724), f.Column().Size)
+
+	size := int64(100)
+	f = &Field{typ: &Type{}, Name: "key", Type: &field.TypeInfo{Type: field.TypeBytes}, def: &load.Field{Size: &size, Encryption: &field.Encryption{}}}
+	require.Equal(t, int64(446), f.Column().Size)
+	f = &Field{typ: &Type{}, Name: "blob", Type: &field.TypeInfo{Type: field.TypeBytes}, def: &load.Field{Encryption: &field.Encryption{}}}
+	require.Zero(t, f.Column().Size)
+
+	f = &Field{Name: "name", Type: &field.TypeInfo{Type: field.TypeString}}
+	require.False(t, f.IsEncrypted())
+	require.Equal(t, stringOps, fieldOps(f))
+}
+
+func TestField_JSONPaths(t *testing.T) {
+	type (
+		Plan   string
+		Limits struct {
+			Max int `json:"max"`
+		}
+		Meta struct {
+			Plan   Plan              `json:"plan"`
+			Limits Limits            `json:"limits"`
+			Tags   []string          `json:"tags"`
+			Data   []byte            `json:"data"`
+			Attrs  map[string]string `json:"attrs"`
+			Remote url.URL           `json:"-"`
+		}
+	)
+	f := &Field{Name: "meta", Type: field.JSON("meta", Meta{}).Descriptor().Info}
+	paths := f.JSONPaths()
+	require.Len(t, paths, 3)
+	require.Equal(t, "MetaPlan", paths[0].StructField())
+	require.Equal(t, "gen.Plan", paths[0].ArgType())
+	require.Equal(t, "string(v)", paths[0].BasicType("v"))
+	require.Equal(t, stringOps, paths[0].Ops())
+	require.Equal(t, "MetaLimitsMax", paths[1].StructField())
+	require.Equal(t, "limits.max", paths[1].DotPath())
+	require.Equal(t, "int", paths[1].ArgType())
+	require.Equal(t, "v", paths[1].BasicType("v"))
+	require.Equal(t, numericOps, paths[1].Ops())
+	require.Equal(t, "MetaTags", paths[2].StructField())
+	require.True(t, paths[2].IsList())
+	require.Equal(t, "string", paths[2].ArgType())
+	require.Equal(t, []Op{Contains}, paths[2].Ops())
+
+	f = &Field{Name: "dirs", Type: field.Strings("dirs").Descriptor().Info}
+	require.Empty(t, f.JSONPaths())
+	f = &Field{Name: "name", Type: &field.TypeInfo{Type: field.TypeString}}
+	require.Empty(t, f.JSONPaths())
+}
+
 func TestBuilderField(t *testing.T) {
 	tests := []struct {
 		name  string
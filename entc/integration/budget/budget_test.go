@@ -0,0 +1,123 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package budget
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+	entsql "github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/dialect/sql/sqlbudget"
+	"github.com/jogly/ent/entc/integration/budget/ent"
+	"github.com/jogly/ent/entc/integration/budget/ent/enttest"
+	"github.com/jogly/ent/entc/integration/budget/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget(t *testing.T) {
+	drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(sqlbudget.NewDriver(drv))))
+	defer client.Close()
+	client.Intercept(ent.BudgetInterceptor())
+	client.Use(ent.BudgetHook())
+	ctx := context.Background()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	users := make([]*ent.UserCreate, 9)
+	for i := range users {
+		users[i] = client.User.Create().SetName("user")
+	}
+	client.User.CreateBulk(users...).ExecX(ctx)
+	events := make([]*ent.EventCreate, 10)
+	for i := range events {
+		events[i] = client.Event.Create().SetName("event").SetUser(a8m)
+	}
+	client.Event.CreateBulk(events...).ExecX(ctx)
+	client.Audit.Create().SetMessage("created").ExecX(ctx)
+
+	exceeded := func(t *testing.T, err error, check sqlbudget.Check, typ string, limit int) {
+		t.Helper()
+		var e *sqlbudget.ExceededError
+		require.True(t, errors.As(err, &e), "expect budget error, got: %v", err)
+		require.Equal(t, check, e.Check)
+		require.Equal(t, typ, e.Type)
+		require.Equal(t, limit, e.Limit)
+	}
+
+	t.Run("Rows", func(t *testing.T) {
+		// Types without a schema limit are not limited by default.
+		require.Len(t, client.User.Query().AllX(ctx), 10)
+
+		ctx := sqlbudget.WithLimits(ctx, sqlbudget.Limits{MaxRows: 5})
+		_, err := client.User.Query().All(ctx)
+		exceeded(t, err, sqlbudget.CheckRows, ent.TypeUser, 5)
+		_, err = client.User.Query().IDs(ctx)
+		exceeded(t, err, sqlbudget.CheckRows, ent.TypeUser, 5)
+		require.Len(t, client.User.Query().Limit(5).AllX(ctx), 5)
+		require.Len(t, client.User.Query().Limit(3).IDsX(ctx), 3)
+		require.Len(t, client.User.Query().Where(user.Name("a8m")).AllX(ctx), 1)
+		require.Equal(t, 10, client.User.Query().CountX(ctx), "count queries are not limited")
+
+		// The limit of a query is restored after its execution.
+		query := client.User.Query()
+		_, err = query.All(ctx)
+		require.True(t, sqlbudget.IsExceeded(err))
+		require.Len(t, query.AllX(sqlbudget.Override(ctx, sqlbudget.CheckRows)), 10)
+
+		// Eager-loaded edges are limited per parent node, and therefore are not checked.
+		u := client.User.Query().Where(user.ID(a8m.ID)).WithEvents().OnlyX(ctx)
+		require.Len(t, u.Edges.Events, 10)
+	})
+
+	t.Run("SchemaRows", func(t *testing.T) {
+		_, err := client.Event.Query().All(ctx)
+		exceeded(t, err, sqlbudget.CheckRows, ent.TypeEvent, 5)
+		require.Len(t, client.Event.Query().Limit(5).AllX(ctx), 5)
+		require.Len(t, a8m.QueryEvents().Limit(2).AllX(ctx), 2)
+		_, err = a8m.QueryEvents().IDs(ctx)
+		exceeded(t, err, sqlbudget.CheckRows, ent.TypeEvent, 5)
+
+		// The limit of the request takes precedence over the schema.
+		require.Len(t, client.Event.Query().AllX(sqlbudget.WithLimits(ctx, sqlbudget.Limits{MaxRows: 20})), 10)
+		_, err = client.Event.Query().All(sqlbudget.WithLimits(ctx, sqlbudget.Limits{MaxRows: 2}))
+		exceeded(t, err, sqlbudget.CheckRows, ent.TypeEvent, 2)
+		require.Len(t, client.Event.Query().AllX(sqlbudget.Override(ctx)), 10)
+	})
+
+	t.Run("Unbounded", func(t *testing.T) {
+		err := client.User.Update().SetName("unbounded").Exec(ctx)
+		exceeded(t, err, sqlbudget.CheckUnbounded, ent.TypeUser, 0)
+		_, err = client.Event.Delete().Exec(ctx)
+		exceeded(t, err, sqlbudget.CheckUnbounded, ent.TypeEvent, 0)
+		require.Equal(t, 10, client.Event.Query().CountX(ctx))
+
+		require.Equal(t, 9, client.User.Update().Where(user.Name("user")).SetName("bounded").SaveX(ctx))
+		client.User.UpdateOne(a8m).SetName("a8m").ExecX(ctx)
+		client.Audit.Update().SetMessage("updated").ExecX(ctx)
+		require.Equal(t, 1, client.Audit.Delete().ExecX(ctx), "schema allows unbounded mutations")
+		require.Equal(t, 10, client.Event.Delete().ExecX(sqlbudget.Override(ctx, sqlbudget.CheckUnbounded)))
+	})
+
+	t.Run("Statements", func(t *testing.T) {
+		ctx := sqlbudget.WithLimits(ctx, sqlbudget.Limits{MaxStatements: 2})
+		client.User.Query().CountX(ctx)
+		client.User.Query().Where(user.Name("a8m")).OnlyX(ctx)
+		_, err := client.User.Query().Count(ctx)
+		exceeded(t, err, sqlbudget.CheckStatements, "", 2)
+		_, err = client.User.Query().Count(sqlbudget.Override(ctx, sqlbudget.CheckStatements))
+		require.NoError(t, err)
+
+		// Statements are counted from scratch in a new request.
+		ctx = sqlbudget.WithLimits(context.Background(), sqlbudget.Limits{MaxStatements: 1})
+		client.User.Query().CountX(ctx)
+		_, err = client.User.Query().Count(ctx)
+		require.True(t, sqlbudget.IsExceeded(err))
+	})
+}
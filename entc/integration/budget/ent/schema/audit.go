@@ -0,0 +1,31 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect/sql/sqlbudget"
+	"github.com/jogly/ent/schema"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Audit holds the schema definition for the Audit entity.
+type Audit struct {
+	ent.Schema
+}
+
+// Fields of the Audit.
+func (Audit) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("message"),
+	}
+}
+
+// Annotations of the Audit.
+func (Audit) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		sqlbudget.Annotation{AllowUnbounded: true},
+	}
+}
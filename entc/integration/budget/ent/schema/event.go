@@ -0,0 +1,41 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/dialect/sql/sqlbudget"
+	"github.com/jogly/ent/schema"
+	"github.com/jogly/ent/schema/edge"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Event holds the schema definition for the Event entity.
+type Event struct {
+	ent.Schema
+}
+
+// Fields of the Event.
+func (Event) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+	}
+}
+
+// Edges of the Event.
+func (Event) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("events").
+			Unique(),
+	}
+}
+
+// Annotations of the Event.
+func (Event) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		sqlbudget.Annotation{MaxRows: 5},
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+	entsql "github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/dialect/sql/sqlcache"
+	"github.com/jogly/ent/entc/integration/cache/ent"
+	"github.com/jogly/ent/entc/integration/cache/ent/enttest"
+	"github.com/jogly/ent/entc/integration/cache/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCache(t *testing.T) {
+	db, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	// queries counts the SELECT queries that were executed in the database.
+	var queries int
+	drv := sqlcache.NewDriver(dialect.Debug(db, func(v ...any) {
+		if strings.Contains(fmt.Sprint(v...), "Query: query=SELECT") {
+			queries++
+		}
+	}))
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	defer client.Close()
+	client.Use(ent.CacheHook(drv.Store()))
+	ctx := context.Background()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+
+	t.Run("Get", func(t *testing.T) {
+		queries = 0
+		require.Equal(t, "a8m", client.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, "a8m", client.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, 1, queries, "second lookup is cached")
+
+		// Node lookups are invalidated only by mutations of their node.
+		client.User.UpdateOne(nati).SetName("nati.1").ExecX(ctx)
+		queries = 0
+		require.Equal(t, "a8m", client.User.GetX(ctx, a8m.ID).Name)
+		require.Zero(t, queries)
+		client.User.UpdateOne(a8m).SetName("a8m.1").ExecX(ctx)
+		queries = 0
+		require.Equal(t, "a8m.1", client.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, 1, queries)
+
+		// Bulk mutations invalidate all lookups of the table.
+		client.User.Update().Where(user.ID(a8m.ID)).SetName("a8m").ExecX(ctx)
+		queries = 0
+		require.Equal(t, "a8m", client.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, 1, queries)
+
+		client.User.GetX(sqlcache.Skip(ctx), a8m.ID)
+		require.Equal(t, 2, queries, "skipped queries are not read from the cache")
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		names := func() []string {
+			return client.User.Query().Order(ent.Asc(user.FieldName)).Select(user.FieldName).StringsX(ctx)
+		}
+		queries = 0
+		require.Equal(t, []string{"a8m", "nati.1"}, names())
+		require.Equal(t, []string{"a8m", "nati.1"}, names())
+		require.Equal(t, 1, queries)
+		client.User.Create().SetName("neta").ExecX(ctx)
+		queries = 0
+		require.Equal(t, []string{"a8m", "nati.1", "neta"}, names())
+		require.Equal(t, 1, queries)
+		client.User.DeleteOneID(nati.ID).ExecX(ctx)
+		queries = 0
+		require.Equal(t, []string{"a8m", "neta"}, names())
+		require.Equal(t, 1, queries)
+	})
+
+	t.Run("Edges", func(t *testing.T) {
+		queries = 0
+		require.Zero(t, a8m.QueryPets().CountX(ctx))
+		require.Zero(t, a8m.QueryPets().CountX(ctx))
+		require.Equal(t, 1, queries)
+		// Mutations of the edges invalidate the entries that read from their tables.
+		client.Pet.Create().SetName("pedro").SetOwner(a8m).ExecX(ctx)
+		queries = 0
+		require.Equal(t, 1, a8m.QueryPets().CountX(ctx))
+		require.Equal(t, 1, queries)
+		client.User.UpdateOne(a8m).AddPets(client.Pet.Create().SetName("xabi").SaveX(ctx)).ExecX(ctx)
+		queries = 0
+		require.Equal(t, 2, a8m.QueryPets().CountX(ctx))
+		require.Equal(t, 1, queries)
+
+		g := client.Group.Create().SetName("g").SaveX(ctx)
+		queries = 0
+		require.Zero(t, g.QueryUsers().CountX(ctx))
+		require.Zero(t, g.QueryUsers().CountX(ctx))
+		require.Equal(t, 1, queries)
+		client.User.UpdateOne(a8m).AddGroups(g).ExecX(ctx)
+		queries = 0
+		require.Equal(t, 1, g.QueryUsers().CountX(ctx), "M2M edges invalidate their join tables")
+		require.Equal(t, 1, queries)
+	})
+
+	t.Run("Tx", func(t *testing.T) {
+		require.Equal(t, "a8m", client.User.GetX(ctx, a8m.ID).Name)
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		queries = 0
+		require.Equal(t, "a8m", tx.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, "a8m", tx.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, 2, queries, "transactions bypass the cache")
+		tx.User.UpdateOne(a8m).SetName("a8m.2").ExecX(ctx)
+		require.NoError(t, tx.Commit())
+		queries = 0
+		require.Equal(t, "a8m.2", client.User.GetX(ctx, a8m.ID).Name)
+		require.Equal(t, 1, queries)
+	})
+}
@@ -491,7 +491,16 @@ func (pq *PostQuery) loadComments(ctx context.Context, query *CommentQuery, node
 	query.Where(predicate.Comment(func(s *sql.Selector) {
 		s.Where(sql.InValues(post.CommentsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(post.CommentsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Comment](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -419,7 +419,16 @@ func (uq *UserQuery) loadPosts(ctx context.Context, query *PostQuery, nodes []*U
 	query.Where(predicate.Post(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PostsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.PostsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Post](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,143 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package compositeid
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/compositeid/ent"
+	"github.com/jogly/ent/entc/integration/compositeid/ent/account"
+	"github.com/jogly/ent/entc/integration/compositeid/ent/card"
+	"github.com/jogly/ent/entc/integration/compositeid/ent/enttest"
+	"github.com/jogly/ent/entc/integration/compositeid/ent/group"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeID(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	tn := client.Tenant.Create().SetName("ent").SaveX(ctx)
+	admins := client.Group.Create().SetName("admins").SaveX(ctx)
+	users := client.Group.Create().SetName("users").SaveX(ctx)
+	c1 := client.Card.Create().SetNumber("1").SaveX(ctx)
+	c2 := client.Card.Create().SetNumber("2").SaveX(ctx)
+	a8m := client.Account.Create().
+		SetTenant(tn).
+		SetExternalID("a8m").
+		AddCards(c1, c2).
+		AddGroups(admins, users).
+		SaveX(ctx)
+	accounts := client.Account.CreateBulk(
+		client.Account.Create().SetTenant(tn).SetExternalID("nati").AddGroups(users).AddFriends(a8m),
+		client.Account.Create().SetTenant(tn).SetExternalID("ariel"),
+	).SaveX(ctx)
+	nati, ariel := accounts[0], accounts[1]
+	c3 := client.Card.Create().SetNumber("3").SetAccount(nati).SaveX(ctx)
+
+	// Traversals.
+	require.Equal(t, []string{"1", "2"}, a8m.QueryCards().Order(ent.Asc(card.FieldNumber)).Select(card.FieldNumber).StringsX(ctx))
+	require.Equal(t, []string{"admins", "users"}, a8m.QueryGroups().Order(ent.Asc(group.FieldName)).Select(group.FieldName).StringsX(ctx))
+	require.Equal(t, a8m.ID(), nati.QueryFriends().OnlyX(ctx).ID())
+	require.Equal(t, nati.ID(), a8m.QueryFriends().OnlyX(ctx).ID(), "friends edge is bidirectional")
+	require.Equal(t, nati.ID(), c3.QueryAccount().OnlyX(ctx).ID())
+	require.Equal(t, 2, users.QueryAccounts().CountX(ctx))
+	require.Equal(t, []string{"3"}, client.Group.Query().
+		Where(group.Name("users")).
+		QueryAccounts().
+		QueryCards().
+		Where(card.HasAccountWith(account.ExternalID("nati"))).
+		Select(card.FieldNumber).
+		StringsX(ctx))
+	require.Equal(t, 2, client.Card.Query().Where(card.HasAccountWith(account.IDEQ(a8m.ID()))).CountX(ctx))
+
+	// Predicates.
+	require.Equal(t, []string{"a8m", "nati"}, client.Account.Query().
+		Where(account.HasCards()).
+		Order(ent.Asc(account.FieldExternalID)).
+		Select(account.FieldExternalID).
+		StringsX(ctx))
+	require.Equal(t, "ariel", client.Account.Query().Where(account.Not(account.HasGroups())).OnlyX(ctx).ExternalID)
+	require.Equal(t, "a8m", client.Account.Query().Where(account.HasGroupsWith(group.Name("admins"))).OnlyX(ctx).ExternalID)
+	require.Equal(t, "nati", client.Account.Query().Where(account.HasFriendsWith(account.ExternalID("a8m"))).OnlyX(ctx).ExternalID)
+	require.Equal(t, "admins", client.Group.Query().Where(group.Not(group.HasAccountsWith(account.ExternalID("nati")))).OnlyX(ctx).Name)
+
+	// Eager loading.
+	all := client.Account.Query().
+		WithCards(func(q *ent.CardQuery) { q.Order(ent.Asc(card.FieldNumber)) }).
+		WithGroups(func(q *ent.GroupQuery) { q.Order(ent.Asc(group.FieldName)) }).
+		WithFriends().
+		Order(ent.Asc(account.FieldExternalID)).
+		AllX(ctx)
+	require.Len(t, all, 3)
+	require.Equal(t, a8m.ID(), all[0].ID())
+	require.Len(t, all[0].Edges.Cards, 2)
+	require.Equal(t, c1.ID, all[0].Edges.Cards[0].ID)
+	require.Len(t, all[0].Edges.Groups, 2)
+	require.Equal(t, admins.ID, all[0].Edges.Groups[0].ID)
+	require.Len(t, all[0].Edges.Friends, 1)
+	require.Equal(t, nati.ID(), all[0].Edges.Friends[0].ID())
+	require.Equal(t, ariel.ID(), all[1].ID())
+	require.Empty(t, all[1].Edges.Cards)
+	require.Empty(t, all[1].Edges.Groups)
+	require.Empty(t, all[1].Edges.Friends)
+	require.Equal(t, nati.ID(), all[2].ID())
+	require.Len(t, all[2].Edges.Cards, 1)
+	require.Equal(t, users.ID, all[2].Edges.Groups[0].ID)
+	require.Equal(t, a8m.ID(), all[2].Edges.Friends[0].ID())
+
+	cards := client.Card.Query().WithAccount().Order(ent.Asc(card.FieldNumber)).AllX(ctx)
+	require.Len(t, cards, 3)
+	require.Equal(t, a8m.ID(), cards[0].Edges.Account.ID())
+	require.Equal(t, a8m.ID(), cards[1].Edges.Account.ID())
+	require.Equal(t, nati.ID(), cards[2].Edges.Account.ID())
+	gs := client.Group.Query().WithAccounts(func(q *ent.AccountQuery) { q.Order(ent.Asc(account.FieldExternalID)) }).Order(ent.Asc(group.FieldName)).AllX(ctx)
+	require.Len(t, gs, 2)
+	require.Len(t, gs[0].Edges.Accounts, 1)
+	require.Equal(t, a8m.ID(), gs[0].Edges.Accounts[0].ID())
+	require.Len(t, gs[1].Edges.Accounts, 2)
+	require.Equal(t, a8m.ID(), gs[1].Edges.Accounts[0].ID())
+	require.Equal(t, nati.ID(), gs[1].Edges.Accounts[1].ID())
+
+	// Limit the neighbors of each node.
+	all = client.Account.Query().
+		WithCards(func(q *ent.CardQuery) { q.Order(ent.Asc(card.FieldNumber)).Limit(1) }).
+		Order(ent.Asc(account.FieldExternalID)).
+		AllX(ctx)
+	require.Len(t, all[0].Edges.Cards, 1)
+	require.Equal(t, c1.ID, all[0].Edges.Cards[0].ID)
+	require.Empty(t, all[1].Edges.Cards)
+	require.Len(t, all[2].Edges.Cards, 1)
+
+	// Updates.
+	c4 := client.Card.Create().SetNumber("4").SaveX(ctx)
+	ariel = ariel.Update().AddFriends(a8m).AddGroupIDs(admins.ID).AddCards(c4).SaveX(ctx)
+	require.Equal(t, 2, a8m.QueryFriends().CountX(ctx))
+	require.Equal(t, 2, admins.QueryAccounts().CountX(ctx))
+	require.Equal(t, ariel.ID(), c4.QueryAccount().OnlyX(ctx).ID())
+	err := ariel.Update().AddCards(c3).Exec(ctx)
+	require.True(t, ent.IsConstraintError(err), "card is already connected to a different account")
+	client.Account.UpdateOneID(a8m.ID()).RemoveFriends(nati).RemoveGroups(users).ClearCards().ExecX(ctx)
+	require.Equal(t, ariel.ID(), a8m.QueryFriends().OnlyX(ctx).ID())
+	require.Zero(t, nati.QueryFriends().CountX(ctx))
+	require.Equal(t, admins.ID, a8m.QueryGroups().OnlyX(ctx).ID)
+	require.Zero(t, a8m.QueryCards().CountX(ctx))
+	client.Account.Update().Where(account.ExternalID("ariel")).ClearGroups().ExecX(ctx)
+	require.Zero(t, ariel.QueryGroups().CountX(ctx))
+	c1 = c1.Update().SetAccountID(nati.ID()).SaveX(ctx)
+	require.Equal(t, nati.ID(), c1.QueryAccount().OnlyX(ctx).ID())
+	client.Card.UpdateOne(c1).ClearAccount().ExecX(ctx)
+	require.False(t, c1.QueryAccount().ExistX(ctx))
+
+	// Deleting an account removes its join-table rows and nullifies the foreign-keys that reference it.
+	client.Account.DeleteOne(ariel).ExecX(ctx)
+	require.False(t, c4.QueryAccount().ExistX(ctx))
+	require.Zero(t, a8m.QueryFriends().CountX(ctx))
+	require.Zero(t, admins.QueryAccounts().Where(account.ExternalID("ariel")).CountX(ctx))
+}
@@ -0,0 +1,48 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/schema"
+	"github.com/jogly/ent/schema/edge"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Account holds the schema definition for the Account entity.
+type Account struct {
+	ent.Schema
+}
+
+// Annotations of the Account.
+func (Account) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		field.ID("tenant_id", "external_id"),
+	}
+}
+
+// Fields of the Account.
+func (Account) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("tenant_id"),
+		field.String("external_id"),
+		field.Int("balance").
+			Default(0),
+	}
+}
+
+// Edges of the Account.
+func (Account) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("tenant", Tenant.Type).
+			Ref("accounts").
+			Field("tenant_id").
+			Unique().
+			Required(),
+		edge.To("cards", Card.Type),
+		edge.To("groups", Group.Type),
+		edge.To("friends", Account.Type),
+	}
+}
@@ -421,7 +421,16 @@ func (aq *AccountQuery) loadToken(ctx context.Context, query *TokenQuery, nodes
 	query.Where(predicate.Token(func(s *sql.Selector) {
 		s.Where(sql.InValues(account.TokenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(account.TokenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Token](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
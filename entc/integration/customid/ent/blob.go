@@ -10,9 +10,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
-	"github.com/google/uuid"
 )
 
 // Blob is the model entity for the Blob schema.
@@ -11,12 +11,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // BlobCreate is the builder for creating a Blob entity.
@@ -12,13 +12,13 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/bloblink"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // BlobQuery is the builder for querying Blob entities.
@@ -543,8 +543,8 @@ func (bq *BlobQuery) loadLinks(ctx context.Context, query *BlobQuery, nodes []*B
 			init(node)
 		}
 	}
+	joinT := sql.Table(blob.LinksTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(blob.LinksTable)
 		s.Join(joinT).On(s.C(blob.FieldID), joinT.C(blob.LinksPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(blob.LinksPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -556,7 +556,10 @@ func (bq *BlobQuery) loadLinks(ctx context.Context, query *BlobQuery, nodes []*B
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(blob.LinksPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -606,7 +609,16 @@ func (bq *BlobQuery) loadBlobLinks(ctx context.Context, query *BlobLinkQuery, no
 	query.Where(predicate.BlobLink(func(s *sql.Selector) {
 		s.Where(sql.InValues(blob.BlobLinksColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(blob.BlobLinksColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*BlobLink](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -11,12 +11,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // BlobUpdate is the builder for updating Blob entities.
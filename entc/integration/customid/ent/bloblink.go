@@ -11,10 +11,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/bloblink"
-	"github.com/google/uuid"
 )
 
 // BlobLink is the model entity for the BlobLink schema.
@@ -9,10 +9,10 @@ package bloblink
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
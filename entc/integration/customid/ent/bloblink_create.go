@@ -12,12 +12,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/bloblink"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // BlobLinkCreate is the builder for creating a BlobLink entity.
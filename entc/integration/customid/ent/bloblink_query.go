@@ -11,12 +11,12 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/bloblink"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // BlobLinkQuery is the builder for querying BlobLink entities.
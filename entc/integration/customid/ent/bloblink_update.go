@@ -12,13 +12,13 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/bloblink"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // BlobLinkUpdate is the builder for updating BlobLink entities.
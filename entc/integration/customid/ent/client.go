@@ -12,12 +12,12 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/entc/integration/customid/ent/migrate"
 	"github.com/jogly/ent/entc/integration/customid/ent/schema"
 	"github.com/jogly/ent/entc/integration/customid/sid"
 	uuidc "github.com/jogly/ent/entc/integration/customid/uuidcompatible"
-	"github.com/google/uuid"
 
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/sql"
@@ -481,7 +481,16 @@ func (dq *DeviceQuery) loadSessions(ctx context.Context, query *SessionQuery, no
 	query.Where(predicate.Session(func(s *sql.Selector) {
 		s.Where(sql.InValues(device.SessionsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(device.SessionsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Session](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
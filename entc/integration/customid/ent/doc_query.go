@@ -545,7 +545,16 @@ func (dq *DocQuery) loadChildren(ctx context.Context, query *DocQuery, nodes []*
 	query.Where(predicate.Doc(func(s *sql.Selector) {
 		s.Where(sql.InValues(doc.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(doc.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Doc](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -573,8 +582,8 @@ func (dq *DocQuery) loadRelated(ctx context.Context, query *DocQuery, nodes []*D
 			init(node)
 		}
 	}
+	joinT := sql.Table(doc.RelatedTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(doc.RelatedTable)
 		s.Join(joinT).On(s.C(doc.FieldID), joinT.C(doc.RelatedPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(doc.RelatedPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -586,7 +595,10 @@ func (dq *DocQuery) loadRelated(ctx context.Context, query *DocQuery, nodes []*D
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(doc.RelatedPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
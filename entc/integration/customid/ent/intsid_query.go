@@ -480,7 +480,16 @@ func (isq *IntSIDQuery) loadChildren(ctx context.Context, query *IntSIDQuery, no
 	query.Where(predicate.IntSID(func(s *sql.Selector) {
 		s.Where(sql.InValues(intsid.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(intsid.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*IntSID](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -7,9 +7,9 @@
 package mixinid
 
 import (
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
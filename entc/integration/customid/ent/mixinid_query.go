@@ -11,12 +11,12 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/customid/ent/mixinid"
 	"github.com/jogly/ent/entc/integration/customid/ent/predicate"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // MixinIDQuery is the builder for querying MixinID entities.
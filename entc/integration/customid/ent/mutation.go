@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/customid/ent/account"
@@ -34,7 +35,6 @@ import (
 	"github.com/jogly/ent/entc/integration/customid/ent/user"
 	"github.com/jogly/ent/entc/integration/customid/sid"
 	uuidc "github.com/jogly/ent/entc/integration/customid/uuidcompatible"
-	"github.com/google/uuid"
 )
 
 const (
@@ -502,7 +502,16 @@ func (nq *NoteQuery) loadChildren(ctx context.Context, query *NoteQuery, nodes [
 	query.Where(predicate.Note(func(s *sql.Selector) {
 		s.Where(sql.InValues(note.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(note.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Note](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
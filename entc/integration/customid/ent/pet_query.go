@@ -566,7 +566,16 @@ func (pq *PetQuery) loadCars(ctx context.Context, query *CarQuery, nodes []*Pet,
 	query.Where(predicate.Car(func(s *sql.Selector) {
 		s.Where(sql.InValues(pet.CarsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(pet.CarsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Car](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -594,8 +603,8 @@ func (pq *PetQuery) loadFriends(ctx context.Context, query *PetQuery, nodes []*P
 			init(node)
 		}
 	}
+	joinT := sql.Table(pet.FriendsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(pet.FriendsTable)
 		s.Join(joinT).On(s.C(pet.FieldID), joinT.C(pet.FriendsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(pet.FriendsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -607,7 +616,10 @@ func (pq *PetQuery) loadFriends(ctx context.Context, query *PetQuery, nodes []*P
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(pet.FriendsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -9,6 +9,7 @@ package ent
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/entc/integration/customid/ent/account"
 	"github.com/jogly/ent/entc/integration/customid/ent/blob"
 	"github.com/jogly/ent/entc/integration/customid/ent/bloblink"
@@ -25,7 +26,6 @@ import (
 	"github.com/jogly/ent/entc/integration/customid/ent/token"
 	"github.com/jogly/ent/entc/integration/customid/sid"
 	uuidc "github.com/jogly/ent/entc/integration/customid/uuidcompatible"
-	"github.com/google/uuid"
 )
 
 // The init function reads all schema descriptors with runtime code
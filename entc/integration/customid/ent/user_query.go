@@ -532,8 +532,8 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.GroupsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.GroupsTable)
 		s.Join(joinT).On(s.C(group.FieldID), joinT.C(user.GroupsPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(user.GroupsPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -545,7 +545,10 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.GroupsPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -628,7 +631,16 @@ func (uq *UserQuery) loadChildren(ctx context.Context, query *UserQuery, nodes [
 	query.Where(predicate.User(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -659,7 +671,16 @@ func (uq *UserQuery) loadPets(ctx context.Context, query *PetQuery, nodes []*Use
 	query.Where(predicate.Pet(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.PetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Pet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
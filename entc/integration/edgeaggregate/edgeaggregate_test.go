@@ -0,0 +1,103 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package edgeaggregate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/edgeaggregate/ent"
+	"github.com/jogly/ent/entc/integration/edgeaggregate/ent/enttest"
+	"github.com/jogly/ent/entc/integration/edgeaggregate/ent/group"
+	"github.com/jogly/ent/entc/integration/edgeaggregate/ent/post"
+	"github.com/jogly/ent/entc/integration/edgeaggregate/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdgeAggregate(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SaveX(ctx)
+	client.User.Create().SetName("ariel").ExecX(ctx)
+	client.Post.CreateBulk(
+		client.Post.Create().SetLikes(1).SetAuthor(a8m),
+		client.Post.Create().SetLikes(5).SetAuthor(a8m),
+		client.Post.Create().SetLikes(3).SetAuthor(a8m),
+		client.Post.Create().SetLikes(10).SetAuthor(nati),
+		client.Post.Create().SetLikes(100),
+	).ExecX(ctx)
+	client.Group.Create().SetName("admins").AddUsers(a8m).ExecX(ctx)
+	client.Group.Create().SetName("users").AddUsers(a8m, nati).ExecX(ctx)
+	client.Group.Create().SetName("archive").SetActive(false).AddUsers(a8m).ExecX(ctx)
+
+	// O2M edge.
+	users := client.User.Query().
+		WithPostsCount().
+		WithPostsAggregate(ent.Sum(post.FieldLikes), ent.Max(post.FieldLikes), ent.Min(post.FieldLikes)).
+		Order(ent.Asc(user.FieldName)).
+		AllX(ctx)
+	require.Len(t, users, 3)
+	for i, want := range []struct {
+		count int
+		aggs  []float64
+	}{
+		{count: 3, aggs: []float64{9, 5, 1}},    // a8m
+		{count: 0, aggs: []float64{0, 0, 0}},    // ariel
+		{count: 1, aggs: []float64{10, 10, 10}}, // nati
+	} {
+		count, err := users[i].Edges.PostsCountOrErr()
+		require.NoError(t, err)
+		require.Equal(t, want.count, count, users[i].Name)
+		aggs, err := users[i].Edges.PostsAggregateOrErr()
+		require.NoError(t, err)
+		require.Equal(t, want.aggs, aggs, users[i].Name)
+	}
+	_, err := users[0].Edges.GroupsCountOrErr()
+	require.True(t, ent.IsNotLoaded(err))
+	_, err = users[0].Edges.GroupsAggregateOrErr()
+	require.True(t, ent.IsNotLoaded(err))
+	require.Nil(t, users[0].Edges.Posts, "neighbors are not loaded")
+
+	// Configure the query of the edge.
+	count, err := client.User.Query().
+		Where(user.ID(a8m.ID)).
+		WithPostsCount(func(q *ent.PostQuery) {
+			q.Where(post.LikesGT(1))
+		}).
+		OnlyX(ctx).
+		Edges.PostsCountOrErr()
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	// M2M edge, from both sides.
+	users = client.User.Query().
+		WithGroupsCount(func(q *ent.GroupQuery) {
+			q.Where(group.Active(true))
+		}).
+		Order(ent.Asc(user.FieldName)).
+		AllX(ctx)
+	for i, want := range []int{2, 0, 1} {
+		count, err := users[i].Edges.GroupsCountOrErr()
+		require.NoError(t, err)
+		require.Equal(t, want, count, users[i].Name)
+	}
+	groups := client.Group.Query().
+		WithUsersCount().
+		WithUsers().
+		Order(ent.Asc(group.FieldName)).
+		AllX(ctx)
+	require.Len(t, groups, 3)
+	for i, want := range []int{1, 1, 2} {
+		count, err := groups[i].Edges.UsersCountOrErr()
+		require.NoError(t, err)
+		require.Equal(t, want, count, groups[i].Name)
+		require.Len(t, groups[i].Edges.Users, want, "counts and neighbors can be loaded together")
+	}
+}
@@ -10,9 +10,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
-	"github.com/google/uuid"
 )
 
 // Car is the model entity for the Car schema.
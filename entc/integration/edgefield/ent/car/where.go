@@ -7,10 +7,10 @@
 package car
 
 import (
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
@@ -10,11 +10,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // CarCreate is the builder for creating a Car entity.
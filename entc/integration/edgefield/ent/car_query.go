@@ -12,13 +12,13 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/predicate"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // CarQuery is the builder for querying Car entities.
@@ -420,7 +420,16 @@ func (cq *CarQuery) loadRentals(ctx context.Context, query *RentalQuery, nodes [
 	query.Where(predicate.Rental(func(s *sql.Selector) {
 		s.Where(sql.InValues(car.RentalsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(car.RentalsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Rental](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
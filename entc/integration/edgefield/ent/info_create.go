@@ -26,8 +26,8 @@ type InfoCreate struct {
 }
 
 // SetContent sets the "content" field.
-func (ic *InfoCreate) SetContent(jm json.RawMessage) *InfoCreate {
-	ic.mutation.SetContent(jm)
+func (ic *InfoCreate) SetContent(j json.RawMessage) *InfoCreate {
+	ic.mutation.SetContent(j)
 	return ic
 }
 
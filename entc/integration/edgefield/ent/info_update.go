@@ -35,14 +35,14 @@ func (iu *InfoUpdate) Where(ps ...predicate.Info) *InfoUpdate {
 }
 
 // SetContent sets the "content" field.
-func (iu *InfoUpdate) SetContent(jm json.RawMessage) *InfoUpdate {
-	iu.mutation.SetContent(jm)
+func (iu *InfoUpdate) SetContent(j json.RawMessage) *InfoUpdate {
+	iu.mutation.SetContent(j)
 	return iu
 }
 
-// AppendContent appends jm to the "content" field.
-func (iu *InfoUpdate) AppendContent(jm json.RawMessage) *InfoUpdate {
-	iu.mutation.AppendContent(jm)
+// AppendContent appends j to the "content" field.
+func (iu *InfoUpdate) AppendContent(j json.RawMessage) *InfoUpdate {
+	iu.mutation.AppendContent(j)
 	return iu
 }
 
@@ -176,14 +176,14 @@ type InfoUpdateOne struct {
 }
 
 // SetContent sets the "content" field.
-func (iuo *InfoUpdateOne) SetContent(jm json.RawMessage) *InfoUpdateOne {
-	iuo.mutation.SetContent(jm)
+func (iuo *InfoUpdateOne) SetContent(j json.RawMessage) *InfoUpdateOne {
+	iuo.mutation.SetContent(j)
 	return iuo
 }
 
-// AppendContent appends jm to the "content" field.
-func (iuo *InfoUpdateOne) AppendContent(jm json.RawMessage) *InfoUpdateOne {
-	iuo.mutation.AppendContent(jm)
+// AppendContent appends j to the "content" field.
+func (iuo *InfoUpdateOne) AppendContent(j json.RawMessage) *InfoUpdateOne {
+	iuo.mutation.AppendContent(j)
 	return iuo
 }
 
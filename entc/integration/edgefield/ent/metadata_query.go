@@ -532,7 +532,16 @@ func (mq *MetadataQuery) loadChildren(ctx context.Context, query *MetadataQuery,
 	query.Where(predicate.Metadata(func(s *sql.Selector) {
 		s.Where(sql.InValues(metadata.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(metadata.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Metadata](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
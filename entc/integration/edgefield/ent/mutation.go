@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
@@ -26,7 +27,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgefield/ent/predicate"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/user"
-	"github.com/google/uuid"
 )
 
 const (
@@ -1094,8 +1094,8 @@ func (m *InfoMutation) IDs(ctx context.Context) ([]int, error) {
 }
 
 // SetContent sets the "content" field.
-func (m *InfoMutation) SetContent(jm json.RawMessage) {
-	m.content = &jm
+func (m *InfoMutation) SetContent(j json.RawMessage) {
+	m.content = &j
 	m.appendcontent = nil
 }
 
@@ -1125,9 +1125,9 @@ func (m *InfoMutation) OldContent(ctx context.Context) (v json.RawMessage, err e
 	return oldValue.Content, nil
 }
 
-// AppendContent adds jm to the "content" field.
-func (m *InfoMutation) AppendContent(jm json.RawMessage) {
-	m.appendcontent = append(m.appendcontent, jm...)
+// AppendContent adds j to the "content" field.
+func (m *InfoMutation) AppendContent(j json.RawMessage) {
+	m.appendcontent = append(m.appendcontent, j...)
 }
 
 // AppendedContent returns the list of values that were appended to the "content" field in this mutation.
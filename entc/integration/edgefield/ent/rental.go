@@ -11,11 +11,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/user"
-	"github.com/google/uuid"
 )
 
 // Rental is the model entity for the Rental schema.
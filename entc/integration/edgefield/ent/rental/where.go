@@ -9,10 +9,10 @@ package rental
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
@@ -12,12 +12,12 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // RentalCreate is the builder for creating a Rental entity.
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
@@ -18,7 +19,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // RentalQuery is the builder for querying Rental entities.
@@ -9,12 +9,12 @@ package ent
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/car"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/metadata"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/node"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/rental"
 	"github.com/jogly/ent/entc/integration/edgefield/ent/schema"
-	"github.com/google/uuid"
 )
 
 // The init function reads all schema descriptors with runtime code
@@ -720,7 +720,16 @@ func (uq *UserQuery) loadPets(ctx context.Context, query *PetQuery, nodes []*Use
 	query.Where(predicate.Pet(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.PetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Pet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -776,7 +785,16 @@ func (uq *UserQuery) loadChildren(ctx context.Context, query *UserQuery, nodes [
 	query.Where(predicate.User(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -880,7 +898,16 @@ func (uq *UserQuery) loadInfo(ctx context.Context, query *InfoQuery, nodes []*Us
 	query.Where(predicate.Info(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.InfoColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.InfoColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Info](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -907,7 +934,16 @@ func (uq *UserQuery) loadRentals(ctx context.Context, query *RentalQuery, nodes
 	query.Where(predicate.Rental(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.RentalsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.RentalsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Rental](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
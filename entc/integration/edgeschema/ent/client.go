@@ -12,9 +12,9 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/migrate"
-	"github.com/google/uuid"
 
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/sql"
@@ -417,8 +417,8 @@ func (fq *FileQuery) loadProcesses(ctx context.Context, query *ProcessQuery, nod
 			init(node)
 		}
 	}
+	joinT := sql.Table(file.ProcessesTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(file.ProcessesTable)
 		s.Join(joinT).On(s.C(process.FieldID), joinT.C(file.ProcessesPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(file.ProcessesPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -430,7 +430,10 @@ func (fq *FileQuery) loadProcesses(ctx context.Context, query *ProcessQuery, nod
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(file.ProcessesPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -549,8 +549,8 @@ func (gq *GroupQuery) loadUsers(ctx context.Context, query *UserQuery, nodes []*
 			init(node)
 		}
 	}
+	joinT := sql.Table(group.UsersTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(group.UsersTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(group.UsersPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(group.UsersPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -562,7 +562,10 @@ func (gq *GroupQuery) loadUsers(ctx context.Context, query *UserQuery, nodes []*
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(group.UsersPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -610,8 +613,8 @@ func (gq *GroupQuery) loadTags(ctx context.Context, query *TagQuery, nodes []*Gr
 			init(node)
 		}
 	}
+	joinT := sql.Table(group.TagsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(group.TagsTable)
 		s.Join(joinT).On(s.C(tag.FieldID), joinT.C(group.TagsPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(group.TagsPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -623,7 +626,10 @@ func (gq *GroupQuery) loadTags(ctx context.Context, query *TagQuery, nodes []*Gr
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(group.TagsPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -673,7 +679,16 @@ func (gq *GroupQuery) loadJoinedUsers(ctx context.Context, query *UserGroupQuery
 	query.Where(predicate.UserGroup(func(s *sql.Selector) {
 		s.Where(sql.InValues(group.JoinedUsersColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(group.JoinedUsersColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*UserGroup](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -700,7 +715,16 @@ func (gq *GroupQuery) loadGroupTags(ctx context.Context, query *GroupTagQuery, n
 	query.Where(predicate.GroupTag(func(s *sql.Selector) {
 		s.Where(sql.InValues(group.GroupTagsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(group.GroupTagsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*GroupTag](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/attachedfile"
@@ -33,7 +34,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/user"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/usergroup"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/usertweet"
-	"github.com/google/uuid"
 )
 
 const (
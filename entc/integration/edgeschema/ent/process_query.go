@@ -439,8 +439,8 @@ func (pq *ProcessQuery) loadFiles(ctx context.Context, query *FileQuery, nodes [
 			init(node)
 		}
 	}
+	joinT := sql.Table(process.FilesTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(process.FilesTable)
 		s.Join(joinT).On(s.C(file.FieldID), joinT.C(process.FilesPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(process.FilesPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -452,7 +452,10 @@ func (pq *ProcessQuery) loadFiles(ctx context.Context, query *FileQuery, nodes [
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(process.FilesPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -502,7 +505,16 @@ func (pq *ProcessQuery) loadAttachedFiles(ctx context.Context, query *AttachedFi
 	query.Where(predicate.AttachedFile(func(s *sql.Selector) {
 		s.Where(sql.InValues(process.AttachedFilesColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(process.AttachedFilesColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*AttachedFile](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -461,8 +461,8 @@ func (rq *RoleQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*Ro
 			init(node)
 		}
 	}
+	joinT := sql.Table(role.UserTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(role.UserTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(role.UserPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(role.UserPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -474,7 +474,10 @@ func (rq *RoleQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*Ro
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(role.UserPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -524,7 +527,16 @@ func (rq *RoleQuery) loadRolesUsers(ctx context.Context, query *RoleUserQuery, n
 	query.Where(predicate.RoleUser(func(s *sql.Selector) {
 		s.Where(sql.InValues(role.RolesUsersColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(role.RolesUsersColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*RoleUser](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -10,6 +10,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/attachedfile"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/friendship"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/group"
@@ -22,7 +23,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/user"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/usergroup"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/usertweet"
-	"github.com/google/uuid"
 
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/privacy"
@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/group"
@@ -19,7 +20,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweet"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweettag"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // TagCreate is the builder for creating a Tag entity.
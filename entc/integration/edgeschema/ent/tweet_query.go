@@ -636,8 +636,8 @@ func (tq *TweetQuery) loadLikedUsers(ctx context.Context, query *UserQuery, node
 			init(node)
 		}
 	}
+	joinT := sql.Table(tweet.LikedUsersTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(tweet.LikedUsersTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(tweet.LikedUsersPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(tweet.LikedUsersPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -649,7 +649,10 @@ func (tq *TweetQuery) loadLikedUsers(ctx context.Context, query *UserQuery, node
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(tweet.LikedUsersPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -697,8 +700,8 @@ func (tq *TweetQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*T
 			init(node)
 		}
 	}
+	joinT := sql.Table(tweet.UserTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(tweet.UserTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(tweet.UserPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(tweet.UserPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -710,7 +713,10 @@ func (tq *TweetQuery) loadUser(ctx context.Context, query *UserQuery, nodes []*T
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(tweet.UserPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -758,8 +764,8 @@ func (tq *TweetQuery) loadTags(ctx context.Context, query *TagQuery, nodes []*Tw
 			init(node)
 		}
 	}
+	joinT := sql.Table(tweet.TagsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(tweet.TagsTable)
 		s.Join(joinT).On(s.C(tag.FieldID), joinT.C(tweet.TagsPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(tweet.TagsPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -771,7 +777,10 @@ func (tq *TweetQuery) loadTags(ctx context.Context, query *TagQuery, nodes []*Tw
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(tweet.TagsPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -821,7 +830,16 @@ func (tq *TweetQuery) loadLikes(ctx context.Context, query *TweetLikeQuery, node
 	query.Where(predicate.TweetLike(func(s *sql.Selector) {
 		s.Where(sql.InValues(tweet.LikesColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(tweet.LikesColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*TweetLike](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -848,7 +866,16 @@ func (tq *TweetQuery) loadTweetUser(ctx context.Context, query *UserTweetQuery,
 	query.Where(predicate.UserTweet(func(s *sql.Selector) {
 		s.Where(sql.InValues(tweet.TweetUserColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(tweet.TweetUserColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*UserTweet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -875,7 +902,16 @@ func (tq *TweetQuery) loadTweetTags(ctx context.Context, query *TweetTagQuery, n
 	query.Where(predicate.TweetTag(func(s *sql.Selector) {
 		s.Where(sql.InValues(tweet.TweetTagsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(tweet.TweetTagsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*TweetTag](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
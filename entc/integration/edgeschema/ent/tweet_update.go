@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/predicate"
@@ -20,7 +21,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/user"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/usertweet"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // TweetUpdate is the builder for updating Tweet entities.
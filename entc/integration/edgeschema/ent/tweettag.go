@@ -11,11 +11,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tag"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweet"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweettag"
-	"github.com/google/uuid"
 )
 
 // TweetTag is the model entity for the TweetTag schema.
@@ -9,10 +9,10 @@ package tweettag
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
@@ -19,7 +20,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweet"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweettag"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // TweetTagCreate is the builder for creating a TweetTag entity.
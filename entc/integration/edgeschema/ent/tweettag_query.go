@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/predicate"
@@ -18,7 +19,6 @@ import (
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweet"
 	"github.com/jogly/ent/entc/integration/edgeschema/ent/tweettag"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // TweetTagQuery is the builder for querying TweetTag entities.
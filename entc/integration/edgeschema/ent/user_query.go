@@ -905,8 +905,8 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.GroupsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.GroupsTable)
 		s.Join(joinT).On(s.C(group.FieldID), joinT.C(user.GroupsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.GroupsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -918,7 +918,10 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.GroupsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -966,8 +969,8 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FriendsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FriendsTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FriendsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.FriendsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -979,7 +982,10 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FriendsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1027,8 +1033,8 @@ func (uq *UserQuery) loadRelatives(ctx context.Context, query *UserQuery, nodes
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.RelativesTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.RelativesTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.RelativesPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.RelativesPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1040,7 +1046,10 @@ func (uq *UserQuery) loadRelatives(ctx context.Context, query *UserQuery, nodes
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.RelativesPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1088,8 +1097,8 @@ func (uq *UserQuery) loadLikedTweets(ctx context.Context, query *TweetQuery, nod
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.LikedTweetsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.LikedTweetsTable)
 		s.Join(joinT).On(s.C(tweet.FieldID), joinT.C(user.LikedTweetsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.LikedTweetsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1101,7 +1110,10 @@ func (uq *UserQuery) loadLikedTweets(ctx context.Context, query *TweetQuery, nod
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.LikedTweetsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1149,8 +1161,8 @@ func (uq *UserQuery) loadTweets(ctx context.Context, query *TweetQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.TweetsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.TweetsTable)
 		s.Join(joinT).On(s.C(tweet.FieldID), joinT.C(user.TweetsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.TweetsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1162,7 +1174,10 @@ func (uq *UserQuery) loadTweets(ctx context.Context, query *TweetQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.TweetsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1210,8 +1225,8 @@ func (uq *UserQuery) loadRoles(ctx context.Context, query *RoleQuery, nodes []*U
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.RolesTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.RolesTable)
 		s.Join(joinT).On(s.C(role.FieldID), joinT.C(user.RolesPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.RolesPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1223,7 +1238,10 @@ func (uq *UserQuery) loadRoles(ctx context.Context, query *RoleQuery, nodes []*U
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.RolesPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1273,7 +1291,16 @@ func (uq *UserQuery) loadJoinedGroups(ctx context.Context, query *UserGroupQuery
 	query.Where(predicate.UserGroup(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.JoinedGroupsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.JoinedGroupsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*UserGroup](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -1300,7 +1327,16 @@ func (uq *UserQuery) loadFriendships(ctx context.Context, query *FriendshipQuery
 	query.Where(predicate.Friendship(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.FriendshipsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.FriendshipsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Friendship](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -1327,7 +1363,16 @@ func (uq *UserQuery) loadRelationship(ctx context.Context, query *RelationshipQu
 	query.Where(predicate.Relationship(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.RelationshipColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.RelationshipColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Relationship](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -1354,7 +1399,16 @@ func (uq *UserQuery) loadLikes(ctx context.Context, query *TweetLikeQuery, nodes
 	query.Where(predicate.TweetLike(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.LikesColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.LikesColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*TweetLike](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -1381,7 +1435,16 @@ func (uq *UserQuery) loadUserTweets(ctx context.Context, query *UserTweetQuery,
 	query.Where(predicate.UserTweet(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.UserTweetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.UserTweetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*UserTweet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -1408,7 +1471,16 @@ func (uq *UserQuery) loadRolesUsers(ctx context.Context, query *RoleUserQuery, n
 	query.Where(predicate.RoleUser(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.RolesUsersColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.RolesUsersColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*RoleUser](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
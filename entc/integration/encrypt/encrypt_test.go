@@ -0,0 +1,155 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package encrypt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/jogly/ent/dialect"
+	entsql "github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/dialect/sql/sqlencrypt"
+	"github.com/jogly/ent/entc/integration/encrypt/ent"
+	"github.com/jogly/ent/entc/integration/encrypt/ent/enttest"
+	"github.com/jogly/ent/entc/integration/encrypt/ent/node"
+	"github.com/jogly/ent/entc/integration/encrypt/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypt(t *testing.T) {
+	drv, err := entsql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	var (
+		v1 = bytes.Repeat([]byte{1}, 32)
+		v2 = bytes.Repeat([]byte{2}, 32)
+		kr = sqlencrypt.Keyring{Current: "v1", Keys: map[string][]byte{"v1": v1}}
+	)
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv), ent.EncryptionKeys(kr)))
+	defer client.Close()
+	ctx := context.Background()
+
+	// raw returns the stored values of the encrypted fields of the given user,
+	// and the key ids of their ciphertexts.
+	raw := func(t *testing.T, id int) (email, ssn string, token []byte, keys []string) {
+		t.Helper()
+		err := drv.DB().QueryRowContext(ctx, "SELECT `email`, `ssn`, `token` FROM `users` WHERE `id` = ?", id).Scan(&email, &ssn, &token)
+		require.NoError(t, err)
+		for _, s := range []string{email, ssn} {
+			b, err := base64.StdEncoding.DecodeString(s)
+			require.NoError(t, err)
+			k, err := sqlencrypt.KeyID(b)
+			require.NoError(t, err)
+			keys = append(keys, k)
+		}
+		if token != nil {
+			k, err := sqlencrypt.KeyID(token)
+			require.NoError(t, err)
+			keys = append(keys, k)
+		}
+		return
+	}
+
+	a8m := client.User.Create().SetName("a8m").SetEmail("a8m@entgo.io").SetSsn("111").SetToken([]byte("t1")).SaveX(ctx)
+	require.Equal(t, "a8m@entgo.io", a8m.Email, "returned values are decrypted")
+	require.Equal(t, "111", a8m.Ssn)
+	require.Equal(t, []byte("t1"), a8m.Token)
+	email, ssn, token, keys := raw(t, a8m.ID)
+	require.NotEqual(t, "a8m@entgo.io", email)
+	require.NotEqual(t, "111", ssn)
+	require.NotEqual(t, []byte("t1"), token)
+	require.Equal(t, []string{"v1", "v1", "v1"}, keys)
+
+	users := client.User.CreateBulk(
+		client.User.Create().SetName("nati").SetEmail("nati@entgo.io").SetSsn("222"),
+		client.User.Create().SetName("neta").SetEmail("neta@entgo.io").SetSsn("333"),
+	).SaveX(ctx)
+	require.Equal(t, "222", users[0].Ssn)
+	_, ssn, _, _ = raw(t, users[1].ID)
+	require.NotEqual(t, "333", ssn)
+	// Types whose package names conflict with local names of the builders.
+	nodes := client.Node.CreateBulk(client.Node.Create().SetSecret("n1"), client.Node.Create().SetSecret("n2")).SaveX(ctx)
+	require.Equal(t, "n2", nodes[1].Secret)
+	specs := client.Spec.CreateBulk(client.Spec.Create().SetSecret("s1")).SaveX(ctx)
+	require.Equal(t, "s1", client.Spec.GetX(ctx, specs[0].ID).Secret)
+	require.Equal(t, []string{"n1", "n2"}, client.Node.Query().Select(node.FieldSecret).StringsX(ctx))
+
+	t.Run("Query", func(t *testing.T) {
+		u := client.User.GetX(ctx, a8m.ID)
+		require.Equal(t, "a8m@entgo.io", u.Email)
+		require.Equal(t, "111", u.Ssn)
+		require.Equal(t, []byte("t1"), u.Token)
+
+		// Deterministic fields can be used in equality predicates.
+		u = client.User.Query().Where(user.Email("nati@entgo.io")).OnlyX(ctx)
+		require.Equal(t, "222", u.Ssn)
+		require.Equal(t, 2, client.User.Query().Where(user.EmailIn("a8m@entgo.io", "neta@entgo.io")).CountX(ctx))
+		require.Equal(t, 2, client.User.Query().Where(user.EmailNEQ("a8m@entgo.io")).CountX(ctx))
+		require.Zero(t, client.User.Query().Where(user.Email("unknown")).CountX(ctx))
+
+		// Selected fields are decrypted while they are scanned.
+		ssns := client.User.Query().Order(ent.Asc(user.FieldID)).Select(user.FieldSsn).StringsX(ctx)
+		require.Equal(t, []string{"111", "222", "333"}, ssns)
+		var v []struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+			Token []byte `json:"token"`
+		}
+		client.User.Query().Where(user.ID(a8m.ID)).Select(user.FieldName, user.FieldEmail, user.FieldToken).ScanX(ctx, &v)
+		require.Len(t, v, 1)
+		require.Equal(t, "a8m@entgo.io", v[0].Email)
+		require.Equal(t, []byte("t1"), v[0].Token)
+		emails := client.User.Query().GroupBy(user.FieldEmail).StringsX(ctx)
+		require.ElementsMatch(t, []string{"a8m@entgo.io", "nati@entgo.io", "neta@entgo.io"}, emails)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		u := client.User.UpdateOne(a8m).SetSsn("112").SaveX(ctx)
+		require.Equal(t, "112", u.Ssn, "updated values are decrypted")
+		require.Equal(t, "a8m@entgo.io", u.Email)
+		_, ssn, _, _ := raw(t, a8m.ID)
+		require.NotEqual(t, "112", ssn)
+		require.Equal(t, "112", client.User.GetX(ctx, a8m.ID).Ssn)
+
+		n := client.User.Update().Where(user.Email("nati@entgo.io")).SetEmail("nati@ent.io").SaveX(ctx)
+		require.Equal(t, 1, n)
+		require.Equal(t, "222", client.User.Query().Where(user.Email("nati@ent.io")).OnlyX(ctx).Ssn)
+		require.Equal(t, 1, client.User.Delete().Where(user.Email("neta@entgo.io")).ExecX(ctx))
+	})
+
+	t.Run("Reencrypt", func(t *testing.T) {
+		rotated := sqlencrypt.Keyring{Current: "v2", Keys: map[string][]byte{"v1": v1, "v2": v2}}
+		client2 := ent.NewClient(ent.Driver(drv), ent.EncryptionKeys(rotated))
+		// New values are encrypted with the current key, while old ones are still readable.
+		u := client2.User.Create().SetName("ariel").SetEmail("ariel@entgo.io").SetSsn("444").SaveX(ctx)
+		_, _, _, keys := raw(t, u.ID)
+		require.Equal(t, []string{"v2", "v2"}, keys)
+		require.Equal(t, "112", client2.User.GetX(ctx, a8m.ID).Ssn)
+
+		n, err := client2.User.Reencrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, n, "only values that were encrypted with an old key are updated")
+		_, _, _, keys = raw(t, a8m.ID)
+		require.Equal(t, []string{"v2", "v2", "v2"}, keys)
+		u = client2.User.Query().Where(user.Email("a8m@entgo.io")).OnlyX(ctx)
+		require.Equal(t, "112", u.Ssn)
+		require.Equal(t, []byte("t1"), u.Token)
+		n, err = client2.User.Reencrypt(ctx)
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		n, err = client2.Node.Reencrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+		require.Equal(t, []string{"n1", "n2"}, client2.Node.Query().Select(node.FieldSecret).StringsX(ctx))
+
+		// Clients without the new key cannot read the re-encrypted values.
+		_, err = client.User.Get(ctx, a8m.ID)
+		require.Error(t, err)
+	})
+}
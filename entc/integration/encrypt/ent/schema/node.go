@@ -0,0 +1,24 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Node holds the schema definition for the Node entity. Its package name
+// conflicts with local names of the generated builders.
+type Node struct {
+	ent.Schema
+}
+
+// Fields of the Node.
+func (Node) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("secret").
+			Encrypted(),
+	}
+}
@@ -0,0 +1,24 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Spec holds the schema definition for the Spec entity. Its package name
+// conflicts with local names of the generated builders.
+type Spec struct {
+	ent.Schema
+}
+
+// Fields of the Spec.
+func (Spec) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("secret").
+			Encrypted(),
+	}
+}
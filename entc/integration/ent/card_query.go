@@ -513,8 +513,8 @@ func (cq *CardQuery) loadSpec(ctx context.Context, query *SpecQuery, nodes []*Ca
 			init(node)
 		}
 	}
+	joinT := sql.Table(card.SpecTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(card.SpecTable)
 		s.Join(joinT).On(s.C(spec.FieldID), joinT.C(card.SpecPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(card.SpecPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -526,7 +526,10 @@ func (cq *CardQuery) loadSpec(ctx context.Context, query *SpecQuery, nodes []*Ca
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(card.SpecPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -647,5 +647,49 @@ func scanWithInterceptors[Q1 ent.Query, Q2 interface {
 	return nil
 }
 
+// IsUniqueConstraintError returns a boolean indicating whether the error is a unique constraint failure.
+// The failed constraint, table and column (if reported by the database) can be extracted using errors.As with *sql.Error.
+func IsUniqueConstraintError(err error) bool {
+	var e *ConstraintError
+	return errors.As(err, &e) && e.wrap != nil && sqlgraph.IsUniqueConstraintError(e.wrap)
+}
+
+// IsForeignKeyConstraintError returns a boolean indicating whether the error is a foreign key constraint failure.
+// The failed constraint, table and column (if reported by the database) can be extracted using errors.As with *sql.Error.
+func IsForeignKeyConstraintError(err error) bool {
+	var e *ConstraintError
+	return errors.As(err, &e) && e.wrap != nil && sqlgraph.IsForeignKeyConstraintError(e.wrap)
+}
+
+// IsNotNullConstraintError returns a boolean indicating whether the error is a not null constraint failure.
+// The failed constraint, table and column (if reported by the database) can be extracted using errors.As with *sql.Error.
+func IsNotNullConstraintError(err error) bool {
+	var e *ConstraintError
+	return errors.As(err, &e) && e.wrap != nil && sqlgraph.IsNotNullConstraintError(e.wrap)
+}
+
+// IsCheckConstraintError returns a boolean indicating whether the error is a check constraint failure.
+// The failed constraint, table and column (if reported by the database) can be extracted using errors.As with *sql.Error.
+func IsCheckConstraintError(err error) bool {
+	var e *ConstraintError
+	return errors.As(err, &e) && e.wrap != nil && sqlgraph.IsCheckConstraintError(e.wrap)
+}
+
 // queryHook describes an internal hook for the different sqlAll methods.
 type queryHook func(context.Context, *sqlgraph.QuerySpec)
+
+// limitNeighbors returns a queryHook for eager-loading queries that applies their
+// limit and offset on the neighbors of each node, instead of on the query as a whole.
+// The partitionBy function returns the column that identifies the node in the query.
+func limitNeighbors(partitionBy func(*sql.Selector) string) queryHook {
+	return func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		if spec.Limit == 0 && spec.Offset == 0 {
+			return
+		}
+		limit, offset := spec.Limit, spec.Offset
+		spec.Limit, spec.Offset = 0, 0
+		spec.Modifiers = append(spec.Modifiers, func(s *sql.Selector) {
+			sqlgraph.LimitNeighbors(partitionBy(s), limit, offset)(s)
+		})
+	}
+}
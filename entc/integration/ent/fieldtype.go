@@ -14,11 +14,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/ent/fieldtype"
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
-	"github.com/google/uuid"
 )
 
 // FieldType is the model entity for the FieldType schema.
@@ -11,11 +11,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/ent/predicate"
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
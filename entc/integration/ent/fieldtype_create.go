@@ -14,13 +14,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/ent/fieldtype"
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // FieldTypeCreate is the builder for creating a FieldType entity.
@@ -14,6 +14,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/dialect/sql/sqljson"
@@ -22,7 +23,6 @@ import (
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // FieldTypeUpdate is the builder for updating FieldType entities.
@@ -591,7 +591,16 @@ func (fq *FileQuery) loadField(ctx context.Context, query *FieldTypeQuery, nodes
 	query.Where(predicate.FieldType(func(s *sql.Selector) {
 		s.Where(sql.InValues(file.FieldColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(file.FieldColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*FieldType](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
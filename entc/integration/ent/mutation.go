@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/ent/card"
@@ -36,7 +37,6 @@ import (
 	"github.com/jogly/ent/entc/integration/ent/spec"
 	enttask "github.com/jogly/ent/entc/integration/ent/task"
 	"github.com/jogly/ent/entc/integration/ent/user"
-	"github.com/google/uuid"
 )
 
 const (
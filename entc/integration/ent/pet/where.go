@@ -7,10 +7,10 @@
 package pet
 
 import (
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
@@ -11,12 +11,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/ent/pet"
 	"github.com/jogly/ent/entc/integration/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // PetCreate is the builder for creating a Pet entity.
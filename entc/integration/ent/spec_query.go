@@ -408,8 +408,8 @@ func (sq *SpecQuery) loadCard(ctx context.Context, query *CardQuery, nodes []*Sp
 			init(node)
 		}
 	}
+	joinT := sql.Table(spec.CardTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(spec.CardTable)
 		s.Join(joinT).On(s.C(card.FieldID), joinT.C(spec.CardPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(spec.CardPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -421,7 +421,10 @@ func (sq *SpecQuery) loadCard(ctx context.Context, query *CardQuery, nodes []*Sp
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(spec.CardPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
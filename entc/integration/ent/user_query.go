@@ -946,7 +946,16 @@ func (uq *UserQuery) loadPets(ctx context.Context, query *PetQuery, nodes []*Use
 	query.Where(predicate.Pet(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.PetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Pet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -977,7 +986,16 @@ func (uq *UserQuery) loadFiles(ctx context.Context, query *FileQuery, nodes []*U
 	query.Where(predicate.File(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.FilesColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.FilesColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*File](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -1005,8 +1023,8 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.GroupsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.GroupsTable)
 		s.Join(joinT).On(s.C(group.FieldID), joinT.C(user.GroupsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.GroupsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1018,7 +1036,10 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.GroupsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1066,8 +1087,8 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FriendsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FriendsTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FriendsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.FriendsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1079,7 +1100,10 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FriendsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1127,8 +1151,8 @@ func (uq *UserQuery) loadFollowers(ctx context.Context, query *UserQuery, nodes
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FollowersTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FollowersTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FollowersPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(user.FollowersPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1140,7 +1164,10 @@ func (uq *UserQuery) loadFollowers(ctx context.Context, query *UserQuery, nodes
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FollowersPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1188,8 +1215,8 @@ func (uq *UserQuery) loadFollowing(ctx context.Context, query *UserQuery, nodes
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FollowingTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FollowingTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FollowingPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.FollowingPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -1201,7 +1228,10 @@ func (uq *UserQuery) loadFollowing(ctx context.Context, query *UserQuery, nodes
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FollowingPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -1312,7 +1342,16 @@ func (uq *UserQuery) loadChildren(ctx context.Context, query *UserQuery, nodes [
 	query.Where(predicate.User(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,75 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package explain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/dialect/sql/sqlgraph"
+	"github.com/jogly/ent/entc/integration/explain/ent"
+	"github.com/jogly/ent/entc/integration/explain/ent/enttest"
+	"github.com/jogly/ent/entc/integration/explain/ent/pet"
+	"github.com/jogly/ent/entc/integration/explain/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	client.Pet.Create().SetName("pedro").SetOwner(a8m).ExecX(ctx)
+
+	t.Run("Query", func(t *testing.T) {
+		plan, err := client.User.Query().Where(user.Name("a8m")).Explain(ctx)
+		require.NoError(t, err)
+		require.True(t, plan.UsesIndex("user_name"), plan.String())
+		require.Len(t, plan.Nodes, 1)
+		require.Equal(t, "users", plan.Nodes[0].Table)
+
+		plan, err = client.User.Query().Where(user.Age(30)).Explain(ctx)
+		require.NoError(t, err)
+		require.False(t, plan.UsesIndex("user_name"))
+		require.Contains(t, plan.String(), "SCAN users")
+
+		plan, err = client.User.Query().Where(user.ID(a8m.ID)).Explain(ctx)
+		require.NoError(t, err)
+		require.True(t, plan.UsesIndex("PRIMARY KEY"), plan.String())
+
+		// Traversals are explained as a single query.
+		plan, err = a8m.QueryPets().Where(pet.Name("pedro")).Explain(ctx)
+		require.NoError(t, err)
+		var tables []string
+		plan.Walk(func(n *sqlgraph.PlanNode) bool {
+			if n.Table != "" {
+				tables = append(tables, n.Table)
+			}
+			return true
+		})
+		require.Contains(t, tables, "pets")
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		plan, err := client.User.Query().Where(user.NameHasPrefix("a")).Select(user.FieldName).Explain(ctx)
+		require.NoError(t, err)
+		require.True(t, plan.UsesIndex("user_name"), plan.String())
+		require.Contains(t, plan.String(), "COVERING INDEX")
+
+		plan, err = client.User.Query().GroupBy(user.FieldName).Aggregate(ent.Count()).Explain(ctx)
+		require.NoError(t, err)
+		require.True(t, plan.UsesIndex("user_name"), plan.String())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := client.User.Query().Explain(ctx, sqlgraph.ExplainAnalyze())
+		require.EqualError(t, err, `sqlgraph: EXPLAIN ANALYZE is not supported by dialect "sqlite3"`)
+		_, err = client.User.Query().Select("unknown").Explain(ctx)
+		require.Error(t, err)
+	})
+}
@@ -14,11 +14,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin"
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/fieldtype"
-	"github.com/google/uuid"
 )
 
 // FieldType is the model entity for the FieldType schema.
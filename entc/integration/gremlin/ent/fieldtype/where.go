@@ -12,13 +12,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/__"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/p"
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
@@ -15,13 +15,13 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/g"
 	"github.com/jogly/ent/entc/integration/ent/role"
 	"github.com/jogly/ent/entc/integration/ent/schema"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/fieldtype"
-	"github.com/google/uuid"
 )
 
 // FieldTypeCreate is the builder for creating a FieldType entity.
@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/__"
@@ -23,7 +24,6 @@ import (
 	"github.com/jogly/ent/entc/integration/ent/schema"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/fieldtype"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // FieldTypeUpdate is the builder for updating FieldType entities.
@@ -16,6 +16,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl"
 	"github.com/jogly/ent/entc/integration/ent/role"
@@ -37,7 +38,6 @@ import (
 	"github.com/jogly/ent/entc/integration/gremlin/ent/spec"
 	enttask "github.com/jogly/ent/entc/integration/gremlin/ent/task"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/user"
-	"github.com/google/uuid"
 )
 
 const (
@@ -10,9 +10,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/user"
-	"github.com/google/uuid"
 )
 
 // Pet is the model entity for the Pet schema.
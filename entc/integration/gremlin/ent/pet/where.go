@@ -7,11 +7,11 @@
 package pet
 
 import (
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/__"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/p"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/predicate"
-	"github.com/google/uuid"
 )
 
 // ID filters vertices based on their ID field.
@@ -10,6 +10,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/gremlin"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl"
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/__"
@@ -17,7 +18,6 @@ import (
 	"github.com/jogly/ent/dialect/gremlin/graph/dsl/p"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/pet"
 	"github.com/jogly/ent/entc/integration/gremlin/ent/user"
-	"github.com/google/uuid"
 )
 
 // PetCreate is the builder for creating a Pet entity.
@@ -0,0 +1,105 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package hints
+
+import (
+	"context"
+	stdsql "database/sql"
+	"testing"
+	"time"
+
+	"github.com/jogly/ent/dialect"
+	"github.com/jogly/ent/dialect/sql"
+	"github.com/jogly/ent/entc/integration/hints/ent"
+	"github.com/jogly/ent/entc/integration/hints/ent/enttest"
+	"github.com/jogly/ent/entc/integration/hints/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// deadlines records whether the queries that were executed had a deadline.
+type deadlines struct {
+	db      *stdsql.DB
+	queries []bool
+}
+
+func (d *deadlines) DB() *stdsql.DB { return d.db }
+
+func (d *deadlines) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	return d.db.ExecContext(ctx, query, args...)
+}
+
+func (d *deadlines) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	_, ok := ctx.Deadline()
+	d.queries = append(d.queries, ok)
+	return d.db.QueryContext(ctx, query, args...)
+}
+
+func TestHints(t *testing.T) {
+	db, err := stdsql.Open(dialect.SQLite, "file:ent?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+	rec := &deadlines{db: db}
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(sql.NewDriver(dialect.SQLite, sql.Conn{ExecQuerier: rec}))))
+	defer client.Close()
+	ctx := context.Background()
+
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SetAge(30).SaveX(ctx)
+	client.Pet.Create().SetName("pedro").SetOwner(a8m).ExecX(ctx)
+	client.Pet.Create().SetName("xabi").SetOwner(nati).ExecX(ctx)
+
+	t.Run("Timeout", func(t *testing.T) {
+		rec.queries = nil
+		users := client.User.Query().WithPets().WithTimeout(time.Minute).AllX(ctx)
+		require.Len(t, users, 2)
+		for _, u := range users {
+			require.Len(t, u.Edges.Pets, 1)
+		}
+		require.Equal(t, []bool{true, true}, rec.queries, "timeout applies to eager-loaded edges")
+
+		rec.queries = nil
+		client.User.Query().AllX(ctx)
+		require.Equal(t, []bool{false}, rec.queries)
+
+		rec.queries = nil
+		names := client.User.Query().Select(user.FieldName).WithTimeout(time.Minute).StringsX(ctx)
+		require.ElementsMatch(t, []string{"a8m", "nati"}, names)
+		var v []struct {
+			Age   int `json:"age"`
+			Count int `json:"count"`
+		}
+		client.User.Query().GroupBy(user.FieldAge).Aggregate(ent.Count()).WithTimeout(time.Minute).ScanX(ctx, &v)
+		require.Len(t, v, 1)
+		require.Equal(t, 2, v[0].Count)
+		require.Equal(t, []bool{true, true}, rec.queries)
+
+		// Expired statements fail with the context error, without cancelling the caller context.
+		_, err := client.User.Query().WithTimeout(time.Nanosecond).All(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NoError(t, ctx.Err())
+	})
+
+	t.Run("Hints", func(t *testing.T) {
+		u := client.User.Query().
+			Where(user.Name("a8m")).
+			WithHints(sql.ForceIndex("user_name")).
+			OnlyX(ctx)
+		require.Equal(t, a8m.ID, u.ID)
+		require.Equal(t, 2, client.User.Query().WithHints(sql.IgnoreIndex("user_name")).CountX(ctx))
+		require.Equal(t, 2, client.User.Query().WithHints(sql.OptimizerHint("NO_INDEX_MERGE(users)")).CountX(ctx))
+		names := client.User.Query().
+			Where(user.NameNEQ("a8m")).
+			Select(user.FieldName).
+			WithHints(sql.UseIndex("user_name")).
+			StringsX(ctx)
+		require.Equal(t, []string{"nati"}, names)
+
+		_, err := client.User.Query().WithHints(sql.ForceIndex("unknown")).All(ctx)
+		require.ErrorContains(t, err, "no such index: unknown")
+		_, err = client.User.Query().WithHints(sql.UseIndex("user_name", "unknown")).All(ctx)
+		require.EqualError(t, err, "sql: INDEXED BY expects exactly one index, got 2")
+	})
+}
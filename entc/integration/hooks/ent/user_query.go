@@ -557,7 +557,16 @@ func (uq *UserQuery) loadCards(ctx context.Context, query *CardQuery, nodes []*U
 	query.Where(predicate.Card(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.CardsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.CardsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Card](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -588,7 +597,16 @@ func (uq *UserQuery) loadPets(ctx context.Context, query *PetQuery, nodes []*Use
 	query.Where(predicate.Pet(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.PetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Pet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -616,8 +634,8 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FriendsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FriendsTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FriendsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.FriendsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -629,7 +647,10 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FriendsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
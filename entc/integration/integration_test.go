@@ -1985,6 +1985,93 @@ func EagerLoading(t *testing.T, client *ent.Client) {
 		require.Len(users[2].Edges.Groups, 1)
 		require.Equal(users[2].Edges.Groups[0].Name, "BitBucket")
 	})
+
+	t.Run("Limit/O2M", func(t *testing.T) {
+		skip(t, "MySQL/5")
+		names := func(pets []*ent.Pet) []string {
+			names := make([]string, len(pets))
+			for i := range pets {
+				names[i] = pets[i].Name
+			}
+			return names
+		}
+		users := client.User.
+			Query().
+			WithPets(func(q *ent.PetQuery) {
+				q.Order(ent.Asc(pet.FieldName)).Limit(2)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Equal([]string{"xabi1", "xabi2"}, names(users[0].Edges.Pets))
+		require.Equal([]string{"nala"}, names(users[1].Edges.Pets))
+		require.Equal([]string{"lola1", "lola2"}, names(users[2].Edges.Pets))
+
+		users = client.User.
+			Query().
+			WithPets(func(q *ent.PetQuery) {
+				q.Order(ent.Asc(pet.FieldName)).Offset(1).Limit(2)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Equal([]string{"xabi2", "xabi3"}, names(users[0].Edges.Pets))
+		require.Empty(users[1].Edges.Pets)
+		require.Equal([]string{"lola2", "lola3"}, names(users[2].Edges.Pets))
+
+		users = client.User.
+			Query().
+			WithPets(func(q *ent.PetQuery) {
+				q.Order(ent.Asc(pet.FieldName)).Offset(3)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Empty(users[0].Edges.Pets)
+		require.Empty(users[1].Edges.Pets)
+		require.Equal([]string{"lola4"}, names(users[2].Edges.Pets))
+	})
+
+	t.Run("Limit/M2M", func(t *testing.T) {
+		skip(t, "MySQL/5")
+		users := client.User.
+			Query().
+			WithGroups(func(q *ent.GroupQuery) {
+				q.Order(ent.Desc(group.FieldName)).Limit(1)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Len(users[0].Edges.Groups, 1)
+		require.Equal("GitLab", users[0].Edges.Groups[0].Name)
+		require.Len(users[1].Edges.Groups, 1)
+		require.Equal("GitLab", users[1].Edges.Groups[0].Name)
+		require.Len(users[2].Edges.Groups, 1)
+		require.Equal("GitHub", users[2].Edges.Groups[0].Name)
+
+		users = client.User.
+			Query().
+			WithGroups(func(q *ent.GroupQuery) {
+				q.Order(ent.Asc(group.FieldName)).Offset(1).Limit(1)
+			}).
+			Order(ent.Asc(user.FieldID)).
+			AllX(ctx)
+		require.Len(users[0].Edges.Groups, 1)
+		require.Equal("GitHub", users[0].Edges.Groups[0].Name)
+		require.Empty(users[1].Edges.Groups)
+		require.Len(users[2].Edges.Groups, 1)
+		require.Equal("GitHub", users[2].Edges.Groups[0].Name)
+
+		// Inverse edge, limited per group.
+		groups := client.Group.
+			Query().
+			WithUsers(func(q *ent.UserQuery) {
+				q.Order(ent.Desc(user.FieldName)).Limit(1)
+			}).
+			Order(ent.Asc(group.FieldName)).
+			AllX(ctx)
+		require.Len(groups, 3)
+		for i, name := range []string{"alexsn", "alexsn", "nati"} {
+			require.Len(groups[i].Edges.Users, 1)
+			require.Equal(name, groups[i].Edges.Users[0].Name)
+		}
+	})
 }
 
 func limitRows(partitionBy string, limit int, orderBy ...string) func(s *sql.Selector) {
@@ -0,0 +1,141 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package iter
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/iter/ent"
+	"github.com/jogly/ent/entc/integration/iter/ent/enttest"
+	"github.com/jogly/ent/entc/integration/iter/ent/pet"
+	"github.com/jogly/ent/entc/integration/iter/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIter(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	// Create enough users to span multiple eager-loading batches,
+	// where every user holds a number of pets that equals age%3.
+	const n = 250
+	builders := make([]*ent.UserCreate, n)
+	for i := range builders {
+		builders[i] = client.User.Create().SetAge(i)
+	}
+	users := client.User.CreateBulk(builders...).SaveX(ctx)
+	var pets []*ent.PetCreate
+	for _, u := range users {
+		for j := 0; j < u.Age%3; j++ {
+			pets = append(pets, client.Pet.Create().SetName(strconv.Itoa(u.Age)).SetOwner(u))
+		}
+	}
+	client.Pet.CreateBulk(pets...).ExecX(ctx)
+
+	t.Run("Iter", func(t *testing.T) {
+		iter, err := client.User.Query().WithPets().Iter(ctx)
+		require.NoError(t, err)
+		defer iter.Close()
+		var count int
+		for iter.Next() {
+			u := iter.Value()
+			pets, err := u.Edges.PetsOrErr()
+			require.NoError(t, err, "pets are loaded for all nodes")
+			require.Len(t, pets, u.Age%3)
+			for _, p := range pets {
+				require.Equal(t, strconv.Itoa(u.Age), p.Name)
+			}
+			count++
+		}
+		require.NoError(t, iter.Err())
+		require.Equal(t, n, count)
+		require.False(t, iter.Next())
+		require.NoError(t, iter.Close())
+
+		iter, err = client.User.Query().Where(user.AgeGTE(n)).Iter(ctx)
+		require.NoError(t, err)
+		require.False(t, iter.Next())
+		require.NoError(t, iter.Err())
+		require.NoError(t, iter.Close())
+	})
+
+	t.Run("ForEach", func(t *testing.T) {
+		var sum int
+		err := client.User.Query().
+			Where(user.AgeLT(10)).
+			Order(ent.Desc(user.FieldAge)).
+			ForEach(ctx, func(u *ent.User) error {
+				require.Equal(t, 9-sum, u.Age)
+				sum++
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, 10, sum)
+
+		errStop := errors.New("stop")
+		var visited int
+		err = client.User.Query().ForEach(ctx, func(*ent.User) error {
+			if visited++; visited == 3 {
+				return errStop
+			}
+			return nil
+		})
+		require.ErrorIs(t, err, errStop)
+		require.Equal(t, 3, visited)
+	})
+
+	t.Run("Batches", func(t *testing.T) {
+		var (
+			sizes []int
+			ages  []int
+		)
+		err := client.User.Query().
+			WithPets(func(q *ent.PetQuery) {
+				q.Where(pet.HasOwnerWith(user.AgeGT(100)))
+			}).
+			Batches(ctx, 100, func(users []*ent.User) error {
+				sizes = append(sizes, len(users))
+				for _, u := range users {
+					ages = append(ages, u.Age)
+					pets, err := u.Edges.PetsOrErr()
+					require.NoError(t, err)
+					if u.Age > 100 {
+						require.Len(t, pets, u.Age%3)
+					} else {
+						require.Empty(t, pets)
+					}
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, []int{100, 100, 50}, sizes)
+		for i := range ages {
+			require.Equal(t, i, ages[i], "batches are ordered by the primary key")
+		}
+
+		sizes = sizes[:0]
+		err = client.User.Query().
+			Where(user.AgeGTE(200)).
+			Batches(ctx, 25, func(users []*ent.User) error {
+				sizes = append(sizes, len(users))
+				return nil
+			})
+		require.NoError(t, err)
+		require.Equal(t, []int{25, 25}, sizes)
+
+		err = client.User.Query().Batches(ctx, 0, func([]*ent.User) error { return nil })
+		require.EqualError(t, err, "ent: invalid batch size 0 for User batches")
+		err = client.User.Query().Order(ent.Asc(user.FieldAge)).Batches(ctx, 10, func([]*ent.User) error { return nil })
+		require.EqualError(t, err, "ent: ordering is not supported by User batches")
+		err = client.User.Query().Limit(10).Batches(ctx, 10, func([]*ent.User) error { return nil })
+		require.EqualError(t, err, "ent: limit and offset are not supported by User batches")
+	})
+}
@@ -323,8 +323,8 @@ func (m *UserMutation) ResetURLs() {
 }
 
 // SetRaw sets the "raw" field.
-func (m *UserMutation) SetRaw(jm json.RawMessage) {
-	m.raw = &jm
+func (m *UserMutation) SetRaw(j json.RawMessage) {
+	m.raw = &j
 	m.appendraw = nil
 }
 
@@ -354,9 +354,9 @@ func (m *UserMutation) OldRaw(ctx context.Context) (v json.RawMessage, err error
 	return oldValue.Raw, nil
 }
 
-// AppendRaw adds jm to the "raw" field.
-func (m *UserMutation) AppendRaw(jm json.RawMessage) {
-	m.appendraw = append(m.appendraw, jm...)
+// AppendRaw adds j to the "raw" field.
+func (m *UserMutation) AppendRaw(j json.RawMessage) {
+	m.appendraw = append(m.appendraw, j...)
 }
 
 // AppendedRaw returns the list of values that were appended to the "raw" field in this mutation.
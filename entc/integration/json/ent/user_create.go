@@ -46,8 +46,8 @@ func (uc *UserCreate) SetURLs(u []*url.URL) *UserCreate {
 }
 
 // SetRaw sets the "raw" field.
-func (uc *UserCreate) SetRaw(jm json.RawMessage) *UserCreate {
-	uc.mutation.SetRaw(jm)
+func (uc *UserCreate) SetRaw(j json.RawMessage) *UserCreate {
+	uc.mutation.SetRaw(j)
 	return uc
 }
 
@@ -80,14 +80,14 @@ func (uu *UserUpdate) ClearURLs() *UserUpdate {
 }
 
 // SetRaw sets the "raw" field.
-func (uu *UserUpdate) SetRaw(jm json.RawMessage) *UserUpdate {
-	uu.mutation.SetRaw(jm)
+func (uu *UserUpdate) SetRaw(j json.RawMessage) *UserUpdate {
+	uu.mutation.SetRaw(j)
 	return uu
 }
 
-// AppendRaw appends jm to the "raw" field.
-func (uu *UserUpdate) AppendRaw(jm json.RawMessage) *UserUpdate {
-	uu.mutation.AppendRaw(jm)
+// AppendRaw appends j to the "raw" field.
+func (uu *UserUpdate) AppendRaw(j json.RawMessage) *UserUpdate {
+	uu.mutation.AppendRaw(j)
 	return uu
 }
 
@@ -376,14 +376,14 @@ func (uuo *UserUpdateOne) ClearURLs() *UserUpdateOne {
 }
 
 // SetRaw sets the "raw" field.
-func (uuo *UserUpdateOne) SetRaw(jm json.RawMessage) *UserUpdateOne {
-	uuo.mutation.SetRaw(jm)
+func (uuo *UserUpdateOne) SetRaw(j json.RawMessage) *UserUpdateOne {
+	uuo.mutation.SetRaw(j)
 	return uuo
 }
 
-// AppendRaw appends jm to the "raw" field.
-func (uuo *UserUpdateOne) AppendRaw(jm json.RawMessage) *UserUpdateOne {
-	uuo.mutation.AppendRaw(jm)
+// AppendRaw appends j to the "raw" field.
+func (uuo *UserUpdateOne) AppendRaw(j json.RawMessage) *UserUpdateOne {
+	uuo.mutation.AppendRaw(j)
 	return uuo
 }
 
@@ -0,0 +1,24 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/entc/integration/jsonpath/ent/schema/settings"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Account holds the schema definition for the Account entity.
+type Account struct {
+	ent.Schema
+}
+
+// Fields of the Account.
+func (Account) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.JSON("settings", settings.Settings{}),
+	}
+}
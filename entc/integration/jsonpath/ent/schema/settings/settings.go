@@ -0,0 +1,31 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package settings
+
+// Plan of an account.
+type Plan string
+
+// Account plans.
+const (
+	PlanFree Plan = "free"
+	PlanPro  Plan = "pro"
+)
+
+type (
+	// Settings of an account.
+	Settings struct {
+		Plan   Plan     `json:"plan"`
+		Active bool     `json:"active"`
+		Limits Limits   `json:"limits"`
+		Tags   []string `json:"tags"`
+		Scores []int    `json:"scores"`
+		Data   []byte   `json:"data,omitempty"`
+	}
+	// Limits of an account plan.
+	Limits struct {
+		Max   int     `json:"max"`
+		Ratio float64 `json:"ratio"`
+	}
+)
@@ -0,0 +1,90 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package jsonpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/jsonpath/ent"
+	"github.com/jogly/ent/entc/integration/jsonpath/ent/account"
+	"github.com/jogly/ent/entc/integration/jsonpath/ent/enttest"
+	"github.com/jogly/ent/entc/integration/jsonpath/ent/schema/settings"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	client.Account.CreateBulk(
+		client.Account.Create().SetName("a8m").SetSettings(settings.Settings{
+			Plan:   settings.PlanPro,
+			Active: true,
+			Limits: settings.Limits{Max: 100, Ratio: 0.5},
+			Tags:   []string{"admin", "dev"},
+			Scores: []int{1, 2},
+		}),
+		client.Account.Create().SetName("nati").SetSettings(settings.Settings{
+			Plan:   settings.PlanFree,
+			Active: true,
+			Limits: settings.Limits{Max: 10, Ratio: 0.25},
+			Tags:   []string{"dev"},
+			Scores: []int{3},
+		}),
+		client.Account.Create().SetName("neta").SetSettings(settings.Settings{
+			Plan:   settings.PlanFree,
+			Limits: settings.Limits{Max: 1, Ratio: 1},
+		}),
+	).ExecX(ctx)
+
+	names := func(t *testing.T, q *ent.AccountQuery) []string {
+		t.Helper()
+		return q.Order(ent.Asc(account.FieldName)).Select(account.FieldName).StringsX(ctx)
+	}
+
+	t.Run("Predicates", func(t *testing.T) {
+		require.Equal(t, []string{"a8m"}, names(t, client.Account.Query().Where(account.SettingsPlanEQ(settings.PlanPro))))
+		require.Equal(t, []string{"nati", "neta"}, names(t, client.Account.Query().Where(account.SettingsPlanNEQ(settings.PlanPro))))
+		require.Equal(t, []string{"a8m", "nati", "neta"}, names(t, client.Account.Query().Where(account.SettingsPlanIn(settings.PlanFree, settings.PlanPro))))
+		require.Equal(t, []string{"a8m"}, names(t, client.Account.Query().Where(account.SettingsPlanNotIn(settings.PlanFree))))
+		require.Equal(t, []string{"a8m"}, names(t, client.Account.Query().Where(account.SettingsPlanHasPrefix("p"))))
+		require.Equal(t, []string{"nati", "neta"}, names(t, client.Account.Query().Where(account.SettingsPlanHasSuffix("ee"))))
+		require.Equal(t, []string{"nati", "neta"}, names(t, client.Account.Query().Where(account.SettingsPlanContains("re"))))
+
+		require.Equal(t, []string{"a8m", "nati"}, names(t, client.Account.Query().Where(account.SettingsActiveEQ(true))))
+		require.Equal(t, []string{"neta"}, names(t, client.Account.Query().Where(account.SettingsActiveNEQ(true))))
+
+		require.Equal(t, []string{"nati"}, names(t, client.Account.Query().Where(account.SettingsLimitsMaxEQ(10))))
+		require.Equal(t, []string{"a8m", "nati"}, names(t, client.Account.Query().Where(account.SettingsLimitsMaxGT(1))))
+		require.Equal(t, []string{"nati", "neta"}, names(t, client.Account.Query().Where(account.SettingsLimitsMaxLTE(10))))
+		require.Equal(t, []string{"a8m", "neta"}, names(t, client.Account.Query().Where(account.SettingsLimitsMaxIn(1, 100))))
+		require.Equal(t, []string{"a8m", "neta"}, names(t, client.Account.Query().Where(account.SettingsLimitsRatioGTE(0.5))))
+		require.Equal(t, []string{"nati"}, names(t, client.Account.Query().Where(account.SettingsLimitsRatioLT(0.5))))
+
+		require.Equal(t, []string{"a8m", "nati"}, names(t, client.Account.Query().Where(account.SettingsTagsContains("dev"))))
+		require.Equal(t, []string{"a8m"}, names(t, client.Account.Query().Where(account.SettingsTagsContains("admin"))))
+		require.Equal(t, []string{"nati"}, names(t, client.Account.Query().Where(account.SettingsScoresContains(3))))
+		require.Empty(t, names(t, client.Account.Query().Where(account.SettingsScoresContains(4))))
+
+		// Predicates can be combined with the standard ones.
+		n := client.Account.Query().
+			Where(account.SettingsPlanEQ(settings.PlanFree), account.Or(account.SettingsLimitsMaxGT(5), account.SettingsTagsContains("admin"))).
+			CountX(ctx)
+		require.Equal(t, 1, n)
+	})
+
+	t.Run("Orders", func(t *testing.T) {
+		ordered := client.Account.Query().Order(account.OrderBySettingsLimitsMax()).Select(account.FieldName).StringsX(ctx)
+		require.Equal(t, []string{"neta", "nati", "a8m"}, ordered)
+		ordered = client.Account.Query().Order(account.OrderBySettingsLimitsRatioDesc()).Select(account.FieldName).StringsX(ctx)
+		require.Equal(t, []string{"neta", "a8m", "nati"}, ordered)
+		ordered = client.Account.Query().Order(account.OrderBySettingsPlanDesc(), account.OrderBySettingsLimitsMax()).Select(account.FieldName).StringsX(ctx)
+		require.Equal(t, []string{"a8m", "neta", "nati"}, ordered)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/schema/edge"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Node holds the schema definition for the Node entity.
+type Node struct {
+	ent.Schema
+}
+
+// Fields of the Node.
+func (Node) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("value"),
+	}
+}
+
+// Edges of the Node.
+func (Node) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("next", Node.Type).
+			Unique().
+			From("prev").
+			Unique(),
+	}
+}
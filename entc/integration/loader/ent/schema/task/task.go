@@ -0,0 +1,15 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package task
+
+// Priority of a task. Its package name conflicts with the
+// generated package of the Task type, which is then aliased.
+type Priority int
+
+// Task priorities.
+const (
+	PriorityLow Priority = iota
+	PriorityHigh
+)
@@ -0,0 +1,230 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package loader
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jogly/ent/entc/integration/loader/ent"
+	"github.com/jogly/ent/entc/integration/loader/ent/enttest"
+	"github.com/jogly/ent/entc/integration/loader/ent/schema/task"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+type viewerKey struct{}
+
+// queries records the queries that are executed by the client,
+// and the viewers of the contexts they are executed with.
+type queries struct {
+	sync.Mutex
+	types   []string
+	viewers []any
+}
+
+func (q *queries) intercept(next ent.Querier) ent.Querier {
+	return ent.QuerierFunc(func(ctx context.Context, query ent.Query) (ent.Value, error) {
+		q.Lock()
+		switch query.(type) {
+		case *ent.UserQuery:
+			q.types = append(q.types, "user")
+		case *ent.PetQuery:
+			q.types = append(q.types, "pet")
+		case *ent.NodeQuery:
+			q.types = append(q.types, "node")
+		case *ent.TaskQuery:
+			q.types = append(q.types, "task")
+		}
+		q.viewers = append(q.viewers, ctx.Value(viewerKey{}))
+		q.Unlock()
+		return next.Query(ctx, query)
+	})
+}
+
+func (q *queries) reset() {
+	q.Lock()
+	defer q.Unlock()
+	q.types, q.viewers = nil, nil
+}
+
+func TestLoader(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").AddFriends(a8m).SaveX(ctx)
+	pedro := client.Pet.Create().SetName("pedro").SetOwner(a8m).SaveX(ctx)
+	xabi := client.Pet.Create().SetName("xabi").SetOwner(a8m).SaveX(ctx)
+	stray := client.Pet.Create().SetName("stray").SaveX(ctx)
+	n1 := client.Node.Create().SetValue(1).SaveX(ctx)
+	n2 := client.Node.Create().SetValue(2).SetPrev(n1).SaveX(ctx)
+	t1 := client.Task.Create().SetTitle("t1").SetOwner(a8m).SaveX(ctx)
+	t2 := client.Task.Create().SetTitle("t2").SetPriority(task.PriorityHigh).SaveX(ctx)
+
+	qs := &queries{}
+	client.Intercept(ent.InterceptFunc(qs.intercept))
+	ctx = context.WithValue(ctx, viewerKey{}, "a8m")
+	// run executes the given lookups concurrently using a new loader.
+	run := func(opts []ent.LoaderOption, fns ...func(context.Context, *ent.Loader)) {
+		qs.reset()
+		l := ent.NewLoader(ctx, client, append([]ent.LoaderOption{ent.LoaderWait(20 * time.Millisecond)}, opts...)...)
+		lctx := ent.NewLoaderContext(ctx, l)
+		var wg sync.WaitGroup
+		for _, fn := range fns {
+			wg.Add(1)
+			go func(fn func(context.Context, *ent.Loader)) {
+				defer wg.Done()
+				fn(lctx, l)
+			}(fn)
+		}
+		wg.Wait()
+	}
+
+	t.Run("Batch", func(t *testing.T) {
+		var u1, u2 *ent.User
+		var p1 *ent.Pet
+		run(nil,
+			func(ctx context.Context, _ *ent.Loader) { u1 = client.User.GetX(ctx, a8m.ID) },
+			func(ctx context.Context, _ *ent.Loader) { u2 = client.User.GetX(ctx, nati.ID) },
+			func(ctx context.Context, _ *ent.Loader) { p1 = client.Pet.GetX(ctx, pedro.ID) },
+		)
+		require.Equal(t, "a8m", u1.Name)
+		require.Equal(t, "nati", u2.Name)
+		require.Equal(t, "pedro", p1.Name)
+		require.ElementsMatch(t, []string{"user", "pet"}, qs.types, "one query for each type")
+
+		var pets, natiPets []*ent.Pet
+		var owner *ent.User
+		var friends []*ent.User
+		run(nil,
+			func(ctx context.Context, l *ent.Loader) { pets, _ = l.User.LoadPets(ctx, a8m) },
+			func(ctx context.Context, l *ent.Loader) { natiPets, _ = l.User.LoadPets(ctx, nati) },
+			func(ctx context.Context, l *ent.Loader) { owner, _ = l.Pet.LoadOwner(ctx, xabi) },
+			func(ctx context.Context, l *ent.Loader) { friends, _ = l.User.LoadFriends(ctx, nati) },
+		)
+		require.Len(t, pets, 2)
+		require.Empty(t, natiPets)
+		require.Equal(t, a8m.ID, owner.ID)
+		require.Len(t, friends, 1)
+		require.Equal(t, a8m.ID, friends[0].ID)
+
+		// Batches are limited by their maximum size.
+		run([]ent.LoaderOption{ent.LoaderMaxBatch(1)},
+			func(ctx context.Context, l *ent.Loader) { client.User.GetX(ctx, a8m.ID) },
+			func(ctx context.Context, l *ent.Loader) { client.User.GetX(ctx, nati.ID) },
+		)
+		require.Equal(t, []string{"user", "user"}, qs.types)
+	})
+
+	// Types whose package names conflict with local names in the generated code.
+	t.Run("Conflicts", func(t *testing.T) {
+		var (
+			nodes      [2]*ent.Node
+			next, prev *ent.Node
+			tasks      [2]*ent.Task
+			owner      *ent.User
+			errs       [2]error
+			ownerTasks []*ent.Task
+		)
+		run(nil,
+			func(ctx context.Context, _ *ent.Loader) { nodes[0] = client.Node.GetX(ctx, n1.ID) },
+			func(ctx context.Context, _ *ent.Loader) { nodes[1] = client.Node.GetX(ctx, n2.ID) },
+			func(ctx context.Context, _ *ent.Loader) { tasks[0] = client.Task.GetX(ctx, t1.ID) },
+			func(ctx context.Context, _ *ent.Loader) { tasks[1] = client.Task.GetX(ctx, t2.ID) },
+		)
+		require.Equal(t, 1, nodes[0].Value)
+		require.Equal(t, 2, nodes[1].Value)
+		require.Equal(t, "t1", tasks[0].Title)
+		require.Equal(t, task.PriorityHigh, tasks[1].Priority)
+		require.ElementsMatch(t, []string{"node", "task"}, qs.types)
+
+		run(nil,
+			func(ctx context.Context, l *ent.Loader) { next, _ = l.Node.LoadNext(ctx, n1) },
+			func(ctx context.Context, l *ent.Loader) { prev, _ = l.Node.LoadPrev(ctx, n2) },
+			func(ctx context.Context, l *ent.Loader) { _, errs[0] = l.Node.LoadNext(ctx, n2) },
+			func(ctx context.Context, l *ent.Loader) { owner, _ = l.Task.LoadOwner(ctx, t1) },
+			func(ctx context.Context, l *ent.Loader) { _, errs[1] = l.Task.LoadOwner(ctx, t2) },
+			func(ctx context.Context, l *ent.Loader) { ownerTasks, _ = l.User.LoadTasks(ctx, a8m) },
+		)
+		require.Equal(t, n2.ID, next.ID)
+		require.Equal(t, n1.ID, prev.ID)
+		require.Equal(t, a8m.ID, owner.ID)
+		require.Len(t, ownerTasks, 1)
+		require.Equal(t, t1.ID, ownerTasks[0].ID)
+		for _, err := range errs {
+			require.True(t, ent.IsNotFound(err))
+		}
+		_, err := client.Task.Get(ent.NewLoaderContext(ctx, ent.NewLoader(ctx, client)), t2.ID+100)
+		require.EqualError(t, err, "ent: task not found")
+	})
+
+	t.Run("Dedup", func(t *testing.T) {
+		users := make([]*ent.User, 3)
+		fns := make([]func(context.Context, *ent.Loader), len(users))
+		for i := range fns {
+			i := i
+			fns[i] = func(ctx context.Context, l *ent.Loader) { users[i] = client.User.GetX(ctx, a8m.ID) }
+		}
+		run(nil, fns...)
+		require.Equal(t, []string{"user"}, qs.types)
+		require.Same(t, users[0], users[1])
+		require.Same(t, users[1], users[2])
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		var errs [2]error
+		var u *ent.User
+		run(nil,
+			func(ctx context.Context, _ *ent.Loader) { u, errs[0] = client.User.Get(ctx, a8m.ID) },
+			func(ctx context.Context, _ *ent.Loader) { _, errs[1] = client.User.Get(ctx, nati.ID+100) },
+		)
+		require.NoError(t, errs[0])
+		require.Equal(t, a8m.ID, u.ID)
+		require.True(t, ent.IsNotFound(errs[1]))
+
+		run(nil, func(ctx context.Context, l *ent.Loader) { _, errs[0] = l.Pet.LoadOwner(ctx, stray) })
+		require.True(t, ent.IsNotFound(errs[0]))
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		var (
+			err   error
+			u     *ent.User
+			ready = make(chan struct{})
+		)
+		run(nil,
+			func(ctx context.Context, _ *ent.Loader) {
+				ctx, cancel := context.WithCancel(ctx)
+				go func() {
+					<-ready
+					cancel()
+				}()
+				_, err = client.User.Get(ctx, nati.ID)
+			},
+			func(ctx context.Context, _ *ent.Loader) {
+				// Joins the batch before the other lookup is canceled.
+				ctx = context.WithValue(ctx, viewerKey{}, "nati")
+				close(ready)
+				u = client.User.GetX(ctx, a8m.ID)
+			},
+		)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, a8m.ID, u.ID)
+		require.Equal(t, []string{"user"}, qs.types)
+		// Batches are executed with the context of the loader.
+		require.Equal(t, []any{"a8m"}, qs.viewers)
+
+		// Canceled lookups are not added to the batch.
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		l := ent.NewLoader(ctx, client)
+		_, err = l.User.Get(cctx, a8m.ID)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
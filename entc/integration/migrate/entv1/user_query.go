@@ -586,7 +586,16 @@ func (uq *UserQuery) loadChildren(ctx context.Context, query *UserQuery, nodes [
 	query.Where(predicate.User(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
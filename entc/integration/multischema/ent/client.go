@@ -71,6 +71,7 @@ type (
 		hooks *hooks
 		// interceptors to execute on queries.
 		inters *inters
+
 		// schemaConfig contains alternative names for all tables.
 		schemaConfig SchemaConfig
 	}
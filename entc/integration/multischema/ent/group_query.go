@@ -427,8 +427,8 @@ func (gq *GroupQuery) loadUsers(ctx context.Context, query *UserQuery, nodes []*
 			init(node)
 		}
 	}
+	joinT := sql.Table(group.UsersTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(group.UsersTable)
 		joinT.Schema(gq.schemaConfig.GroupUsers)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(group.UsersPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(group.UsersPrimaryKey[0]), edgeIDs...))
@@ -441,7 +441,10 @@ func (gq *GroupQuery) loadUsers(ctx context.Context, query *UserQuery, nodes []*
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(group.UsersPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
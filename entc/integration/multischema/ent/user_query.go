@@ -569,7 +569,16 @@ func (uq *UserQuery) loadPets(ctx context.Context, query *PetQuery, nodes []*Use
 	query.Where(predicate.Pet(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.PetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.PetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Pet](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -594,8 +603,8 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.GroupsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.GroupsTable)
 		joinT.Schema(uq.schemaConfig.GroupUsers)
 		s.Join(joinT).On(s.C(group.FieldID), joinT.C(user.GroupsPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(user.GroupsPrimaryKey[1]), edgeIDs...))
@@ -608,7 +617,10 @@ func (uq *UserQuery) loadGroups(ctx context.Context, query *GroupQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.GroupsPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -656,8 +668,8 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FriendsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FriendsTable)
 		joinT.Schema(uq.schemaConfig.Friendship)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FriendsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.FriendsPrimaryKey[0]), edgeIDs...))
@@ -670,7 +682,10 @@ func (uq *UserQuery) loadFriends(ctx context.Context, query *UserQuery, nodes []
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FriendsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -720,7 +735,16 @@ func (uq *UserQuery) loadFriendships(ctx context.Context, query *FriendshipQuery
 	query.Where(predicate.Friendship(func(s *sql.Selector) {
 		s.Where(sql.InValues(user.FriendshipsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(user.FriendshipsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Friendship](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,22 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Photo holds the schema definition for the Photo entity.
+type Photo struct {
+	ent.Schema
+}
+
+// Fields of the Photo.
+func (Photo) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("url"),
+	}
+}
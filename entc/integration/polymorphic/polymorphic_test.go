@@ -0,0 +1,102 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package polymorphic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/polymorphic/ent"
+	"github.com/jogly/ent/entc/integration/polymorphic/ent/comment"
+	"github.com/jogly/ent/entc/integration/polymorphic/ent/enttest"
+	"github.com/jogly/ent/entc/integration/polymorphic/ent/photo"
+	"github.com/jogly/ent/entc/integration/polymorphic/ent/post"
+	"github.com/jogly/ent/entql"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolymorphic(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	p := client.Post.Create().SetTitle("ent").SaveX(ctx)
+	ph := client.Photo.Create().SetURL("ent.png").SaveX(ctx)
+	tk := client.Ticket.Create().SetSubject("bug").SaveX(ctx)
+	c1 := client.Comment.Create().SetText("c1").SetTargetPost(p).SaveX(ctx)
+	c2 := client.Comment.Create().SetText("c2").SetTargetPhoto(ph).SaveX(ctx)
+	c3 := client.Comment.Create().SetText("c3").SetTargetTicket(tk).SaveX(ctx)
+	c4 := client.Comment.Create().SetText("c4").SaveX(ctx)
+	require.Equal(t, comment.TargetTypePost, *c1.TargetType)
+	require.Equal(t, p.ID, *c1.TargetID)
+	require.Nil(t, c4.TargetType)
+
+	// Photos and posts share the same identifiers, and only the
+	// neighbor that matches the type column should be returned.
+	require.Equal(t, p.ID, ph.ID)
+	require.Equal(t, p.ID, c1.QueryTargetPost().OnlyX(ctx).ID)
+	require.False(t, c1.QueryTargetPhoto().ExistX(ctx))
+	require.Equal(t, ph.ID, c2.QueryTargetPhoto().OnlyX(ctx).ID)
+	require.False(t, c2.QueryTargetPost().ExistX(ctx))
+	require.Equal(t, tk.ID, client.Comment.QueryTargetTicket(c3).OnlyX(ctx).ID)
+	require.Equal(t, []string{"ent"}, client.Comment.Query().
+		Where(comment.Text("c1")).
+		QueryTargetPost().
+		Select(post.FieldTitle).
+		StringsX(ctx))
+	require.False(t, c4.QueryTargetPost().ExistX(ctx))
+
+	// Predicates.
+	require.Equal(t, c1.ID, client.Comment.Query().Where(comment.HasTargetPost()).OnlyIDX(ctx))
+	require.Equal(t, c2.ID, client.Comment.Query().Where(comment.HasTargetPhotoWith(photo.URL("ent.png"))).OnlyIDX(ctx))
+	require.False(t, client.Comment.Query().Where(comment.HasTargetPhotoWith(photo.URL("ent.jpg"))).ExistX(ctx))
+	require.Equal(t, 3, client.Comment.Query().
+		Where(comment.Or(comment.HasTargetPost(), comment.HasTargetPhoto(), comment.HasTargetTicket())).
+		CountX(ctx))
+
+	// Eager loading.
+	comments := client.Comment.Query().
+		WithTargetPost().
+		WithTargetPhoto().
+		WithTargetTicket().
+		Order(ent.Asc(comment.FieldText)).
+		AllX(ctx)
+	require.Len(t, comments, 4)
+	require.Equal(t, p.ID, comments[0].Edges.TargetPost.ID)
+	require.Nil(t, comments[0].Edges.TargetPhoto)
+	require.Nil(t, comments[1].Edges.TargetPost)
+	require.Equal(t, ph.ID, comments[1].Edges.TargetPhoto.ID)
+	require.Equal(t, tk.ID, comments[2].Edges.TargetTicket.ID)
+	for i, want := range []any{p, ph, tk} {
+		v, err := comments[i].Edges.TargetOrErr()
+		require.NoError(t, err)
+		require.IsType(t, want, v)
+	}
+	_, err := comments[3].Edges.TargetOrErr()
+	require.True(t, ent.IsNotFound(err))
+	_, err = client.Comment.GetX(ctx, c1.ID).Edges.TargetOrErr()
+	require.True(t, ent.IsNotLoaded(err))
+
+	// EntQL filters.
+	q := client.Comment.Query()
+	q.Filter().WhereHasTargetTicket()
+	require.Equal(t, c3.ID, q.OnlyIDX(ctx))
+	q = client.Comment.Query()
+	q.Filter().Where(entql.HasEdgeWith(comment.EdgeTargetPost, entql.FieldEQ(post.FieldTitle, "ent")))
+	require.Equal(t, c1.ID, q.OnlyIDX(ctx))
+
+	// Updates.
+	c4 = c4.Update().SetTargetPhoto(ph).SaveX(ctx)
+	require.Equal(t, comment.TargetTypePhoto, *c4.TargetType)
+	require.Equal(t, 2, client.Comment.Query().Where(comment.HasTargetPhoto()).CountX(ctx))
+	client.Comment.Update().Where(comment.HasTargetPhoto()).SetTargetPost(p).ExecX(ctx)
+	require.Equal(t, 3, client.Comment.Query().Where(comment.HasTargetPost()).CountX(ctx))
+	c1 = c1.Update().ClearTarget().SaveX(ctx)
+	require.Nil(t, c1.TargetType)
+	require.Nil(t, c1.TargetID)
+	require.False(t, c1.QueryTargetPost().ExistX(ctx))
+}
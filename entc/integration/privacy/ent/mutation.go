@@ -12,13 +12,13 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/privacy/ent/predicate"
 	"github.com/jogly/ent/entc/integration/privacy/ent/task"
 	"github.com/jogly/ent/entc/integration/privacy/ent/team"
 	"github.com/jogly/ent/entc/integration/privacy/ent/user"
-	"github.com/google/uuid"
 )
 
 const (
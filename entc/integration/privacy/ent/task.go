@@ -10,10 +10,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/entc/integration/privacy/ent/task"
 	"github.com/jogly/ent/entc/integration/privacy/ent/user"
-	"github.com/google/uuid"
 )
 
 // Task is the model entity for the Task schema.
@@ -11,12 +11,12 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/privacy/ent/task"
 	"github.com/jogly/ent/entc/integration/privacy/ent/team"
 	"github.com/jogly/ent/entc/integration/privacy/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // TaskCreate is the builder for creating a Task entity.
@@ -475,8 +475,8 @@ func (tq *TaskQuery) loadTeams(ctx context.Context, query *TeamQuery, nodes []*T
 			init(node)
 		}
 	}
+	joinT := sql.Table(task.TeamsTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(task.TeamsTable)
 		s.Join(joinT).On(s.C(team.FieldID), joinT.C(task.TeamsPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(task.TeamsPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -488,7 +488,10 @@ func (tq *TaskQuery) loadTeams(ctx context.Context, query *TeamQuery, nodes []*T
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(task.TeamsPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
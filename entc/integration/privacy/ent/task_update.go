@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/entc/integration/privacy/ent/predicate"
@@ -18,7 +19,6 @@ import (
 	"github.com/jogly/ent/entc/integration/privacy/ent/team"
 	"github.com/jogly/ent/entc/integration/privacy/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // TaskUpdate is the builder for updating Task entities.
@@ -468,8 +468,8 @@ func (tq *TeamQuery) loadTasks(ctx context.Context, query *TaskQuery, nodes []*T
 			init(node)
 		}
 	}
+	joinT := sql.Table(team.TasksTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(team.TasksTable)
 		s.Join(joinT).On(s.C(task.FieldID), joinT.C(team.TasksPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(team.TasksPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -481,7 +481,10 @@ func (tq *TeamQuery) loadTasks(ctx context.Context, query *TaskQuery, nodes []*T
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(team.TasksPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -529,8 +532,8 @@ func (tq *TeamQuery) loadUsers(ctx context.Context, query *UserQuery, nodes []*T
 			init(node)
 		}
 	}
+	joinT := sql.Table(team.UsersTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(team.UsersTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(team.UsersPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(team.UsersPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -542,7 +545,10 @@ func (tq *TeamQuery) loadUsers(ctx context.Context, query *UserQuery, nodes []*T
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(team.UsersPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
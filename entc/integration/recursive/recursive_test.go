@@ -0,0 +1,89 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package recursive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/recursive/ent"
+	"github.com/jogly/ent/entc/integration/recursive/ent/enttest"
+	"github.com/jogly/ent/entc/integration/recursive/ent/node"
+	"github.com/jogly/ent/entc/integration/recursive/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecursive(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	// root
+	// ├── a
+	// │   ├── a1
+	// │   │   └── a11
+	// │   └── a2
+	// └── b
+	//     └── b1
+	root := client.Node.Create().SetName("root").SaveX(ctx)
+	a := client.Node.Create().SetName("a").SetParent(root).SaveX(ctx)
+	b := client.Node.Create().SetName("b").SetParent(root).SaveX(ctx)
+	a1 := client.Node.Create().SetName("a1").SetParent(a).SaveX(ctx)
+	client.Node.Create().SetName("a2").SetParent(a).ExecX(ctx)
+	a11 := client.Node.Create().SetName("a11").SetParent(a1).SaveX(ctx)
+	client.Node.Create().SetName("b1").SetParent(b).ExecX(ctx)
+
+	names := func(q *ent.NodeQuery) []string {
+		return q.Select(node.FieldName).StringsX(ctx)
+	}
+	require.Equal(t, []string{"a", "a1", "a11", "a2", "b", "b1"}, names(root.QueryDescendants().Order(ent.Asc(node.FieldName))))
+	require.Equal(t, []string{"a1", "a11", "a2"}, names(client.Node.QueryDescendants(a).Order(ent.Asc(node.FieldName))))
+	require.Empty(t, names(a11.QueryDescendants()))
+	require.Equal(t, []string{"a", "b", "a1", "a2", "b1"}, names(root.QueryChildrenRecursive(2).Order(node.OrderByDepth(), ent.Asc(node.FieldName))))
+	require.Equal(t, []string{"a11", "a1", "a2", "b1"}, names(root.QueryChildrenRecursive(3).Where(node.NameNEQ("a"), node.NameNEQ("b")).Order(node.OrderByDepthDesc(), ent.Asc(node.FieldName))))
+	require.Equal(t, []string{"a", "b"}, names(root.QueryChildrenRecursive(1).Order(ent.Asc(node.FieldName))))
+
+	// Ancestors.
+	require.Equal(t, []string{"a1", "a", "root"}, names(a11.QueryAncestors().Order(node.OrderByDepth())))
+	require.Equal(t, []string{"a1"}, names(a11.QueryParentRecursive(1)))
+	require.Empty(t, names(root.QueryAncestors()))
+	require.Equal(t, []string{"a", "b", "root"}, names(client.Node.Query().
+		Where(node.NameIn("a11", "b1")).
+		QueryAncestors().
+		Where(node.NameNEQ("a1")).
+		Order(ent.Asc(node.FieldName))),
+		"nodes that are reachable through multiple paths are returned once",
+	)
+
+	// Chain a traversal on the results of the recursive query.
+	require.Equal(t, 3, root.QueryDescendants().Where(node.HasParentWith(node.NameIn("a", "a1"))).CountX(ctx))
+	require.Equal(t, []string{"a1", "a2"}, names(root.QueryChildrenRecursive(1).Where(node.Name("a")).QueryChildren().Order(ent.Asc(node.FieldName))))
+
+	// Cycles in M2M edges.
+	//
+	// a8m -> nati -> ariel -> a8m
+	//                ariel -> neta
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").AddFollowers(a8m).SaveX(ctx)
+	ariel := client.User.Create().SetName("ariel").AddFollowers(nati).AddFollowing(a8m).SaveX(ctx)
+	client.User.Create().SetName("neta").AddFollowers(ariel).ExecX(ctx)
+
+	unames := func(q *ent.UserQuery) []string {
+		return q.Select(user.FieldName).StringsX(ctx)
+	}
+	require.Equal(t, []string{"ariel", "nati", "neta"}, unames(a8m.QueryFollowingRecursive(0).Order(ent.Asc(user.FieldName))),
+		"the traversal stops when the cycle reaches a8m again")
+	require.Equal(t, []string{"nati", "ariel"}, unames(a8m.QueryFollowingRecursive(2).Order(user.OrderByDepth())))
+	require.Equal(t, []string{"ariel", "nati"}, unames(client.User.QueryFollowersRecursive(a8m, 0).Order(ent.Asc(user.FieldName))))
+	require.Equal(t, 3, client.User.Query().Where(user.Name("neta")).QueryFollowersRecursive(0).CountX(ctx))
+	require.Equal(t, []string{"a8m", "ariel", "nati", "neta"}, unames(client.User.Query().
+		Where(user.NameIn("a8m", "nati")).
+		QueryFollowingRecursive(0).
+		Order(ent.Asc(user.FieldName))),
+		"source nodes are returned if they are reachable from other source nodes",
+	)
+}
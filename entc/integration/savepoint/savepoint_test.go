@@ -0,0 +1,119 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package savepoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/savepoint/ent"
+	"github.com/jogly/ent/entc/integration/savepoint/ent/enttest"
+	"github.com/jogly/ent/entc/integration/savepoint/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavepoint(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	// calls records the execution of the completion hooks.
+	var calls []string
+	onCommit := func(name string) ent.CommitHook {
+		return func(next ent.Committer) ent.Committer {
+			return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+				calls = append(calls, "commit "+name)
+				return next.Commit(ctx, tx)
+			})
+		}
+	}
+	onRollback := func(name string) ent.RollbackHook {
+		return func(next ent.Rollbacker) ent.Rollbacker {
+			return ent.RollbackFunc(func(ctx context.Context, tx *ent.Tx) error {
+				calls = append(calls, "rollback "+name)
+				return next.Rollback(ctx, tx)
+			})
+		}
+	}
+	names := func(t *testing.T, c *ent.Client) []string {
+		t.Helper()
+		return c.User.Query().Order(ent.Asc(user.FieldName)).Select(user.FieldName).StringsX(ctx)
+	}
+
+	t.Run("Commit", func(t *testing.T) {
+		calls = nil
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		tx.OnCommit(onCommit("outer"))
+		tx.User.Create().SetName("a8m").ExecX(ctx)
+
+		// Rolling back a nested transaction undoes only its changes.
+		n1, err := tx.Client().Tx(ctx)
+		require.NoError(t, err)
+		n1.OnCommit(onCommit("n1"))
+		n1.OnRollback(onRollback("n1"))
+		n1.User.Create().SetName("nati").ExecX(ctx)
+		require.Equal(t, []string{"a8m", "nati"}, names(t, n1.Client()))
+		require.NoError(t, n1.Rollback())
+		require.Equal(t, []string{"rollback n1"}, calls)
+		require.Equal(t, []string{"a8m"}, names(t, tx.Client()))
+
+		// Committing a nested transaction defers its commit hooks to the outermost commit.
+		n2, err := tx.Client().BeginTx(ctx, nil)
+		require.NoError(t, err)
+		n2.OnCommit(onCommit("n2"))
+		n2.User.Create().SetName("neta").ExecX(ctx)
+		n3, err := n2.Client().Tx(ctx)
+		require.NoError(t, err)
+		n3.OnCommit(onCommit("n3"))
+		n3.User.Create().SetName("ariel").ExecX(ctx)
+		require.NoError(t, n3.Commit())
+		require.NoError(t, n2.Commit())
+		require.Equal(t, []string{"rollback n1"}, calls, "commit hooks of nested transactions are deferred")
+		require.Error(t, n2.Commit(), "should return an error on the second call")
+		require.Equal(t, []string{"a8m", "ariel", "neta"}, names(t, tx.Client()))
+
+		require.NoError(t, tx.Commit())
+		require.Equal(t, []string{"rollback n1", "commit outer", "commit n2", "commit n3"}, calls)
+		require.Equal(t, []string{"a8m", "ariel", "neta"}, names(t, client))
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		calls = nil
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		tx.OnRollback(onRollback("outer"))
+		tx.User.Create().SetName("pedro").ExecX(ctx)
+
+		// Rolling back a transaction undoes the changes of the committed transactions that are nested in it.
+		n1, err := tx.Client().Tx(ctx)
+		require.NoError(t, err)
+		n1.OnRollback(onRollback("n1"))
+		n2, err := n1.Client().Tx(ctx)
+		require.NoError(t, err)
+		n2.OnCommit(onCommit("n2"))
+		n2.OnRollback(onRollback("n2"))
+		n2.User.Create().SetName("xabi").ExecX(ctx)
+		require.NoError(t, n2.Commit())
+		require.NoError(t, n1.Rollback())
+		require.Equal(t, []string{"rollback n1", "rollback n2"}, calls)
+		require.Equal(t, []string{"a8m", "ariel", "neta", "pedro"}, names(t, tx.Client()))
+
+		n3, err := tx.Client().Tx(ctx)
+		require.NoError(t, err)
+		n3.OnCommit(onCommit("n3"))
+		n3.OnRollback(onRollback("n3"))
+		n3.User.Create().SetName("xabi").ExecX(ctx)
+		require.NoError(t, n3.Commit())
+
+		// Commit hooks of committed nested transactions are dropped on rollback.
+		calls = nil
+		require.NoError(t, tx.Rollback())
+		require.Equal(t, []string{"rollback outer", "rollback n3"}, calls)
+		require.Equal(t, []string{"a8m", "ariel", "neta"}, names(t, client))
+	})
+}
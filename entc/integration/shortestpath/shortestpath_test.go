@@ -0,0 +1,87 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package shortestpath
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/shortestpath/ent/enttest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortestPath(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+	ctx := context.Background()
+
+	// a8m -> nati -> ariel -> alex, a8m -> pedro -> alex, and alex -> a8m.
+	a8m := client.User.Create().SetName("a8m").SaveX(ctx)
+	nati := client.User.Create().SetName("nati").AddFollowers(a8m).SaveX(ctx)
+	ariel := client.User.Create().SetName("ariel").AddFollowers(nati).SaveX(ctx)
+	pedro := client.User.Create().SetName("pedro").AddFollowers(a8m).SaveX(ctx)
+	alex := client.User.Create().SetName("alex").AddFollowers(ariel, pedro).AddFollowing(a8m).SaveX(ctx)
+	stray := client.User.Create().SetName("stray").SaveX(ctx)
+
+	t.Run("Path", func(t *testing.T) {
+		path, err := client.User.ShortestPathViaFollowing(ctx, a8m.ID, alex.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{a8m.ID, pedro.ID, alex.ID}, path)
+		path, err = client.User.ShortestPathViaFollowing(ctx, nati.ID, pedro.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{nati.ID, ariel.ID, alex.ID, a8m.ID, pedro.ID}, path)
+		path, err = client.User.ShortestPathViaFollowers(ctx, alex.ID, nati.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{alex.ID, ariel.ID, nati.ID}, path)
+		path, err = client.User.ShortestPathViaFollowing(ctx, a8m.ID, stray.ID, 0)
+		require.NoError(t, err)
+		require.Nil(t, path)
+	})
+
+	t.Run("Depth", func(t *testing.T) {
+		path, err := client.User.ShortestPathViaFollowing(ctx, nati.ID, pedro.ID, 3)
+		require.NoError(t, err)
+		require.Nil(t, path)
+		path, err = client.User.ShortestPathViaFollowing(ctx, nati.ID, pedro.ID, 4)
+		require.NoError(t, err)
+		require.Len(t, path, 5)
+		ok, err := client.User.ReachableViaFollowing(ctx, a8m.ID, alex.ID, 1)
+		require.NoError(t, err)
+		require.False(t, ok)
+		ok, err = client.User.ReachableViaFollowing(ctx, a8m.ID, alex.ID, 2)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		path, err := client.User.ShortestPathViaFollowing(ctx, stray.ID, stray.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{stray.ID}, path)
+		ok, err := client.User.ReachableViaFollowing(ctx, stray.ID, stray.ID, 0)
+		require.NoError(t, err)
+		require.True(t, ok)
+		// Nodes on cycles are reachable from themselves in the same way.
+		path, err = client.User.ShortestPathViaFollowing(ctx, a8m.ID, a8m.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{a8m.ID}, path)
+	})
+
+	t.Run("Tree", func(t *testing.T) {
+		root := client.User.Create().SetName("root").SaveX(ctx)
+		child := client.User.Create().SetName("child").SetParent(root).SaveX(ctx)
+		leaf := client.User.Create().SetName("leaf").SetParent(child).SaveX(ctx)
+		path, err := client.User.ShortestPathViaChildren(ctx, root.ID, leaf.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{root.ID, child.ID, leaf.ID}, path)
+		path, err = client.User.ShortestPathViaParent(ctx, leaf.ID, root.ID, 0)
+		require.NoError(t, err)
+		require.Equal(t, []int{leaf.ID, child.ID, root.ID}, path)
+		ok, err := client.User.ReachableViaChildren(ctx, leaf.ID, root.ID, 0)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
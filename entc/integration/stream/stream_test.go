@@ -0,0 +1,137 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jogly/ent/entc/integration/stream/ent"
+	"github.com/jogly/ent/entc/integration/stream/ent/enttest"
+	"github.com/jogly/ent/entc/integration/stream/ent/hook"
+	"github.com/jogly/ent/entc/integration/stream/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+// users iterates over n user builders that are created by the
+// given function, and then fails with err, if it is not nil.
+type users struct {
+	n, i  int
+	err   error
+	build func(int) *ent.UserCreate
+	cur   *ent.UserCreate
+}
+
+func (u *users) Next() bool {
+	if u.i == u.n {
+		return false
+	}
+	u.cur = u.build(u.i)
+	u.i++
+	return true
+}
+
+func (u *users) Value() *ent.UserCreate { return u.cur }
+func (u *users) Err() error             { return u.err }
+
+func TestStream(t *testing.T) {
+	var inserts int
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.Debug(), ent.Log(func(v ...any) {
+			if strings.Contains(fmt.Sprint(v...), "INSERT INTO") {
+				inserts++
+			}
+		})),
+	)
+	defer client.Close()
+	ctx := context.Background()
+
+	var mutations int
+	client.User.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			mutations++
+			return next.Mutate(ctx, m)
+		})
+	})
+
+	t.Run("Users", func(t *testing.T) {
+		inserts = 0
+		it := &users{n: 5000, build: func(i int) *ent.UserCreate {
+			u := client.User.Create().SetName(fmt.Sprintf("user%d", i)).SetAge(i % 100)
+			// Builders that set different columns start a new statement.
+			if i%1000 == 0 {
+				u.SetNickname(fmt.Sprintf("nick%d", i))
+			}
+			return u
+		}}
+		n, err := client.User.CreateBulk(
+			client.User.Create().SetName("a8m").SetAge(30),
+			client.User.Create().SetName("nati"),
+		).Stream(ctx, it)
+		require.NoError(t, err)
+		require.Equal(t, 5002, n)
+		require.Equal(t, 5002, client.User.Query().CountX(ctx))
+		require.Zero(t, mutations, "hooks are not executed")
+		require.Greater(t, inserts, 5, "entities are inserted in batches")
+		require.Less(t, inserts, 100)
+
+		nati := client.User.Query().Where(user.Name("nati")).OnlyX(ctx)
+		require.Equal(t, 1, nati.Age, "defaults are applied")
+		require.False(t, nati.CreatedAt.IsZero())
+		require.Equal(t, 5, client.User.Query().Where(user.NicknameNotNil()).CountX(ctx))
+		require.Equal(t, 30, client.User.Query().Where(user.Name("user1030")).OnlyX(ctx).Age)
+
+		n, err = client.User.CreateBulk().Stream(ctx, nil)
+		require.NoError(t, err)
+		require.Zero(t, n)
+		n, err = client.User.CreateBulk(client.User.Create().SetName("neta")).CopyFrom().Stream(ctx, nil)
+		require.NoError(t, err, "CopyFrom is ignored by SQLite")
+		require.Equal(t, 1, n)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		count := client.User.Query().CountX(ctx)
+		// Failures roll back the entities that were already inserted.
+		errIter := errors.New("iterator failure")
+		_, err := client.User.CreateBulk().Stream(ctx, &users{n: 2000, err: errIter, build: func(i int) *ent.UserCreate {
+			return client.User.Create().SetName(fmt.Sprintf("fail%d", i))
+		}})
+		require.ErrorIs(t, err, errIter)
+		_, err = client.User.CreateBulk().Stream(ctx, &users{n: 2000, build: func(i int) *ent.UserCreate {
+			name := fmt.Sprintf("invalid%d", i)
+			if i == 1999 {
+				name = ""
+			}
+			return client.User.Create().SetName(name)
+		}})
+		require.True(t, ent.IsValidationError(err), "validators are applied")
+		_, err = client.User.CreateBulk(
+			client.User.Create().SetName("dup").SetNickname("nick0"),
+		).Stream(ctx, nil)
+		require.True(t, ent.IsConstraintError(err))
+		require.Equal(t, count, client.User.Query().CountX(ctx))
+
+		pet := client.Pet.Create().SetName("pedro").SaveX(ctx)
+		_, err = client.User.CreateBulk(client.User.Create().SetName("owner").AddPets(pet)).Stream(ctx, nil)
+		require.EqualError(t, err, `sqlgraph: stream insert does not support edges that are stored in table "pets"`)
+	})
+
+	t.Run("Pets", func(t *testing.T) {
+		a8m := client.User.Query().Where(user.Name("a8m")).OnlyX(ctx)
+		n, err := client.Pet.CreateBulk(
+			client.Pet.Create().SetName("xabi").SetOwner(a8m),
+			client.Pet.Create().SetName("coco").SetOwnerID(a8m.ID),
+			client.Pet.Create().SetName("stray"),
+		).Stream(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+		require.Equal(t, []string{"coco", "xabi"}, a8m.QueryPets().Order(ent.Asc("name")).Select("name").StringsX(ctx))
+	})
+}
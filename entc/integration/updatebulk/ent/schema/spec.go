@@ -0,0 +1,43 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package schema
+
+import (
+	"github.com/jogly/ent"
+	"github.com/jogly/ent/schema"
+	"github.com/jogly/ent/schema/edge"
+	"github.com/jogly/ent/schema/field"
+)
+
+// Spec holds the schema definition for the Spec entity. Its package
+// name conflicts with local names of the UpdateBulk builders.
+type Spec struct {
+	ent.Schema
+}
+
+// Fields of the Spec.
+func (Spec) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name"),
+		field.Int("version").
+			Default(0),
+	}
+}
+
+// Edges of the Spec.
+func (Spec) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("owner", User.Type).
+			Ref("specs").
+			Unique(),
+	}
+}
+
+// Annotations of the Spec.
+func (Spec) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		field.Version("version"),
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package updatebulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jogly/ent/entc/integration/updatebulk/ent"
+	"github.com/jogly/ent/entc/integration/updatebulk/ent/enttest"
+	"github.com/jogly/ent/entc/integration/updatebulk/ent/hook"
+	"github.com/jogly/ent/entc/integration/updatebulk/ent/user"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateBulk(t *testing.T) {
+	var updates int
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1",
+		enttest.WithOptions(ent.Debug(), ent.Log(func(v ...any) {
+			if strings.Contains(fmt.Sprint(v...), "UPDATE") {
+				updates++
+			}
+		})),
+	)
+	defer client.Close()
+	ctx := context.Background()
+
+	var mutations int
+	client.User.Use(func(next ent.Mutator) ent.Mutator {
+		return hook.UserFunc(func(ctx context.Context, m *ent.UserMutation) (ent.Value, error) {
+			mutations++
+			return next.Mutate(ctx, m)
+		})
+	})
+	a8m := client.User.Create().SetName("a8m").SetAge(30).SaveX(ctx)
+	nati := client.User.Create().SetName("nati").SetAge(28).SaveX(ctx)
+	neta := client.User.Create().SetName("neta").SetAge(2).SaveX(ctx)
+
+	t.Run("Users", func(t *testing.T) {
+		updates, mutations = 0, 0
+		users := client.User.UpdateBulk(
+			client.User.UpdateOne(a8m).SetAge(31),
+			client.User.UpdateOne(nati).SetAge(29),
+			client.User.UpdateOne(neta).SetNickname("nt"),
+		).SaveX(ctx)
+		require.Len(t, users, 3)
+		require.Equal(t, 31, users[0].Age)
+		require.Equal(t, 29, users[1].Age)
+		require.Equal(t, "nt", users[2].Nickname)
+		require.True(t, users[0].UpdatedAt.After(a8m.UpdatedAt), "update defaults are applied")
+		require.Equal(t, 3, mutations, "hooks are executed for each mutation")
+		require.Equal(t, 2, updates, "one statement for the entities that change the same fields")
+		require.Equal(t, []int{2, 29, 31}, client.User.Query().Order(ent.Asc(user.FieldAge)).Select(user.FieldAge).IntsX(ctx))
+		require.Equal(t, "nt", client.User.GetX(ctx, neta.ID).Nickname)
+
+		updates = 0
+		client.User.UpdateBulk(
+			client.User.UpdateOne(a8m).SetAge(32),
+			client.User.UpdateOne(nati).SetAge(30),
+		).BatchSize(1).ExecX(ctx)
+		require.Equal(t, 2, updates)
+		require.Equal(t, 32, client.User.GetX(ctx, a8m.ID).Age)
+
+		err := client.User.UpdateBulk(client.User.UpdateOneID(neta.ID + 100).SetAge(1)).Exec(ctx)
+		require.True(t, ent.IsNotFound(err))
+		require.Empty(t, client.User.UpdateBulk().SaveX(ctx))
+	})
+
+	t.Run("Specs", func(t *testing.T) {
+		s1 := client.Spec.Create().SetName("s1").SetOwner(a8m).SaveX(ctx)
+		s2 := client.Spec.Create().SetName("s2").SaveX(ctx)
+		specs := client.Spec.UpdateBulk(
+			client.Spec.UpdateOne(s1).SetName("s1.1"),
+			client.Spec.UpdateOne(s2).SetName("s2.1").SetOwner(nati),
+		).SaveX(ctx)
+		require.Equal(t, "s1.1", specs[0].Name)
+		require.Equal(t, 1, specs[0].Version, "versions are bumped")
+		require.Equal(t, "s2.1", specs[1].Name)
+		require.Equal(t, 1, specs[1].Version)
+		require.Equal(t, nati.ID, client.Spec.GetX(ctx, s2.ID).QueryOwner().OnlyIDX(ctx))
+		require.Equal(t, a8m.ID, client.Spec.GetX(ctx, s1.ID).QueryOwner().OnlyIDX(ctx))
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		stale := client.Spec.Create().SetName("s3").SaveX(ctx)
+		s3 := client.Spec.UpdateOne(stale).SetName("s3.1").SaveX(ctx)
+		require.Equal(t, 1, s3.Version)
+		err := client.Spec.UpdateOne(stale).SetName("s3.2").Exec(ctx)
+		require.True(t, ent.IsConflictError(err))
+		var cerr *ent.ConflictError
+		require.True(t, errors.As(err, &cerr))
+		require.Equal(t, stale.ID, cerr.ID)
+		require.Equal(t, 0, cerr.Version)
+		err = client.Spec.UpdateOneID(s3.ID).ExpectVersion(5).SetName("s3.2").Exec(ctx)
+		require.True(t, errors.As(err, &cerr))
+		require.Equal(t, 5, cerr.Version)
+		require.Equal(t, "s3.1", client.Spec.GetX(ctx, s3.ID).Name)
+
+		// Bulks fail if one of their entities is stale, without updating the others.
+		s4 := client.Spec.Create().SetName("s4").SaveX(ctx)
+		err = client.Spec.UpdateBulk(
+			client.Spec.UpdateOne(s4).SetName("s4.1"),
+			client.Spec.UpdateOne(stale).SetName("s3.2"),
+		).Exec(ctx)
+		require.True(t, ent.IsConflictError(err))
+		var errs ent.ConflictErrors
+		require.True(t, errors.As(err, &errs))
+		require.Len(t, errs, 1)
+		require.Equal(t, stale.ID, errs[0].ID)
+		require.Equal(t, 0, errs[0].Version)
+		require.Equal(t, "s4", client.Spec.GetX(ctx, s4.ID).Name)
+		require.Equal(t, "s3.1", client.Spec.GetX(ctx, s3.ID).Name)
+	})
+
+	t.Run("TimeVersions", func(t *testing.T) {
+		stale := client.Document.Create().SetTitle("d1").SaveX(ctx)
+		require.Zero(t, stale.UpdatedAt.Nanosecond()%int(time.Microsecond), "versions are truncated to microseconds")
+		d1 := client.Document.UpdateOne(stale).SetTitle("d1.1").SaveX(ctx)
+		require.True(t, d1.UpdatedAt.After(stale.UpdatedAt))
+		require.True(t, d1.UpdatedAt.Equal(client.Document.GetX(ctx, d1.ID).UpdatedAt), "returned versions match the stored ones")
+		d1 = client.Document.UpdateOne(d1).SetTitle("d1.2").SaveX(ctx)
+
+		err := client.Document.UpdateOne(stale).SetTitle("d1.3").Exec(ctx)
+		var cerr *ent.ConflictError
+		require.True(t, errors.As(err, &cerr))
+		require.Equal(t, stale.ID, cerr.ID)
+		require.True(t, stale.UpdatedAt.Equal(cerr.Version.(time.Time)))
+
+		d2 := client.Document.Create().SetTitle("d2").SaveX(ctx)
+		docs := client.Document.UpdateBulk(
+			client.Document.UpdateOne(d1).SetTitle("d1.3"),
+			client.Document.UpdateOne(d2).SetTitle("d2.1"),
+		).SaveX(ctx)
+		require.Equal(t, "d1.3", docs[0].Title)
+		require.Equal(t, "d2.1", docs[1].Title)
+		err = client.Document.UpdateBulk(client.Document.UpdateOne(d2).SetTitle("d2.2")).Exec(ctx)
+		require.True(t, ent.IsConflictError(err))
+		client.Document.UpdateBulk(client.Document.UpdateOne(docs[1]).SetTitle("d2.2")).ExecX(ctx)
+		require.Equal(t, "d2.2", client.Document.GetX(ctx, d2.ID).Title)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package withtx
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jogly/ent/dialect/sql/sqlgraph"
+	"github.com/jogly/ent/entc/integration/withtx/ent"
+	"github.com/jogly/ent/entc/integration/withtx/ent/enttest"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTx(t *testing.T) {
+	// A file database without a busy timeout, in order to fail
+	// writes that conflict with other transactions immediately.
+	dsn := "file:" + filepath.Join(t.TempDir(), "ent.db") + "?_fk=1&_busy_timeout=0"
+	client := enttest.Open(t, "sqlite3", dsn)
+	defer client.Close()
+	ctx := context.Background()
+
+	var commits int
+	onCommit := ent.WithTxOnCommit(func(next ent.Committer) ent.Committer {
+		return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+			commits++
+			return next.Commit(ctx, tx)
+		})
+	})
+	backoff := ent.WithTxBackoff(time.Millisecond, 10*time.Millisecond)
+
+	// conflict fails the write of tx, as another transaction holds the write lock of the database.
+	conflict := func(t *testing.T, ctx context.Context, tx *ent.Tx) error {
+		t.Helper()
+		other, err := client.Tx(ctx)
+		require.NoError(t, err)
+		other.User.Create().SetName("other").ExecX(ctx)
+		err = tx.User.Create().SetName("conflict").Exec(ctx)
+		require.Error(t, err)
+		require.True(t, sqlgraph.IsRetryableError(err))
+		require.NoError(t, other.Rollback())
+		return err
+	}
+
+	t.Run("Retry", func(t *testing.T) {
+		var attempts []int
+		err := client.WithTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			attempts = append(attempts, ent.TxAttempt(ctx))
+			if ent.TxAttempt(ctx) == 1 {
+				return conflict(t, ctx, tx)
+			}
+			return tx.User.Create().SetName("a8m").Exec(ctx)
+		}, onCommit, backoff)
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 2}, attempts)
+		require.Equal(t, 1, commits)
+		require.Equal(t, []string{"a8m"}, client.User.Query().Select("name").StringsX(ctx))
+		require.Zero(t, ent.TxAttempt(ctx))
+	})
+
+	t.Run("Attempts", func(t *testing.T) {
+		var attempts int
+		err := client.WithTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			attempts++
+			return conflict(t, ctx, tx)
+		}, ent.WithTxAttempts(2), backoff)
+		require.True(t, sqlgraph.IsRetryableError(err))
+		require.Equal(t, 2, attempts)
+
+		// Other errors are not retried.
+		attempts = 0
+		errFail := errors.New("fail")
+		err = client.WithTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			attempts++
+			tx.User.Create().SetName("nati").ExecX(ctx)
+			return errFail
+		}, backoff)
+		require.ErrorIs(t, err, errFail)
+		require.Equal(t, 1, attempts)
+		err = client.WithTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			attempts++
+			return conflict(t, ctx, tx)
+		}, ent.WithTxRetryIf(func(error) bool { return false }), backoff)
+		require.Error(t, err)
+		require.Equal(t, 2, attempts)
+		require.Equal(t, 1, client.User.Query().CountX(ctx), "failed attempts are rolled back")
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		err := client.WithTx(ctx, func(ctx context.Context, tx *ent.Tx) error {
+			tx.User.Create().SetName("nati").ExecX(ctx)
+			panic("boom")
+		})
+		require.EqualError(t, err, "ent: panic in transaction: boom")
+		require.Equal(t, 1, client.User.Query().CountX(ctx))
+	})
+
+	t.Run("Transactional", func(t *testing.T) {
+		tx, err := client.Tx(ctx)
+		require.NoError(t, err)
+		var attempts int
+		err = tx.Client().WithTx(ctx, func(context.Context, *ent.Tx) error {
+			attempts++
+			return errors.New("database is locked")
+		})
+		require.EqualError(t, err, "ent: cannot start a transaction within a transaction")
+		require.Zero(t, attempts)
+		require.NoError(t, tx.Rollback())
+	})
+}
@@ -55,6 +55,7 @@ type Field struct {
 	StorageKey    string                  `json:"storage_key,omitempty"`
 	Position      *Position               `json:"position,omitempty"`
 	Sensitive     bool                    `json:"sensitive,omitempty"`
+	Encryption    *field.Encryption       `json:"encryption,omitempty"`
 	SchemaType    map[string]string       `json:"schema_type,omitempty"`
 	Annotations   map[string]any          `json:"annotations,omitempty"`
 	Comment       string                  `json:"comment,omitempty"`
@@ -64,6 +65,7 @@ type Field struct {
 type Edge struct {
 	Name        string                 `json:"name,omitempty"`
 	Type        string                 `json:"type,omitempty"`
+	Types       []string               `json:"types,omitempty"`
 	Tag         string                 `json:"tag,omitempty"`
 	Field       string                 `json:"field,omitempty"`
 	RefName     string                 `json:"ref_name,omitempty"`
@@ -92,6 +94,7 @@ func NewEdge(ed *edge.Descriptor) *Edge {
 	ne := &Edge{
 		Tag:         ed.Tag,
 		Type:        ed.Type,
+		Types:       ed.Types,
 		Name:        ed.Name,
 		Field:       ed.Field,
 		Unique:      ed.Unique,
@@ -133,6 +136,7 @@ func NewField(fd *field.Descriptor) (*Field, error) {
 		StorageKey:    fd.StorageKey,
 		Validators:    len(fd.Validators),
 		Sensitive:     fd.Sensitive,
+		Encryption:    fd.Encryption,
 		SchemaType:    fd.SchemaType,
 		Annotations:   make(map[string]any),
 		Comment:       fd.Comment,
@@ -398,7 +402,7 @@ func addAnnotation(annotations map[string]any, an schema.Annotation) {
 }
 
 func (f *Field) defaults() error {
-	if !f.Default || !f.Info.Numeric() || f.DefaultKind == reflect.Func {
+	if !f.Default || !f.Info.Numeric() || f.Info.Type.Decimal() || f.DefaultKind == reflect.Func {
 		return nil
 	}
 	n, ok := f.DefaultValue.(float64)
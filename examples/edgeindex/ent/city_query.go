@@ -420,7 +420,16 @@ func (cq *CityQuery) loadStreets(ctx context.Context, query *StreetQuery, nodes
 	query.Where(predicate.Street(func(s *sql.Selector) {
 		s.Where(sql.InValues(city.StreetsColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(city.StreetsColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Street](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
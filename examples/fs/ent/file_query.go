@@ -489,7 +489,16 @@ func (fq *FileQuery) loadChildren(ctx context.Context, query *FileQuery, nodes [
 	query.Where(predicate.File(func(s *sql.Selector) {
 		s.Where(sql.InValues(file.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(file.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*File](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -459,8 +459,8 @@ func (uq *UserQuery) loadFollowers(ctx context.Context, query *UserQuery, nodes
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FollowersTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FollowersTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FollowersPrimaryKey[0]))
 		s.Where(sql.InValues(joinT.C(user.FollowersPrimaryKey[1]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -472,7 +472,10 @@ func (uq *UserQuery) loadFollowers(ctx context.Context, query *UserQuery, nodes
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FollowersPrimaryKey[1])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
@@ -520,8 +523,8 @@ func (uq *UserQuery) loadFollowing(ctx context.Context, query *UserQuery, nodes
 			init(node)
 		}
 	}
+	joinT := sql.Table(user.FollowingTable)
 	query.Where(func(s *sql.Selector) {
-		joinT := sql.Table(user.FollowingTable)
 		s.Join(joinT).On(s.C(user.FieldID), joinT.C(user.FollowingPrimaryKey[1]))
 		s.Where(sql.InValues(joinT.C(user.FollowingPrimaryKey[0]), edgeIDs...))
 		columns := s.SelectedColumns()
@@ -533,7 +536,10 @@ func (uq *UserQuery) loadFollowing(ctx context.Context, query *UserQuery, nodes
 		return err
 	}
 	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
-		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+		limit := limitNeighbors(func(*sql.Selector) string {
+			return joinT.C(user.FollowingPrimaryKey[0])
+		})
+		return query.sqlAll(ctx, limit, func(_ context.Context, spec *sqlgraph.QuerySpec) {
 			assign := spec.Assign
 			values := spec.ScanValues
 			spec.ScanValues = func(columns []string) ([]any, error) {
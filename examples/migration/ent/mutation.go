@@ -12,13 +12,13 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/examples/migration/ent/card"
 	"github.com/jogly/ent/examples/migration/ent/pet"
 	"github.com/jogly/ent/examples/migration/ent/predicate"
 	"github.com/jogly/ent/examples/migration/ent/user"
-	"github.com/google/uuid"
 )
 
 const (
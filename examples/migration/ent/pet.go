@@ -10,10 +10,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/examples/migration/ent/pet"
 	"github.com/jogly/ent/examples/migration/ent/user"
-	"github.com/google/uuid"
 )
 
 // Pet is the model entity for the Pet schema.
@@ -11,11 +11,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/examples/migration/ent/pet"
 	"github.com/jogly/ent/examples/migration/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // PetCreate is the builder for creating a Pet entity.
@@ -11,13 +11,13 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
 	"github.com/jogly/ent/dialect/sql"
 	"github.com/jogly/ent/dialect/sql/sqlgraph"
 	"github.com/jogly/ent/examples/migration/ent/pet"
 	"github.com/jogly/ent/examples/migration/ent/predicate"
 	"github.com/jogly/ent/examples/migration/ent/user"
 	"github.com/jogly/ent/schema/field"
-	"github.com/google/uuid"
 )
 
 // PetUpdate is the builder for updating Pet entities.
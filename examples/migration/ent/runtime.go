@@ -7,10 +7,10 @@
 package ent
 
 import (
+	"github.com/google/uuid"
 	"github.com/jogly/ent/examples/migration/ent/card"
 	"github.com/jogly/ent/examples/migration/ent/pet"
 	"github.com/jogly/ent/examples/migration/ent/schema"
-	"github.com/google/uuid"
 )
 
 // The init function reads all schema descriptors with runtime code
@@ -489,7 +489,16 @@ func (nq *NodeQuery) loadChildren(ctx context.Context, query *NodeQuery, nodes [
 	query.Where(predicate.Node(func(s *sql.Selector) {
 		s.Where(sql.InValues(node.ChildrenColumn, fks...))
 	}))
-	neighbors, err := query.All(ctx)
+	ctx = setContextOp(ctx, query.ctx, "All")
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, limitNeighbors(func(s *sql.Selector) string {
+			return s.C(node.ChildrenColumn)
+		}))
+	})
+	neighbors, err := withInterceptors[[]*Node](ctx, query, qr, query.inters)
 	if err != nil {
 		return err
 	}
@@ -14,6 +14,7 @@ import (
 type Descriptor struct {
 	Tag         string                 // struct tag.
 	Type        string                 // edge type.
+	Types       []string               // edge types; polymorphic only.
 	Name        string                 // edge name.
 	Field       string                 // edge field name (e.g. foreign-key).
 	RefName     string                 // ref name; inverse only.
@@ -138,6 +139,55 @@ func (b *assocBuilder) Descriptor() *Descriptor {
 	return b.desc
 }
 
+// Polymorphic defines a unique edge that can point to a vertex of one of the
+// given types. The edge is stored in two columns of the vertex table, the type
+// of the neighbor and its identifier.
+//
+//	edge.Polymorphic("target", Post.Type, Photo.Type, Ticket.Type)
+func Polymorphic(name string, t any, ts ...any) *polymorphicBuilder {
+	types := []string{typ(t)}
+	for _, t := range ts {
+		types = append(types, typ(t))
+	}
+	return &polymorphicBuilder{desc: &Descriptor{Name: name, Types: types, Unique: true}}
+}
+
+// polymorphicBuilder is the builder for polymorphic edges.
+type polymorphicBuilder struct {
+	desc *Descriptor
+}
+
+// Required indicates that this edge is a required field on creation.
+// Unlike fields, edges are optional by default.
+func (b *polymorphicBuilder) Required() *polymorphicBuilder {
+	b.desc.Required = true
+	return b
+}
+
+// Immutable indicates that this edge cannot be updated.
+func (b *polymorphicBuilder) Immutable() *polymorphicBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// Comment used to put annotations on the schema.
+func (b *polymorphicBuilder) Comment(c string) *polymorphicBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// Annotations adds a list of annotations to the edge object to be used by
+// codegen extensions.
+func (b *polymorphicBuilder) Annotations(annotations ...schema.Annotation) *polymorphicBuilder {
+	b.desc.Annotations = append(b.desc.Annotations, annotations...)
+	return b
+}
+
+// Descriptor implements the ent.Descriptor interface.
+func (b *polymorphicBuilder) Descriptor() *Descriptor {
+	return b.desc
+}
+
 // inverseBuilder is the builder for inverse edges.
 type inverseBuilder struct {
 	desc *Descriptor
@@ -99,6 +99,23 @@ func TestEdge(t *testing.T) {
 	assert.Equal("followers", from.Tag)
 	assert.Equal("following", from.Ref.Tag)
 	assert.Equal(edge.StorageKey{Table: "user_followers", Symbols: []string{"users_followers"}, Columns: []string{"following_id", "followers_id"}}, *from.Ref.StorageKey)
+
+	t.Log("polymorphic edge")
+	type Post struct{ ent.Schema }
+	type Photo struct{ ent.Schema }
+	e = edge.Polymorphic("target", Post.Type, Photo.Type).
+		Required().
+		Immutable().
+		Comment("comment").
+		Descriptor()
+	assert.Equal("target", e.Name)
+	assert.Empty(e.Type)
+	assert.Equal([]string{"Post", "Photo"}, e.Types)
+	assert.True(e.Unique)
+	assert.True(e.Required)
+	assert.True(e.Immutable)
+	assert.False(e.Inverse)
+	assert.Equal("comment", e.Comment)
 }
 
 type GQL struct {
@@ -20,8 +20,9 @@ type Annotation struct {
 	//
 	StructTag map[string]string
 
-	// ID defines a multi-field schema identifier. Note,
-	// the annotation is valid only for edge schemas.
+	// ID defines a multi-field schema identifier (composite primary key).
+	// It can be used by edge schemas, or by regular schemas whose
+	// edges are stored in their own table.
 	//
 	//	func (TweetLike) Annotations() []schema.Annotation {
 	//		return []schema.Annotation{
@@ -30,20 +31,59 @@ type Annotation struct {
 	//	}
 	//
 	ID []string
+
+	// Version defines the field that is used as the concurrency token of the
+	// schema (optimistic locking). Updates of a single entity are applied only
+	// if the field was not modified since the entity was read, and the field
+	// is bumped automatically on each update.
+	//
+	//	func (Document) Annotations() []schema.Annotation {
+	//		return []schema.Annotation{
+	//			field.Version("version"),
+	//		}
+	//	}
+	//
+	Version string `json:",omitempty"`
 }
 
-// ID defines a multi-field schema identifier. Note, the
-// annotation is valid only for edge schemas.
+// ID defines a multi-field schema identifier (composite primary key).
+// Edge schemas can use it for their two edge fields, and regular schemas
+// for any set of their required fields. Note, other types cannot hold a
+// foreign key to a regular schema with a composite identifier.
 //
 //	func (TweetLike) Annotations() []schema.Annotation {
 //		return []schema.Annotation{
 //			field.ID("user_id", "tweet_id"),
 //		}
 //	}
+//
+//	func (Account) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			field.ID("tenant_id", "external_id"),
+//		}
+//	}
 func ID(first, second string, fields ...string) *Annotation {
 	return &Annotation{ID: append([]string{first, second}, fields...)}
 }
 
+// Version defines the field that is used as the concurrency token of the schema
+// for optimistic locking. The field must be a required integer or time field, and
+// it is bumped automatically by the update builders. That is, integer fields are
+// incremented by 1, and time fields are set to the current time.
+//
+// The UpdateOne builders of the schema update the entity only if the field holds the
+// value that was read (or set using the Expect<Field> method of the builder), and fail
+// with a ConflictError otherwise.
+//
+//	func (Document) Annotations() []schema.Annotation {
+//		return []schema.Annotation{
+//			field.Version("version"),
+//		}
+//	}
+func Version(name string) *Annotation {
+	return &Annotation{Version: name}
+}
+
 // Name describes the annotation name.
 func (Annotation) Name() string {
 	return "Fields"
@@ -71,6 +111,9 @@ func (a Annotation) Merge(other schema.Annotation) schema.Annotation {
 	if len(ant.ID) > 0 {
 		a.ID = ant.ID
 	}
+	if ant.Version != "" {
+		a.Version = ant.Version
+	}
 	return a
 }
 
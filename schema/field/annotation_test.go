@@ -23,4 +23,10 @@ func TestAnnotation_Merge(t *testing.T) {
 	})
 	assert.Equal(t, a.(field.Annotation).StructTag["foo"], "baz")
 	assert.Equal(t, a.(field.Annotation).StructTag["baz"], "qux")
+	a = a.(field.Annotation).Merge(field.Version("version"))
+	assert.Equal(t, "version", a.(field.Annotation).Version)
+	assert.Equal(t, "qux", a.(field.Annotation).StructTag["baz"])
+	a = a.(field.Annotation).Merge(field.ID("a", "b"))
+	assert.Equal(t, "version", a.(field.Annotation).Version)
+	assert.Equal(t, []string{"a", "b"}, a.(field.Annotation).ID)
 }
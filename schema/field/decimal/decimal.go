@@ -0,0 +1,195 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+// Package decimal provides the Go type of fixed-precision decimal fields.
+package decimal
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number held in its textual representation.
+// For example, "12.50" or "-0.003". Unlike float64, values are stored and
+// loaded from the database without any loss of precision, and the scale
+// (number of fractional digits) of the value is preserved. The zero value
+// represents the number 0.
+type Decimal string
+
+// New returns a new Decimal that equals to unscaled * 10^-scale.
+//
+//	decimal.New(1250, 2)	// 12.50
+func New(unscaled int64, scale int) Decimal {
+	return format(big.NewInt(unscaled), scale)
+}
+
+// Parse parses the given string into a Decimal. The accepted format is
+// an optional sign, followed by digits with an optional decimal point.
+func Parse(s string) (Decimal, error) {
+	n, scale, err := parse(s)
+	if err != nil {
+		return "", err
+	}
+	return format(n, scale), nil
+}
+
+// MustParse is like Parse but panics if the string cannot be parsed.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// String implements the fmt.Stringer interface.
+func (d Decimal) String() string {
+	if d == "" {
+		return "0"
+	}
+	return string(d)
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	_, scale := d.unscaled()
+	return scale
+}
+
+// Add returns the sum d+x. The scale of the result is the
+// maximum scale of the two operands.
+func (d Decimal) Add(x Decimal) Decimal {
+	a, b, scale := align(d, x)
+	return format(a.Add(a, b), scale)
+}
+
+// Sub returns the difference d-x. The scale of the result is
+// the maximum scale of the two operands.
+func (d Decimal) Sub(x Decimal) Decimal {
+	a, b, scale := align(d, x)
+	return format(a.Sub(a, b), scale)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	n, scale := d.unscaled()
+	return format(n.Neg(n), scale)
+}
+
+// Cmp compares d and x and returns:
+//
+//	-1 if d <  x
+//	 0 if d == x
+//	+1 if d >  x
+func (d Decimal) Cmp(x Decimal) int {
+	a, b, _ := align(d, x)
+	return a.Cmp(b)
+}
+
+// Sign returns -1, 0 or +1 depending on whether d is negative, zero or positive.
+func (d Decimal) Sign() int {
+	n, _ := d.unscaled()
+	return n.Sign()
+}
+
+// Rat returns the value of d as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	n, scale := d.unscaled()
+	return new(big.Rat).SetFrac(n, pow10(scale))
+}
+
+// Float64 returns the nearest float64 value for d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Value implements the driver.Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	if _, _, err := parse(d.String()); err != nil {
+		return nil, err
+	}
+	return d.String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (d *Decimal) Scan(src any) (err error) {
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case string:
+		*d, err = Parse(v)
+	case []byte:
+		*d, err = Parse(string(v))
+	case int64:
+		*d = Decimal(strconv.FormatInt(v, 10))
+	case float64:
+		*d, err = Parse(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		err = fmt.Errorf("decimal: unexpected type %T for scan", src)
+	}
+	return err
+}
+
+// unscaled returns the unscaled value of d and its scale.
+// Invalid values are treated as zero.
+func (d Decimal) unscaled() (*big.Int, int) {
+	n, scale, err := parse(d.String())
+	if err != nil {
+		return new(big.Int), 0
+	}
+	return n, scale
+}
+
+// align returns the unscaled values of a and b with the same scale.
+func align(a, b Decimal) (*big.Int, *big.Int, int) {
+	x, xs := a.unscaled()
+	y, ys := b.unscaled()
+	switch {
+	case xs < ys:
+		x.Mul(x, pow10(ys-xs))
+		return x, y, ys
+	case xs > ys:
+		y.Mul(y, pow10(xs-ys))
+	}
+	return x, y, xs
+}
+
+func parse(s string) (*big.Int, int, error) {
+	v := s
+	if v != "" && (v[0] == '-' || v[0] == '+') {
+		v = v[1:]
+	}
+	i, f, _ := strings.Cut(v, ".")
+	if i == "" && f == "" || strings.Trim(i+f, "0123456789") != "" {
+		return nil, 0, fmt.Errorf("decimal: invalid value %q", s)
+	}
+	n, ok := new(big.Int).SetString(s[:len(s)-len(v)]+i+f, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("decimal: invalid value %q", s)
+	}
+	return n, len(f), nil
+}
+
+func format(n *big.Int, scale int) Decimal {
+	if scale <= 0 {
+		return Decimal(n.Mul(n, pow10(-scale)).String())
+	}
+	digits := new(big.Int).Abs(n).String()
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	sign := ""
+	if n.Sign() < 0 {
+		sign = "-"
+	}
+	return Decimal(sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:])
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
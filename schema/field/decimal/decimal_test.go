@@ -0,0 +1,79 @@
+// Copyright 2019-present Facebook Inc. All rights reserved.
+// This source code is licensed under the Apache 2.0 license found
+// in the LICENSE file in the root directory of this source tree.
+
+package decimal_test
+
+import (
+	"testing"
+
+	"github.com/jogly/ent/schema/field/decimal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	for s, want := range map[string]decimal.Decimal{
+		"0":                             "0",
+		"12.50":                         "12.50",
+		"+1.5":                          "1.5",
+		"-0.003":                        "-0.003",
+		".5":                            "0.5",
+		"5.":                            "5",
+		"007.10":                        "7.10",
+		"-0.00":                         "0.00",
+		"1234567890123456789012345.678": "1234567890123456789012345.678",
+	} {
+		d, err := decimal.Parse(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, d, s)
+	}
+	for _, s := range []string{"", "-", ".", "1e3", "1.2.3", "a", "1,5"} {
+		_, err := decimal.Parse(s)
+		require.Error(t, err, s)
+	}
+	require.Panics(t, func() { decimal.MustParse("x") })
+}
+
+func TestArithmetic(t *testing.T) {
+	require.Equal(t, decimal.Decimal("12.50"), decimal.New(1250, 2))
+	require.Equal(t, decimal.Decimal("-0.05"), decimal.New(-5, 2))
+	require.Equal(t, decimal.Decimal("100"), decimal.New(1, -2))
+	a, b := decimal.MustParse("10.25"), decimal.MustParse("0.1")
+	require.Equal(t, decimal.Decimal("10.35"), a.Add(b))
+	require.Equal(t, decimal.Decimal("10.15"), a.Sub(b))
+	require.Equal(t, decimal.Decimal("-10.25"), a.Neg())
+	require.Equal(t, decimal.Decimal("0.1"), decimal.Decimal("").Add(b))
+	require.Equal(t, 1, a.Cmp(b))
+	require.Equal(t, -1, b.Cmp(a))
+	require.Equal(t, 0, b.Cmp("0.10"))
+	require.Equal(t, 2, a.Scale())
+	require.Equal(t, -1, a.Neg().Sign())
+	require.Equal(t, 10.25, a.Float64())
+	require.Equal(t, "41/4", a.Rat().String())
+	require.Equal(t, "0", decimal.Decimal("").String())
+}
+
+func TestValueScan(t *testing.T) {
+	v, err := decimal.Decimal("").Value()
+	require.NoError(t, err)
+	require.Equal(t, "0", v)
+	_, err = decimal.Decimal("invalid").Value()
+	require.Error(t, err)
+
+	var d decimal.Decimal
+	for src, want := range map[any]decimal.Decimal{
+		"1.50":        "1.50",
+		int64(-3):     "-3",
+		float64(2.25): "2.25",
+		float64(1e21): "1000000000000000000000",
+	} {
+		require.NoError(t, d.Scan(src))
+		require.Equal(t, want, d)
+	}
+	require.NoError(t, d.Scan([]byte("0.001")))
+	require.Equal(t, decimal.Decimal("0.001"), d)
+	require.NoError(t, d.Scan(nil))
+	require.Equal(t, decimal.Decimal(""), d)
+	require.Error(t, d.Scan(true))
+}
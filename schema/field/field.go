@@ -7,6 +7,8 @@ package field
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -15,7 +17,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/schema"
+	"github.com/jogly/ent/schema/field/decimal"
 )
 
 // String returns a new Field with type string.
@@ -85,6 +89,7 @@ func JSON(name string, typ any) *jsonBuilder {
 	b.desc.Info.Ident = t.String()
 	b.desc.Info.PkgPath = t.PkgPath()
 	b.desc.goType(typ, t)
+	b.desc.Info.RType.Fields = jsonFields(t, make(map[reflect.Type]bool))
 	switch t.Kind() {
 	case reflect.Slice, reflect.Array, reflect.Ptr, reflect.Map:
 		b.desc.Info.Nillable = true
@@ -160,6 +165,21 @@ func Other(name string, typ driver.Valuer) *otherBuilder {
 	return ob
 }
 
+// Decimal returns a new Field with type decimal. Decimal fields hold exact
+// fixed-precision numbers (e.g. money) and are represented by decimal.Decimal
+// in the generated code, unless a custom GoType is provided.
+//
+//	field.Decimal("amount").
+//		Precision(10, 2)
+func Decimal(name string) *decimalBuilder {
+	b := &decimalBuilder{desc: &Descriptor{
+		Name: name,
+		Info: &TypeInfo{Type: TypeDecimal},
+	}}
+	b.desc.goType(decimal.Decimal(""), valueScannerType)
+	return b
+}
+
 // stringBuilder is the builder for string fields.
 type stringBuilder struct {
 	desc *Descriptor
@@ -177,6 +197,27 @@ func (b *stringBuilder) Sensitive() *stringBuilder {
 	return b
 }
 
+// Encrypted configures the field to be encrypted at rest in randomized mode.
+// Values are encrypted before they are stored in the database, and decrypted
+// when they are loaded back. Encrypted fields cannot be used in predicates.
+// See the sqlencrypt package for more info.
+//
+//	field.String("ssn").
+//		Encrypted()
+func (b *stringBuilder) Encrypted() *stringBuilder {
+	b.desc.Encryption = &Encryption{}
+	return b
+}
+
+// EncryptedDeterministic is like Encrypted, but configures the field to be
+// encrypted in deterministic mode. That is, the same value is always encrypted
+// to the same ciphertext. This leaks equality of values, but allows using the
+// field in equality predicates (EQ, NEQ, In and NotIn) and unique indexes.
+func (b *stringBuilder) EncryptedDeterministic() *stringBuilder {
+	b.desc.Encryption = &Encryption{Deterministic: true}
+	return b
+}
+
 // Match adds a regex matcher for this field. Operation fails if the regex fails.
 func (b *stringBuilder) Match(re *regexp.Regexp) *stringBuilder {
 	b.desc.Validators = append(b.desc.Validators, func(v string) error {
@@ -322,6 +363,7 @@ func (b *stringBuilder) Descriptor() *Descriptor {
 	if b.desc.Default != nil {
 		b.desc.checkDefaultFunc(stringType)
 	}
+	b.desc.checkEncryption(reflect.String)
 	return b.desc
 }
 
@@ -558,6 +600,21 @@ func (b *bytesBuilder) Sensitive() *bytesBuilder {
 	return b
 }
 
+// Encrypted configures the field to be encrypted at rest in randomized mode.
+// Values are encrypted before they are stored in the database, and decrypted
+// when they are loaded back. Encrypted fields cannot be used in predicates.
+func (b *bytesBuilder) Encrypted() *bytesBuilder {
+	b.desc.Encryption = &Encryption{}
+	return b
+}
+
+// EncryptedDeterministic is like Encrypted, but configures the field to be
+// encrypted in deterministic mode and allows using it in equality predicates.
+func (b *bytesBuilder) EncryptedDeterministic() *bytesBuilder {
+	b.desc.Encryption = &Encryption{Deterministic: true}
+	return b
+}
+
 // Unique makes the field unique within all vertices of this type.
 // Only supported in PostgreSQL.
 func (b *bytesBuilder) Unique() *bytesBuilder {
@@ -670,6 +727,7 @@ func (b *bytesBuilder) Descriptor() *Descriptor {
 	if b.desc.Default != nil {
 		b.desc.checkDefaultFunc(bytesType)
 	}
+	b.desc.checkEncryption(reflect.Slice)
 	return b.desc
 }
 
@@ -1112,6 +1170,142 @@ func (b *otherBuilder) Descriptor() *Descriptor {
 	return b.desc
 }
 
+// decimalBuilder is the builder for decimal fields.
+type decimalBuilder struct {
+	desc             *Descriptor
+	precision, scale int
+}
+
+// Precision sets the precision (total number of digits) and the scale (number of
+// digits after the decimal point) of the column. The database type of the column
+// is derived from these values, unless it was overridden using SchemaType.
+//
+//	field.Decimal("amount").
+//		Precision(10, 2)
+func (b *decimalBuilder) Precision(precision, scale int) *decimalBuilder {
+	if precision <= 0 || scale < 0 || scale > precision {
+		b.desc.Err = fmt.Errorf("invalid precision (%d, %d) for decimal field %q", precision, scale, b.desc.Name)
+		return b
+	}
+	b.precision, b.scale = precision, scale
+	return b
+}
+
+// Unique makes the field unique within all vertices of this type.
+func (b *decimalBuilder) Unique() *decimalBuilder {
+	b.desc.Unique = true
+	return b
+}
+
+// Default sets the default value of the field. For example:
+//
+//	field.Decimal("amount").
+//		Precision(10, 2).
+//		Default(decimal.New(0, 2))
+func (b *decimalBuilder) Default(v any) *decimalBuilder {
+	b.desc.Default = v
+	switch fieldT, defaultT := b.desc.Info.RType.rtype, reflect.TypeOf(v); {
+	case fieldT == defaultT:
+	case defaultT.Kind() == reflect.Func:
+		b.desc.checkDefaultFunc(b.desc.Info.RType.rtype)
+	default:
+		b.desc.Err = fmt.Errorf("expect type (func() %[1]s) or (%[1]s) for decimal default value", b.desc.Info)
+	}
+	return b
+}
+
+// Nillable indicates that this field is a nillable.
+// Unlike "Optional" only fields, "Nillable" fields are pointers in the generated struct.
+func (b *decimalBuilder) Nillable() *decimalBuilder {
+	b.desc.Nillable = true
+	return b
+}
+
+// Optional indicates that this field is optional on create.
+// Unlike edges, fields are required by default.
+func (b *decimalBuilder) Optional() *decimalBuilder {
+	b.desc.Optional = true
+	return b
+}
+
+// Immutable indicates that this field cannot be updated.
+func (b *decimalBuilder) Immutable() *decimalBuilder {
+	b.desc.Immutable = true
+	return b
+}
+
+// Comment sets the comment of the field.
+func (b *decimalBuilder) Comment(c string) *decimalBuilder {
+	b.desc.Comment = c
+	return b
+}
+
+// StructTag sets the struct tag of the field.
+func (b *decimalBuilder) StructTag(s string) *decimalBuilder {
+	b.desc.Tag = s
+	return b
+}
+
+// StorageKey sets the storage key of the field.
+// In SQL dialects is the column name and Gremlin is the property.
+func (b *decimalBuilder) StorageKey(key string) *decimalBuilder {
+	b.desc.StorageKey = key
+	return b
+}
+
+// SchemaType overrides the default database type with a custom
+// schema type (per dialect) for decimal.
+//
+//	field.Decimal("amount").
+//		SchemaType(map[string]string{
+//			dialect.MySQL:		"decimal(5, 2)",
+//			dialect.Postgres: 	"numeric(5, 2)",
+//		})
+func (b *decimalBuilder) SchemaType(types map[string]string) *decimalBuilder {
+	b.desc.SchemaType = types
+	return b
+}
+
+// GoType overrides the default Go type with a custom one.
+// The type must implement the ValueScanner interface.
+//
+//	field.Decimal("amount").
+//		GoType(pkg.Decimal{})
+//
+// Note that, the custom Go type `T` needs to implement the
+// `Add(T) T` method in order to support the `Add<F>` operation
+// in mutations.
+func (b *decimalBuilder) GoType(typ any) *decimalBuilder {
+	b.desc.goType(typ, valueScannerType)
+	return b
+}
+
+// Annotations adds a list of annotations to the field object to be used by
+// codegen extensions.
+//
+//	field.Decimal("amount").
+//		Annotations(entgql.OrderField("AMOUNT"))
+func (b *decimalBuilder) Annotations(annotations ...schema.Annotation) *decimalBuilder {
+	b.desc.Annotations = append(b.desc.Annotations, annotations...)
+	return b
+}
+
+// Descriptor implements the ent.Field interface by returning its descriptor.
+func (b *decimalBuilder) Descriptor() *Descriptor {
+	if b.precision > 0 {
+		types := map[string]string{
+			dialect.MySQL:    fmt.Sprintf("decimal(%d,%d)", b.precision, b.scale),
+			dialect.Postgres: fmt.Sprintf("numeric(%d,%d)", b.precision, b.scale),
+			dialect.SQLite:   fmt.Sprintf("decimal(%d,%d)", b.precision, b.scale),
+		}
+		for name, t := range b.desc.SchemaType {
+			types[name] = t
+		}
+		b.desc.SchemaType = types
+	}
+	return b.desc
+}
+
 // A Descriptor for field configuration.
 type Descriptor struct {
 	Tag           string                  // struct tag.
@@ -1128,12 +1322,19 @@ type Descriptor struct {
 	StorageKey    string                  // sql column or gremlin property.
 	Enums         []struct{ N, V string } // enum values.
 	Sensitive     bool                    // sensitive info string field.
+	Encryption    *Encryption             // encryption at rest.
 	SchemaType    map[string]string       // override the schema type.
 	Annotations   []schema.Annotation     // field annotations.
 	Comment       string                  // field comment.
 	Err           error
 }
 
+// Encryption holds the encryption configuration of a field.
+type Encryption struct {
+	// Deterministic indicates the field is encrypted in deterministic mode.
+	Deterministic bool `json:"deterministic,omitempty"`
+}
+
 func (d *Descriptor) goType(typ any, expectType reflect.Type) {
 	t := reflect.TypeOf(typ)
 	tv := indirect(t)
@@ -1202,6 +1403,51 @@ func methods(t reflect.Type, rtype *RType) {
 	}
 }
 
+// jsonFields returns the exported fields of the given struct type (if it is a struct),
+// following the naming and embedding rules of the encoding/json package. Types that
+// implement their own JSON encoding are not walked, as their layout is unknown.
+func jsonFields(t reflect.Type, visited map[reflect.Type]bool) []*RTypeField {
+	t = indirect(t)
+	if t.Kind() != reflect.Struct || visited[t] || t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return nil
+	}
+	// Avoid infinite recursion on recursive types.
+	visited[t] = true
+	defer delete(visited, t)
+	var fields []*RTypeField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch ft := indirect(f.Type); {
+		case key == "-" && opts == "":
+		// Fields of embedded structs without a JSON name are promoted to the outer struct.
+		case f.Anonymous && key == "" && ft.Kind() == reflect.Struct:
+			fields = append(fields, jsonFields(ft, visited)...)
+		// Fields encoded as JSON strings (",string" option) are skipped.
+		case !f.IsExported() || strings.Contains(opts, "string"):
+		default:
+			if key == "" {
+				key = f.Name
+			}
+			fields = append(fields, &RTypeField{Name: f.Name, Key: key, Type: jsonRType(ft, visited)})
+		}
+	}
+	return fields
+}
+
+// jsonRType returns the RType of a type that is nested in a JSON field.
+func jsonRType(t reflect.Type, visited map[reflect.Type]bool) *RType {
+	rt := &RType{Name: t.Name(), Ident: t.String(), Kind: t.Kind(), PkgPath: t.PkgPath()}
+	switch t.Kind() {
+	case reflect.Struct:
+		rt.Fields = jsonFields(t, visited)
+	case reflect.Slice, reflect.Array:
+		rt.Elem = jsonRType(indirect(t.Elem()), visited)
+	}
+	return rt
+}
+
 func (d *Descriptor) checkDefaultFunc(expectType reflect.Type) {
 	for _, typ := range []reflect.Type{reflect.TypeOf(d.Default), reflect.TypeOf(d.UpdateDefault)} {
 		if typ == nil || typ.Kind() != reflect.Func || d.Err != nil {
@@ -1221,6 +1467,17 @@ func (d *Descriptor) checkDefaultFunc(expectType reflect.Type) {
 	}
 }
 
+// checkEncryption checks that the encryption configuration of the field is valid.
+func (d *Descriptor) checkEncryption(expectKind reflect.Kind) {
+	switch e := d.Encryption; {
+	case e == nil || d.Err != nil:
+	case d.Unique && !e.Deterministic:
+		d.Err = errors.New("unique encrypted fields must use deterministic encryption")
+	case d.Info.ValueScanner() || d.Info.RType != nil && d.Info.RType.Kind != expectKind:
+		d.Err = fmt.Errorf("encrypted fields do not support GoType %s", d.Info)
+	}
+}
+
 var (
 	boolType         = reflect.TypeOf(false)
 	bytesType        = reflect.TypeOf([]byte(nil))
@@ -1228,6 +1485,9 @@ var (
 	stringType       = reflect.TypeOf("")
 	valuerType       = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
 	valueScannerType = reflect.TypeOf((*ValueScanner)(nil)).Elem()
+	// JSON encoding interfaces.
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
 )
 
 // ValueScanner is the interface that groups the Value and the Scan methods.
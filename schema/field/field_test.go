@@ -22,9 +22,11 @@ import (
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/dialect"
 	"github.com/jogly/ent/schema/field"
+	"github.com/jogly/ent/schema/field/decimal"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInt(t *testing.T) {
@@ -154,6 +156,48 @@ func TestFloat(t *testing.T) {
 	assert.Error(t, fd.Err)
 }
 
+func TestDecimal(t *testing.T) {
+	fd := field.Decimal("price").
+		Precision(10, 2).
+		Default(decimal.New(0, 2)).
+		Comment("comment").
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "price", fd.Name)
+	assert.Equal(t, field.TypeDecimal, fd.Info.Type)
+	assert.Equal(t, "decimal.Decimal", fd.Info.String())
+	assert.Equal(t, "github.com/jogly/ent/schema/field/decimal", fd.Info.PkgPath)
+	assert.True(t, fd.Info.ValueScanner())
+	assert.Equal(t, decimal.Decimal("0.00"), fd.Default)
+	assert.Equal(t, "comment", fd.Comment)
+	assert.Equal(t, map[string]string{
+		dialect.MySQL:    "decimal(10,2)",
+		dialect.Postgres: "numeric(10,2)",
+		dialect.SQLite:   "decimal(10,2)",
+	}, fd.SchemaType)
+
+	fd = field.Decimal("price").
+		Precision(10, 2).
+		SchemaType(map[string]string{dialect.Postgres: "money"}).
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, "money", fd.SchemaType[dialect.Postgres])
+	assert.Equal(t, "decimal(10,2)", fd.SchemaType[dialect.MySQL])
+
+	fd = field.Decimal("price").Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Empty(t, fd.SchemaType)
+
+	fd = field.Decimal("price").Default(func() decimal.Decimal { return "1" }).Descriptor()
+	assert.NoError(t, fd.Err)
+	fd = field.Decimal("price").Default("1").Descriptor()
+	assert.Error(t, fd.Err)
+	fd = field.Decimal("price").Precision(2, 3).Descriptor()
+	assert.Error(t, fd.Err)
+	fd = field.Decimal("price").GoType(1.5).Descriptor()
+	assert.Error(t, fd.Err)
+}
+
 func TestBool(t *testing.T) {
 	fd := field.Bool("active").Default(true).Comment("comment").Immutable().Descriptor()
 	assert.Equal(t, "active", fd.Name)
@@ -394,6 +438,30 @@ func TestString(t *testing.T) {
 	assert.Error(t, fd.Err)
 }
 
+func TestField_Encrypted(t *testing.T) {
+	fd := field.String("ssn").Encrypted().Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.Equal(t, &field.Encryption{}, fd.Encryption)
+
+	fd = field.String("email").EncryptedDeterministic().Unique().Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.True(t, fd.Encryption.Deterministic)
+
+	fd = field.String("dir").GoType(http.Dir("dir")).Encrypted().Descriptor()
+	assert.NoError(t, fd.Err)
+
+	fd = field.Bytes("secret").Encrypted().Optional().Descriptor()
+	assert.NoError(t, fd.Err)
+	assert.NotNil(t, fd.Encryption)
+
+	fd = field.String("ssn").Encrypted().Unique().Descriptor()
+	assert.EqualError(t, fd.Err, "unique encrypted fields must use deterministic encryption")
+	fd = field.String("name").GoType(&sql.NullString{}).Encrypted().Descriptor()
+	assert.Error(t, fd.Err)
+	fd = field.Bytes("ip").GoType(net.IP("127.0.0.1")).EncryptedDeterministic().Descriptor()
+	assert.NoError(t, fd.Err)
+}
+
 func TestTime(t *testing.T) {
 	now := time.Now()
 	fd := field.Time("created_at").
@@ -470,6 +538,47 @@ func TestJSON(t *testing.T) {
 	assert.Equal(t, "T", fd.Info.RType.Name)
 	assert.Equal(t, "github.com/jogly/ent/schema/field_test", fd.Info.RType.PkgPath)
 
+	type (
+		Limits struct {
+			Max  int      `json:"max"`
+			Rate *float64 `json:"rate,omitempty"`
+		}
+		Base struct {
+			ID string `json:"id"`
+		}
+		Meta struct {
+			Base
+			Plan    string
+			Limits  Limits    `json:"limits"`
+			Tags    []string  `json:"tags"`
+			Created time.Time `json:"created"`
+			Count   int64     `json:"count,string"`
+			Skipped string    `json:"-"`
+			Next    *Meta     `json:"next"`
+			hidden  string
+		}
+	)
+	fd = field.JSON("meta", &Meta{}).
+		Descriptor()
+	assert.NoError(t, fd.Err)
+	fields := fd.Info.RType.Fields
+	require.Len(t, fields, 6)
+	assert.Equal(t, &field.RTypeField{Name: "ID", Key: "id", Type: fields[0].Type}, fields[0])
+	assert.Equal(t, reflect.String, fields[0].Type.Kind)
+	assert.Equal(t, "Plan", fields[1].Key)
+	assert.Equal(t, "limits", fields[2].Key)
+	assert.Equal(t, "Limits", fields[2].Type.Name)
+	require.Len(t, fields[2].Type.Fields, 2)
+	assert.Equal(t, "max", fields[2].Type.Fields[0].Key)
+	assert.Equal(t, reflect.Int, fields[2].Type.Fields[0].Type.Kind)
+	assert.Equal(t, reflect.Float64, fields[2].Type.Fields[1].Type.Kind, "pointers are indirected")
+	assert.Equal(t, reflect.Slice, fields[3].Type.Kind)
+	assert.Equal(t, reflect.String, fields[3].Type.Elem.Kind)
+	assert.Equal(t, "created", fields[4].Key)
+	assert.Empty(t, fields[4].Type.Fields, "types with custom encoding are not walked")
+	assert.Equal(t, "next", fields[5].Key)
+	assert.Empty(t, fields[5].Type.Fields, "recursive types are not walked")
+
 	fd = field.JSON("dir", http.Dir("dir")).
 		Optional().
 		Descriptor()
@@ -781,7 +890,7 @@ func TestTypeString(t *testing.T) {
 	assert.Equal(t, "bool", typ.String())
 	typ = field.TypeInvalid
 	assert.Equal(t, "invalid", typ.String())
-	typ = 21
+	typ = 22
 	assert.Equal(t, "invalid", typ.String())
 }
 
@@ -790,6 +899,10 @@ func TestTypeNumeric(t *testing.T) {
 	assert.False(t, typ.Numeric())
 	typ = field.TypeUint8
 	assert.True(t, typ.Numeric())
+	typ = field.TypeDecimal
+	assert.True(t, typ.Numeric())
+	assert.False(t, typ.Integer())
+	assert.False(t, typ.Float())
 }
 
 func TestTypeValid(t *testing.T) {
@@ -797,7 +910,7 @@ func TestTypeValid(t *testing.T) {
 	assert.True(t, typ.Valid())
 	typ = 0
 	assert.False(t, typ.Valid())
-	typ = 21
+	typ = 22
 	assert.False(t, typ.Valid())
 }
 
@@ -810,6 +923,8 @@ func TestTypeConstName(t *testing.T) {
 	assert.Equal(t, "TypeInt64", typ.ConstName())
 	typ = field.TypeOther
 	assert.Equal(t, "TypeOther", typ.ConstName())
-	typ = 21
+	typ = field.TypeDecimal
+	assert.Equal(t, "TypeDecimal", typ.ConstName())
+	typ = 22
 	assert.Equal(t, "invalid", typ.ConstName())
 }
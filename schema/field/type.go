@@ -36,6 +36,7 @@ const (
 	TypeUint64
 	TypeFloat32
 	TypeFloat64
+	TypeDecimal
 	endTypes
 )
 
@@ -57,9 +58,14 @@ func (t Type) Float() bool {
 	return t == TypeFloat32 || t == TypeFloat64
 }
 
+// Decimal reports if the given type is a fixed-precision decimal type.
+func (t Type) Decimal() bool {
+	return t == TypeDecimal
+}
+
 // Integer reports if the given type is an integral type.
 func (t Type) Integer() bool {
-	return t.Numeric() && !t.Float()
+	return t.Numeric() && !t.Float() && !t.Decimal()
 }
 
 // Valid reports if the given type if known type.
@@ -173,6 +179,7 @@ var (
 		TypeUint64:  "uint64",
 		TypeFloat32: "float32",
 		TypeFloat64: "float64",
+		TypeDecimal: "decimal",
 	}
 	constNames = [...]string{
 		TypeJSON:  "TypeJSON",
@@ -192,10 +199,23 @@ type RType struct {
 	Kind    reflect.Kind
 	PkgPath string
 	Methods map[string]struct{ In, Out []*RType }
+	// Fields and Elem are set only for the Go types of JSON fields,
+	// and hold the exported struct fields and the element type of
+	// slices and arrays (after pointer indirection).
+	Fields []*RTypeField `json:",omitempty"`
+	Elem   *RType        `json:",omitempty"`
 	// Used only for in-package checks.
 	rtype reflect.Type
 }
 
+// RTypeField holds a serializable information of an exported
+// struct field. Used by the entc package for JSON fields.
+type RTypeField struct {
+	Name string // Go name of the field.
+	Key  string // JSON key of the field.
+	Type *RType
+}
+
 // TypeEqual reports if the underlying type is equal to the RType (after pointer indirections).
 func (r *RType) TypeEqual(t reflect.Type) bool {
 	tv := indirect(t)
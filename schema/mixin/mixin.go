@@ -88,6 +88,28 @@ func (Time) Fields() []ent.Field {
 // time mixin must implement `Mixin` interface.
 var _ ent.Mixin = (*Time)(nil)
 
+// Version adds a version field that is used as the concurrency
+// token of the schema. See field.Version for more info.
+type Version struct{ Schema }
+
+// Fields of the version mixin.
+func (Version) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("version").
+			Default(1),
+	}
+}
+
+// Annotations of the version mixin.
+func (Version) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		field.Version("version"),
+	}
+}
+
+// version mixin must implement `Mixin` interface.
+var _ ent.Mixin = (*Version)(nil)
+
 // AnnotateFields adds field annotations to underlying mixin fields.
 func AnnotateFields(m ent.Mixin, annotations ...schema.Annotation) ent.Mixin {
 	return fieldAnnotator{Mixin: m, annotations: annotations}
@@ -10,6 +10,7 @@ import (
 	"github.com/jogly/ent"
 	"github.com/jogly/ent/schema"
 	"github.com/jogly/ent/schema/edge"
+	"github.com/jogly/ent/schema/field"
 	"github.com/jogly/ent/schema/mixin"
 
 	"github.com/stretchr/testify/assert"
@@ -45,6 +46,17 @@ func TestTimeMixin(t *testing.T) {
 	})
 }
 
+func TestVersionMixin(t *testing.T) {
+	fields := mixin.Version{}.Fields()
+	require.Len(t, fields, 1)
+	desc := fields[0].Descriptor()
+	assert.Equal(t, "version", desc.Name)
+	assert.Equal(t, int64(1), desc.Default)
+	annotations := mixin.Version{}.Annotations()
+	require.Len(t, annotations, 1)
+	assert.Equal(t, "version", annotations[0].(*field.Annotation).Version)
+}
+
 type annotation string
 
 func (annotation) Name() string { return "" }